@@ -9,6 +9,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
 	"github.com/Martindeeepdark/go-start/pkg/wizard"
 	"github.com/spf13/cobra"
 )
@@ -82,23 +83,31 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Normalize architecture type
 	archType = strings.ToLower(archType)
 
+	// Default options for non-interactive mode
+	config := &wizard.ProjectConfig{
+		ProjectName:  projectName,
+		Module:       module,
+		Architecture: archType,
+		Description:  "",
+		Database:     "mysql",
+		WithAuth:     archType != "ddd", // DDD 模板暂不包含认证系统
+		WithSwagger:  true,
+		WithRedis:    true,
+		ServerPort:   8080,
+	}
+
 	// Generate project based on architecture
 	switch archType {
 	case "mvc":
-		if err := generateMVCProjectWithOptions(projectDir, &wizard.ProjectConfig{
-			ProjectName: projectName,
-			Module:      module,
-			Description: "",
-			Database:    "mysql",
-			WithAuth:    true,
-			WithSwagger: true,
-			WithRedis:   true,
-			ServerPort:  8080,
-		}); err != nil {
+		if err := generateMVCProjectWithOptions(projectDir, config); err != nil {
+			return err
+		}
+	case "ddd":
+		if err := generateDDDProjectWithOptions(projectDir, config); err != nil {
 			return err
 		}
 	default:
-		return fmt.Errorf("unsupported architecture: %s (supported: mvc)", archType)
+		return fmt.Errorf("unsupported architecture: %s (supported: mvc, ddd)", archType)
 	}
 
 	fmt.Printf("✓ Project %s created successfully!\n", projectName)
@@ -144,7 +153,9 @@ func runWizardMode() error {
 			return err
 		}
 	case "ddd":
-		return fmt.Errorf("DDD 架构尚未实现，请选择 MVC 架构")
+		if err := generateDDDProjectWithOptions(projectDir, config); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("不支持的架构类型: %s", config.Architecture)
 	}
@@ -290,13 +301,18 @@ require (
 }
 
 func generateFileFromTemplate(projectDir, outputPath, templateName string, data interface{}) error {
+	return generateArchFileFromTemplate(projectDir, "mvc", outputPath, templateName, data)
+}
+
+// generateArchFileFromTemplate renders a template of the given architecture (mvc, ddd)
+func generateArchFileFromTemplate(projectDir, arch, outputPath, templateName string, data interface{}) error {
 	// Try embedded templates first
-	templatePath := filepath.Join("templates", "mvc", templateName)
+	templatePath := filepath.Join("templates", arch, templateName)
 
 	templateContent, err := fs.ReadFile(templatesFS, templatePath)
 	if err != nil {
 		// Fallback to filesystem
-		fallbackPath := filepath.Join(getTemplateDir(), "mvc", templateName)
+		fallbackPath := filepath.Join(getTemplateDir(), arch, templateName)
 		templateContent, err = os.ReadFile(fallbackPath)
 		if err != nil {
 			return fmt.Errorf("failed to read template %s: %w", templateName, err)
@@ -535,7 +551,7 @@ func generateMVCProjectWithOptions(projectDir string, config *wizard.ProjectConf
 		ProjectName: config.ProjectName,
 		Module:      config.Module, // 使用完整的模块路径
 		Description: config.Description,
-		Database:    config.Database,
+		Database:    databaseDriver(config.Database),
 		WithAuth:    config.WithAuth,
 		WithSwagger: config.WithSwagger,
 		WithRedis:   config.WithRedis,
@@ -583,13 +599,29 @@ func generateMVCProjectWithOptions(projectDir string, config *wizard.ProjectConf
 
 	// Generate swagger files if enabled
 	if config.WithSwagger {
-		// TODO: 生成 Swagger 配置
+		if err := generateSwaggerDocs(projectDir, config); err != nil {
+			return fmt.Errorf("生成 Swagger 文档失败: %w", err)
+		}
 		fmt.Println("  ✓ Swagger 文档已配置")
 	}
 
 	return nil
 }
 
+// generateSwaggerDocs generates the docs package (OpenAPI document + embedded Swagger UI).
+// The document only describes /health; gen db --openapi and spec generate regenerate it from the models.
+func generateSwaggerDocs(projectDir string, config *wizard.ProjectConfig) error {
+	doc := openapi.New(config.ProjectName+" API", "1.0.0", config.Description)
+
+	health := &openapi.Operation{Summary: "健康检查", OperationID: "Health"}
+	health.Success("服务正常", nil)
+	if err := doc.AddOperation("GET", "/health", health); err != nil {
+		return err
+	}
+
+	return openapi.WriteDocs(filepath.Join(projectDir, "docs"), doc)
+}
+
 // generateGoModWithOptions generates go.mod with wizard options
 func generateGoModWithOptions(projectDir string, config *wizard.ProjectConfig) error {
 	modContent := fmt.Sprintf(`module %s
@@ -616,19 +648,115 @@ require (
 		modContent += "\tgolang.org/x/crypto v0.31.0\n"
 	}
 
-	// Add Swagger if enabled
-	if config.WithSwagger {
-		modContent += "\tgithub.com/swaggo/files v1.0.1\n"
-		modContent += "\tgithub.com/swaggo/gin-swagger v1.6.0\n"
-		modContent += "\tgithub.com/swaggo/swag v1.16.3\n"
-	}
-
 	// Close the require block
 	modContent += ")\n"
 
 	return os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte(modContent), 0644)
 }
 
+// generateDDDProjectWithOptions generates DDD project with wizard options
+func generateDDDProjectWithOptions(projectDir string, config *wizard.ProjectConfig) error {
+	// The DDD templates have no auth system yet
+	if config.WithAuth {
+		return fmt.Errorf("DDD 架构暂不支持认证系统，请关闭认证选项后重试（向导中选择不需要认证系统）")
+	}
+
+	// Create directory structure
+	dirs := []string{
+		"cmd/server",
+		"internal/domain/user",
+		"internal/application/user",
+		"internal/infrastructure/persistence/user",
+		"internal/interface/http/user",
+		"config",
+		"pkg/cache",
+		"pkg/database",
+		"pkg/httpx/middleware",
+		"pkg/httpx/response",
+		"pkg/httpx/router",
+	}
+
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(projectDir, dir), 0755); err != nil {
+			return fmt.Errorf("创建目录 %s 失败: %w", dir, err)
+		}
+	}
+
+	// Template data
+	data := struct {
+		ProjectName string
+		Module      string
+		Description string
+		Database    string
+		WithAuth    bool
+		WithSwagger bool
+		WithRedis   bool
+		ServerPort  int
+	}{
+		ProjectName: config.ProjectName,
+		Module:      config.Module,
+		Description: config.Description,
+		Database:    databaseDriver(config.Database),
+		WithAuth:    config.WithAuth,
+		WithSwagger: config.WithSwagger,
+		WithRedis:   config.WithRedis,
+		ServerPort:  config.ServerPort,
+	}
+
+	// Generate go.mod
+	if err := generateGoModWithOptions(projectDir, config); err != nil {
+		return err
+	}
+
+	// Template files to generate
+	templateFiles := map[string]string{
+		"cmd/server/main.go":                                          "main.go.tpl",
+		"config/config.go":                                            "config/config.go.tpl",
+		"config.yaml.example":                                         "config.yaml.tpl",
+		"internal/domain/user/user.go":                                "internal/domain/user/User.go.tpl",
+		"internal/domain/user/repository.go":                          "internal/domain/user/repository.go.tpl",
+		"internal/application/user/service.go":                        "internal/application/user/service.go.tpl",
+		"internal/application/apps.go":                                "internal/application/apps.go.tpl",
+		"internal/infrastructure/persistence/user/user_repository.go": "internal/infrastructure/persistence/user/UserRepositoryImpl.go.tpl",
+		"internal/infrastructure/persistence/repos.go":                "internal/infrastructure/persistence/repos.go.tpl",
+		"internal/interface/http/user/controller.go":                  "internal/interface/http/user/controller.go.tpl",
+		"internal/interface/http/controllers.go":                      "internal/interface/http/controllers.go.tpl",
+		"internal/interface/http/routes.go":                           "internal/interface/http/routes.go.tpl",
+		"README.md":                                                   "README.md.tpl",
+		".gitignore":                                                  "gitignore.tpl",
+	}
+
+	// Generate template files
+	for outputPath, templateName := range templateFiles {
+		if err := generateArchFileFromTemplate(projectDir, "ddd", outputPath, templateName, data); err != nil {
+			return fmt.Errorf("生成 %s 失败: %w", outputPath, err)
+		}
+	}
+
+	// Copy pkg files from go-start
+	if err := copyPkgFiles(projectDir); err != nil {
+		return fmt.Errorf("复制 pkg 文件失败: %w", err)
+	}
+
+	// Generate swagger files if enabled
+	if config.WithSwagger {
+		if err := generateSwaggerDocs(projectDir, config); err != nil {
+			return fmt.Errorf("生成 Swagger 文档失败: %w", err)
+		}
+		fmt.Println("  ✓ Swagger 文档已配置")
+	}
+
+	return nil
+}
+
+// databaseDriver maps the wizard database choice to the pkg/database driver name
+func databaseDriver(db string) string {
+	if db == "postgresql" {
+		return "postgres"
+	}
+	return db
+}
+
 func getArchitectureLabel(arch string) string {
 	labels := map[string]string{
 		"mvc": "MVC (Model-View-Controller)",
@@ -19,6 +19,8 @@ var (
 	genConfig       string
 	genArchitecture string // 架构类型：mvc 或 ddd
 	genModule       string // Go 模块路径
+	genOpenAPI      bool   // 是否生成 OpenAPI 文档与 Swagger UI
+	genClient       bool   // 是否生成 Go 客户端包
 )
 
 func newGenCmd() *cobra.Command {
@@ -49,8 +51,17 @@ func newGenCmd() *cobra.Command {
   # 使用通配符
   go-start gen db --dsn="..." --tables="user*"
 
+  # 使用配置文件（可提交到仓库，保证生成结果可复现）
+  go-start gen db --config=gen.yaml
+
   # 从 SQL 文件生成
-  go-start gen sql --file=schema.sql`,
+  go-start gen sql --file=schema.sql
+
+  # 同时生成 OpenAPI 文档与 Swagger UI（/swagger/index.html）
+  go-start gen sql --file=schema.sql --openapi
+
+  # 同时生成供其他 Go 服务调用的 client 包
+  go-start gen sql --file=schema.sql --client`,
 	}
 
 	cmd.AddCommand(newGenDbCmd())
@@ -71,10 +82,12 @@ func newGenDbCmd() *cobra.Command {
 	cmd.Flags().StringVar(&genDSN, "dsn", "", "数据库连接字符串 (必填)")
 	cmd.Flags().StringVar(&genTables, "tables", "", "要生成的表名，逗号分隔 (如: users,articles)，支持通配符 (user*)")
 	cmd.Flags().BoolVar(&genInteractive, "interactive", false, "交互式选择表（推荐）")
-	cmd.Flags().StringVar(&genConfig, "config", "", "从配置文件读取表列表")
+	cmd.Flags().StringVar(&genConfig, "config", "", "gen.yaml 配置文件（表筛选与表级/列级覆盖）")
 	cmd.Flags().StringVar(&genOutput, "output", "./internal", "输出目录")
 	cmd.Flags().StringVar(&genArchitecture, "arch", "mvc", "架构类型 (mvc 或 ddd)")
 	cmd.Flags().StringVar(&genModule, "module", "", "Go 模块路径 (如: github.com/user/my-api)")
+	cmd.Flags().BoolVar(&genOpenAPI, "openapi", false, "生成 OpenAPI 3.1 文档 (docs/openapi.yaml) 并注册 Swagger UI 路由")
+	cmd.Flags().BoolVar(&genClient, "client", false, "生成 Go 客户端包 (client/)，供其他服务调用")
 
 	return cmd
 }
@@ -84,25 +97,45 @@ func newGenSqlCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sql",
 		Short: "从 SQL DDL 文件生成 CRUD 代码",
-		Long:  "解析 SQL DDL 文件，生成完整的 CRUD 代码",
-		RunE:  runGenSql,
+		Long: `解析 SQL DDL 文件，生成完整的 CRUD 代码（无需连接数据库）
+
+支持 MySQL 与 PostgreSQL 的 DDL：
+  - CREATE TABLE（列定义、主键、唯一键、普通索引）
+  - CREATE [UNIQUE] INDEX ... ON table (...)
+  - ALTER TABLE ... ADD CONSTRAINT ... PRIMARY KEY / UNIQUE
+  - COMMENT ON TABLE / COLUMN
+
+示例：
+  go-start gen sql --file=schema.sql
+  go-start gen sql --file=schema.sql --tables="user*" --module=github.com/user/my-api`,
+		RunE: runGenSql,
 	}
 
 	cmd.Flags().StringVar(&genSQLFile, "file", "", "SQL 文件路径 (必填)")
+	cmd.Flags().StringVar(&genTables, "tables", "", "要生成的表名，逗号分隔，支持通配符 (默认生成全部表)")
 	cmd.Flags().StringVar(&genOutput, "output", "./internal", "输出目录")
+	cmd.Flags().StringVar(&genModule, "module", "", "Go 模块路径 (如: github.com/user/my-api)")
+	cmd.Flags().BoolVar(&genOpenAPI, "openapi", false, "生成 OpenAPI 3.1 文档 (docs/openapi.yaml) 并注册 Swagger UI 路由")
+	cmd.Flags().BoolVar(&genClient, "client", false, "生成 Go 客户端包 (client/)，供其他服务调用")
+	cmd.Flags().StringVar(&genConfig, "config", "", "gen.yaml 配置文件（表筛选与表级/列级覆盖）")
 
 	return cmd
 }
 
 func runGenDb(cmd *cobra.Command, args []string) error {
+	// 读取配置文件（命令行参数优先）
+	options, err := loadGenConfig(cmd)
+	if err != nil {
+		return err
+	}
+
 	// 验证参数
 	if genDSN == "" {
-		return fmt.Errorf("请提供数据库连接字符串 (--dsn)")
+		return fmt.Errorf("请提供数据库连接字符串 (--dsn 或配置文件中的 dsn)")
 	}
 
 	// 解析要生成的表列表
 	var tables []string
-	var err error
 
 	if genInteractive {
 		// 交互式模式
@@ -114,11 +147,11 @@ func runGenDb(cmd *cobra.Command, args []string) error {
 			fmt.Println("❌ 未选择任何表，操作已取消")
 			return nil
 		}
-	} else if genConfig != "" {
-		// 从配置文件读取
-		tables, err = loadTablesFromConfig(genConfig)
+	} else if options != nil {
+		// 从配置文件读取（--tables 可覆盖配置文件中的 tables，exclude 仍然生效）
+		tables, err = loadTablesFromConfig(genDSN, options, parseTables(genTables))
 		if err != nil {
-			return fmt.Errorf("读取配置文件失败: %w", err)
+			return err
 		}
 	} else if genTables != "" {
 		// 命令行指定
@@ -146,20 +179,30 @@ func runGenDb(cmd *cobra.Command, args []string) error {
 	// 根据架构类型创建生成器
 	if genArchitecture == "ddd" {
 		// DDD 架构
+		if genOpenAPI || options != nil && options.OpenAPI {
+			fmt.Println("⚠️  DDD 架构暂不支持生成 OpenAPI 文档，已跳过")
+		}
+		if genClient || options != nil && options.Client {
+			fmt.Println("⚠️  DDD 架构暂不支持生成 Go 客户端，已跳过")
+		}
 		generator := gen.NewDDDGenerator(gen.Config{
-			DSN:    genDSN,
-			Tables: tables,
-			Output: genOutput,
-			Module: genModule,
+			DSN:     genDSN,
+			Tables:  tables,
+			Output:  genOutput,
+			Module:  genModule,
+			Options: options,
 		})
 		err = generator.Generate()
 	} else {
 		// MVC 架构（默认）
 		generator := gen.NewDatabaseGenerator(gen.Config{
-			DSN:    genDSN,
-			Tables: tables,
-			Output: genOutput,
-			Module: genModule,
+			DSN:     genDSN,
+			Tables:  tables,
+			Output:  genOutput,
+			Module:  genModule,
+			OpenAPI: genOpenAPI,
+			Client:  genClient,
+			Options: options,
 		})
 		err = generator.Generate()
 	}
@@ -188,6 +231,11 @@ func runGenDb(cmd *cobra.Command, args []string) error {
 }
 
 func runGenSql(cmd *cobra.Command, args []string) error {
+	options, err := loadGenConfig(cmd)
+	if err != nil {
+		return err
+	}
+
 	if genSQLFile == "" {
 		return fmt.Errorf("请提供 SQL 文件路径 (--file)")
 	}
@@ -200,7 +248,12 @@ func runGenSql(cmd *cobra.Command, args []string) error {
 
 	generator := gen.NewSQLGenerator(gen.Config{
 		SQLFile: genSQLFile,
+		Tables:  parseTables(genTables),
 		Output:  genOutput,
+		Module:  genModule,
+		OpenAPI: genOpenAPI,
+		Client:  genClient,
+		Options: options,
 	})
 
 	if err := generator.Generate(); err != nil {
@@ -208,6 +261,12 @@ func runGenSql(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Print("\n✅ 代码生成完成！\n")
+	fmt.Print("\n📦 已生成:\n")
+	fmt.Println("  ✓ Model (数据模型)")
+	fmt.Println("  ✓ Repository (数据访问层 + CRUD + 高级查询)")
+	fmt.Println("  ✓ Service (业务逻辑层 + 缓存)")
+	fmt.Println("  ✓ Controller (HTTP 处理器 + RESTful API)")
+	fmt.Println("  ✓ Routes (路由注册)")
 
 	return nil
 }
@@ -365,9 +424,58 @@ func parseInt(s string) int {
 	return i
 }
 
-func loadTablesFromConfig(filename string) ([]string, error) {
-	// TODO: 实现配置文件解析
-	return []string{}, fmt.Errorf("配置文件功能尚未实现")
+// loadGenConfig 读取 --config 指定的 gen.yaml，并把其中的 dsn/output/module/arch
+// 作为未在命令行显式指定的参数的默认值。未指定 --config 时返回 nil。
+func loadGenConfig(cmd *cobra.Command) (*gen.GenConfig, error) {
+	if genConfig == "" {
+		return nil, nil
+	}
+
+	cfg, err := gen.LoadGenConfig(genConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := cmd.Flags()
+	if cfg.DSN != "" && flags.Lookup("dsn") != nil && !flags.Changed("dsn") {
+		genDSN = cfg.DSN
+	}
+	if cfg.Output != "" && !flags.Changed("output") {
+		genOutput = cfg.Output
+	}
+	if cfg.Module != "" && !flags.Changed("module") {
+		genModule = cfg.Module
+	}
+	if cfg.Arch != "" && flags.Lookup("arch") != nil && !flags.Changed("arch") {
+		genArchitecture = cfg.Arch
+	}
+
+	fmt.Printf("📝 使用配置文件: %s\n", genConfig)
+
+	return cfg, nil
+}
+
+// loadTablesFromConfig 按配置文件的 tables/exclude 规则从数据库中筛选表
+func loadTablesFromConfig(dsn string, cfg *gen.GenConfig, patterns []string) ([]string, error) {
+	allTables, err := gen.ListTables(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("获取表列表失败: %w", err)
+	}
+
+	var names []string
+	for _, t := range allTables {
+		names = append(names, t.Name)
+	}
+
+	tables, err := cfg.ResolveTables(names, patterns)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("没有需要生成的表，请检查配置文件中的 tables/exclude")
+	}
+
+	return tables, nil
 }
 
 // expandTableWildcards 展开表名通配符
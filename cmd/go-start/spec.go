@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+	"github.com/Martindeeepdark/go-start/pkg/sdk"
 	"github.com/Martindeeepdark/go-start/pkg/spec"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +16,17 @@ var (
 	specFile  string
 	specDir   string
 	outputDir string
+
+	specTargets string // spec generate 的生成目标
+
+	openapiOutput string // spec openapi 的输出文件
+
+	importOpenAPI string // spec import 读取的 OpenAPI 文档
+	importOutput  string // spec import 生成的规范文件
+	importModule  string // spec import 生成的项目模块名
+
+	clientLang   string // spec client 生成的语言
+	clientOutput string // spec client 的输出文件
 )
 
 func newSpecCmd() *cobra.Command {
@@ -26,23 +40,44 @@ func newSpecCmd() *cobra.Command {
   - 自动生成 Repository、Service、Controller
   - 生成路由注册代码
   - 生成请求验证器
+  - 生成 OpenAPI 3.1 文档与 Swagger UI
+  - 生成 TypeScript 与 Go 客户端
+  - 生成 .proto 与 gRPC 服务（--target=grpc）
 
 示例：
   # 从单个规范文件生成代码
   go-start spec generate --file=blog.spec.yaml
 
+  # 同时生成 HTTP 与 gRPC 服务
+  go-start spec generate --file=blog.spec.yaml --target=http,grpc
+
   # 从目录批量生成
   go-start spec generate --dir=./specs
 
   # 验证规范文件
   go-start spec validate --file=blog.spec.yaml
 
+  # 导出 OpenAPI 文档（.json 后缀输出 JSON）
+  go-start spec openapi --file=blog.spec.yaml --output=openapi.yaml
+
+  # 生成 TypeScript 客户端
+  go-start spec client --file=blog.spec.yaml --lang=ts --output=web/src/api/client.ts
+
+  # 生成 Go 客户端包
+  go-start spec client --file=blog.spec.yaml --lang=go --output=client
+
+  # 从 OpenAPI 文档导入规范文件
+  go-start spec import --openapi=api.yaml --output=api.spec.yaml
+
   # 创建规范文件示例
   go-start spec init`,
 	}
 
 	cmd.AddCommand(newSpecGenerateCmd())
 	cmd.AddCommand(newSpecValidateCmd())
+	cmd.AddCommand(newSpecOpenAPICmd())
+	cmd.AddCommand(newSpecImportCmd())
+	cmd.AddCommand(newSpecClientCmd())
 	cmd.AddCommand(newSpecInitCmd())
 
 	return cmd
@@ -59,6 +94,7 @@ func newSpecGenerateCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&specFile, "file", "f", "", "规范文件路径")
 	cmd.Flags().StringVarP(&specDir, "dir", "d", "", "规范文件目录（批量生成）")
 	cmd.Flags().StringVarP(&outputDir, "output", "o", ".", "输出目录")
+	cmd.Flags().StringVarP(&specTargets, "target", "t", spec.TargetHTTP, "生成目标：http、grpc，逗号分隔")
 
 	return cmd
 }
@@ -76,6 +112,53 @@ func newSpecValidateCmd() *cobra.Command {
 	return cmd
 }
 
+func newSpecOpenAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "导出 OpenAPI 文档",
+		Long:  "根据规范文件生成 OpenAPI 3.1 文档，包含模型、请求验证规则、端点、认证要求与分页参数",
+		RunE:  runSpecOpenAPI,
+	}
+
+	cmd.Flags().StringVarP(&specFile, "file", "f", "", "规范文件路径（必填）")
+	cmd.Flags().StringVarP(&openapiOutput, "output", "o", "openapi.yaml", "输出文件（.json 后缀输出 JSON，否则输出 YAML）")
+
+	return cmd
+}
+
+func newSpecClientCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client",
+		Short: "生成客户端 SDK",
+		Long:  "根据规范文件生成调用接口的客户端：模型与请求的类型定义，每个端点一个方法，自动携带 Bearer Token 并解包统一响应结构",
+		RunE:  runSpecClient,
+	}
+
+	cmd.Flags().StringVarP(&specFile, "file", "f", "", "规范文件路径（必填）")
+	cmd.Flags().StringVarP(&clientLang, "lang", "l", "ts", "客户端语言: ts 或 go")
+	cmd.Flags().StringVarP(&clientOutput, "output", "o", "", "输出路径 (默认: ts 为 client.ts，go 为 client 目录，包名取目录名)")
+
+	return cmd
+}
+
+func newSpecImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "从 OpenAPI 文档导入规范文件",
+		Long: `把 OpenAPI 3.0/3.1 文档（YAML 或 JSON）转换为规范文件：
+  - components.schemas 中的对象转换为模型
+  - 接口转换为端点，security 决定是否需要认证，operationId 作为 handler
+  - 请求体转换为请求验证定义，schema 约束映射为 validator 规则`,
+		RunE: runSpecImport,
+	}
+
+	cmd.Flags().StringVar(&importOpenAPI, "openapi", "", "OpenAPI 文档路径（必填）")
+	cmd.Flags().StringVarP(&importOutput, "output", "o", "api.spec.yaml", "输出的规范文件")
+	cmd.Flags().StringVarP(&importModule, "module", "m", "", "Go 模块名 (默认: github.com/yourname/<文档标题>)")
+
+	return cmd
+}
+
 func newSpecInitCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init",
@@ -111,6 +194,9 @@ func runSpecGenerate(cmd *cobra.Command, args []string) error {
 
 		// 生成代码
 		generator := spec.NewGenerator(s, outputDir)
+		if err := generator.SetTargets(specTargets); err != nil {
+			return err
+		}
 		if err := generator.Generate(); err != nil {
 			return err
 		}
@@ -140,6 +226,9 @@ func runSpecGenerate(cmd *cobra.Command, args []string) error {
 			fmt.Printf("[%d/%d] 生成 %s...\n", i+1, len(specs), s.Name)
 
 			generator := spec.NewGenerator(s, outputDir)
+			if err := generator.SetTargets(specTargets); err != nil {
+				return fmt.Errorf("生成 %s 失败: %w", s.Name, err)
+			}
 			if err := generator.Generate(); err != nil {
 				return fmt.Errorf("生成 %s 失败: %w", s.Name, err)
 			}
@@ -182,6 +271,11 @@ func runSpecValidate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  作者: %s\n", s.Project.Author)
 	fmt.Printf("\n📦 统计:")
 	fmt.Printf("  模型数量: %d\n", len(s.Models))
+	relations := 0
+	for _, model := range s.Models {
+		relations += len(model.Relations)
+	}
+	fmt.Printf("  关联数量: %d\n", relations)
 	fmt.Printf("  端点数量: %d\n", len(s.APIs))
 	fmt.Printf("  验证器数量: %d\n", len(s.Requests))
 	fmt.Printf("  业务规则数量: %d\n", len(s.Rules))
@@ -189,6 +283,134 @@ func runSpecValidate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSpecOpenAPI(cmd *cobra.Command, args []string) error {
+	if specFile == "" {
+		return fmt.Errorf("请使用 --file 参数指定规范文件")
+	}
+
+	s, err := spec.New("").ParseFile(specFile)
+	if err != nil {
+		return fmt.Errorf("解析规范文件失败: %w", err)
+	}
+
+	doc, err := spec.BuildOpenAPI(s)
+	if err != nil {
+		return fmt.Errorf("生成 OpenAPI 文档失败: %w", err)
+	}
+	if err := openapi.WriteFile(openapiOutput, doc); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ OpenAPI 文档已生成: %s\n", openapiOutput)
+	fmt.Printf("  模型: %d  请求: %d  端点: %d\n", len(s.Models), len(s.Requests), len(s.APIs))
+	return nil
+}
+
+func runSpecClient(cmd *cobra.Command, args []string) error {
+	if specFile == "" {
+		return fmt.Errorf("请使用 --file 参数指定规范文件")
+	}
+
+	s, err := spec.New("").ParseFile(specFile)
+	if err != nil {
+		return fmt.Errorf("解析规范文件失败: %w", err)
+	}
+	doc, err := spec.BuildOpenAPI(s)
+	if err != nil {
+		return fmt.Errorf("生成 OpenAPI 文档失败: %w", err)
+	}
+
+	output := clientOutput
+	switch strings.ToLower(clientLang) {
+	case "ts", "typescript":
+		if output == "" {
+			output = "client.ts"
+		}
+		content, err := sdk.TypeScript(doc)
+		if err != nil {
+			return fmt.Errorf("生成客户端失败: %w", err)
+		}
+		if dir := filepath.Dir(output); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("创建目录失败: %w", err)
+			}
+		}
+		if err := os.WriteFile(output, content, 0644); err != nil {
+			return fmt.Errorf("写入文件失败: %w", err)
+		}
+	case "go", "golang":
+		if output == "" {
+			output = "client"
+		}
+		if err := sdk.WriteGo(output, doc); err != nil {
+			return fmt.Errorf("生成客户端失败: %w", err)
+		}
+	default:
+		return fmt.Errorf("不支持的客户端语言 %q (支持: ts, go)", clientLang)
+	}
+
+	fmt.Printf("✅ 客户端已生成: %s\n", output)
+	fmt.Printf("  类型: %d  方法: %d\n", len(s.Models)+len(s.Requests), len(s.APIs))
+	return nil
+}
+
+func runSpecImport(cmd *cobra.Command, args []string) error {
+	if importOpenAPI == "" {
+		return fmt.Errorf("请使用 --openapi 参数指定 OpenAPI 文档")
+	}
+
+	doc, err := openapi.ReadFile(importOpenAPI)
+	if err != nil {
+		return err
+	}
+
+	moduleName := importModule
+	if moduleName == "" {
+		moduleName = "github.com/yourname/" + projectSlug(doc.Info.Title)
+	}
+
+	s, err := spec.ImportOpenAPI(doc, moduleName)
+	if err != nil {
+		return fmt.Errorf("导入 OpenAPI 文档失败: %w", err)
+	}
+
+	if _, err := os.Stat(importOutput); err == nil {
+		fmt.Printf("⚠️  文件 %s 已存在\n", importOutput)
+		fmt.Print("是否覆盖？(y/n): ")
+		var confirm string
+		fmt.Scanln(&confirm)
+		if confirm != "y" && confirm != "Y" {
+			fmt.Println("❌ 操作已取消")
+			return nil
+		}
+	}
+	if err := spec.WriteFile(importOutput, s); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 规范文件已生成: %s\n", importOutput)
+	fmt.Printf("  模型: %d  请求: %d  端点: %d\n", len(s.Models), len(s.Requests), len(s.APIs))
+	fmt.Println("\n🚀 下一步:")
+	fmt.Printf("  1. 检查模型的表名、字段类型与索引\n")
+	fmt.Printf("  2. go-start spec generate --file=%s\n", importOutput)
+
+	return nil
+}
+
+// projectSlug 由文档标题生成项目名，如 Blog API → blog-api
+func projectSlug(title string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	}) {
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return "api"
+	}
+	return strings.Join(words, "-")
+}
+
 func runSpecInit(cmd *cobra.Command, args []string) error {
 	fmt.Println("📝 创建规范文件示例...")
 
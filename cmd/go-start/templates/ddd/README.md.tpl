@@ -29,10 +29,10 @@
 go mod tidy
 
 # 复制配置文件
-cp config/config.yaml.example config/config.yaml
+cp config.yaml.example config.yaml
 
 # 编辑配置
-vim config/config.yaml
+vim config.yaml
 
 # 运行服务
 go run cmd/server/main.go
@@ -1,10 +1,10 @@
 server:
-  port: 8080
+  port: {{.ServerPort}}
 
 database:
-  driver: mysql
+  driver: {{.Database}}
   host: localhost
-  port: 3306
+  port: {{if eq .Database "postgres"}}5432{{else}}3306{{end}}
   database: {{.ProjectName}}
   username: root
   password: ""
@@ -15,7 +15,7 @@ database:
   max_open_conns: 100
   conn_max_lifetime: 3600
   log_level: info
-
+{{if .WithRedis}}
 redis:
   host: localhost
   port: 6379
@@ -27,3 +27,4 @@ redis:
   read_timeout: 3
   write_timeout: 3
   pool_timeout: 4
+{{end}}
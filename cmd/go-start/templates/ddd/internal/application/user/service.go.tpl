@@ -4,16 +4,16 @@ import (
 	"context"
 	"fmt"
 
-	"{{.Module}}/internal/domain/user"
+	domainuser "{{.Module}}/internal/domain/user"
 )
 
 // UserService 用户应用服务
 type UserService struct {
-	userRepo user.UserRepository
+	userRepo domainuser.UserRepository
 }
 
 // NewUserService 创建用户应用服务
-func NewUserService(userRepo user.UserRepository) *UserService {
+func NewUserService(userRepo domainuser.UserRepository) *UserService {
 	return &UserService{
 		userRepo: userRepo,
 	}
@@ -22,7 +22,7 @@ func NewUserService(userRepo user.UserRepository) *UserService {
 // CreateUser 创建用户用例
 func (s *UserService) CreateUser(ctx context.Context, username, email, password string, age int) error {
 	// 1. 创建领域对象
-	u, err := user.NewUser(username, email, password, age)
+	u, err := domainuser.NewUser(username, email, password, age)
 	if err != nil {
 		return fmt.Errorf("创建用户失败: %w", err)
 	}
@@ -1,21 +1,22 @@
 package persistence
 
 import (
-	"{{.Module}}/internal/domain/user"
-	"{{.Module}}/internal/infrastructure/persistence/user"
 	"gorm.io/gorm"
+
+	domainuser "{{.Module}}/internal/domain/user"
+	persistenceuser "{{.Module}}/internal/infrastructure/persistence/user"
 )
 
 // Repositories 仓储集合
 type Repositories struct {
-	User user.UserRepository
+	User domainuser.UserRepository
 	// TODO: 添加其他聚合的仓储
 }
 
 // NewRepositories 初始化所有仓储
 func NewRepositories(db *gorm.DB, cache interface{}) *Repositories {
 	return &Repositories{
-		User: user.NewUserRepositoryImpl(db),
+		User: persistenceuser.NewUserRepositoryImpl(db),
 		// TODO: 初始化其他仓储
 	}
 }
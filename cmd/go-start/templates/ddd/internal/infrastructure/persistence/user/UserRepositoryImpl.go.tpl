@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
-	"{{.Module}}/internal/domain/user"
+	domainuser "{{.Module}}/internal/domain/user"
 )
 
 // UserRepositoryImpl 用户仓储实现
@@ -14,12 +14,12 @@ type UserRepositoryImpl struct {
 }
 
 // NewUserRepositoryImpl 创建用户仓储实现
-func NewUserRepositoryImpl(db *gorm.DB) user.UserRepository {
+func NewUserRepositoryImpl(db *gorm.DB) domainuser.UserRepository {
 	return &UserRepositoryImpl{db: db}
 }
 
 // Save 保存用户
-func (r *UserRepositoryImpl) Save(ctx context.Context, u *user.User) error {
+func (r *UserRepositoryImpl) Save(ctx context.Context, u *domainuser.User) error {
 	// TODO: 领域模型与数据模型转换
 	// dataModel := r.toDataModel(u)
 	// return r.db.WithContext(ctx).Save(dataModel).Error
@@ -27,7 +27,7 @@ func (r *UserRepositoryImpl) Save(ctx context.Context, u *user.User) error {
 }
 
 // FindByID 根据 ID 查找用户
-func (r *UserRepositoryImpl) FindByID(ctx context.Context, id uint) (*user.User, error) {
+func (r *UserRepositoryImpl) FindByID(ctx context.Context, id uint) (*domainuser.User, error) {
 	// TODO: 查询并转换为领域模型
 	// var dataModel UserDataModel
 	// err := r.db.WithContext(ctx).First(&dataModel, id).Error
@@ -39,12 +39,12 @@ func (r *UserRepositoryImpl) FindByID(ctx context.Context, id uint) (*user.User,
 }
 
 // FindByEmail 根据邮箱查找用户
-func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*domainuser.User, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
 // FindAll 查找所有用户
-func (r *UserRepositoryImpl) FindAll(ctx context.Context) ([]*user.User, error) {
+func (r *UserRepositoryImpl) FindAll(ctx context.Context) ([]*domainuser.User, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
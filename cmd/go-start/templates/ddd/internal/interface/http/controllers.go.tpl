@@ -2,18 +2,19 @@ package http
 
 import (
 	"{{.Module}}/internal/application"
+	"{{.Module}}/internal/interface/http/user"
 )
 
 // Controllers 控制器集合
 type Controllers struct {
-	User *UserController
+	User *user.UserController
 	// TODO: 添加其他控制器
 }
 
 // NewControllers 初始化所有控制器
 func NewControllers(apps *application.Applications) *Controllers {
 	return &Controllers{
-		User: NewUserController(apps.User),
+		User: user.NewUserController(apps.User),
 		// TODO: 初始化其他控制器
 	}
 }
@@ -1,21 +1,20 @@
 package user
 
 import (
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"{{.Module}}/internal/application/user"
+	appuser "{{.Module}}/internal/application/user"
 	"{{.Module}}/pkg/httpx/response"
 )
 
 // UserController 用户控制器
 type UserController struct {
-	userService *user.UserService
+	userService *appuser.UserService
 }
 
 // NewUserController 创建用户控制器
-func NewUserController(userService *user.UserService) *UserController {
+func NewUserController(userService *appuser.UserService) *UserController {
 	return &UserController{
 		userService: userService,
 	}
@@ -31,12 +30,12 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.Error(ctx, http.StatusBadRequest, "参数错误: "+err.Error())
+		response.Error(ctx, response.CodeInvalidParams, "参数错误: "+err.Error())
 		return
 	}
 
 	if err := c.userService.CreateUser(ctx, req.Username, req.Email, req.Password, req.Age); err != nil {
-		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		response.Error(ctx, response.CodeInternalError, err.Error())
 		return
 	}
 
@@ -48,7 +47,7 @@ func (c *UserController) UpdateEmail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		response.Error(ctx, http.StatusBadRequest, "无效的ID")
+		response.Error(ctx, response.CodeInvalidParams, "无效的ID")
 		return
 	}
 
@@ -57,12 +56,12 @@ func (c *UserController) UpdateEmail(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		response.Error(ctx, http.StatusBadRequest, "参数错误: "+err.Error())
+		response.Error(ctx, response.CodeInvalidParams, "参数错误: "+err.Error())
 		return
 	}
 
 	if err := c.userService.UpdateUserEmail(ctx, uint(id), req.Email); err != nil {
-		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		response.Error(ctx, response.CodeInternalError, err.Error())
 		return
 	}
 
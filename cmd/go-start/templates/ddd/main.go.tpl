@@ -14,13 +14,16 @@ import (
 	"{{.Module}}/config"
 	"{{.Module}}/internal/application"
 	"{{.Module}}/internal/infrastructure/persistence"
-	"{{.Module}}/internal/interface/http"
+	httpapi "{{.Module}}/internal/interface/http"
 	"{{.Module}}/pkg/cache"
 	"{{.Module}}/pkg/database"
 	httpx "{{.Module}}/pkg/httpx/middleware"
 	"{{.Module}}/pkg/httpx/response"
 	"{{.Module}}/pkg/httpx/router"
 	"go.uber.org/zap"
+	{{if .WithSwagger}}
+	"{{.Module}}/docs"
+	{{end}}
 )
 
 // @title           {{.ProjectName}} API (DDD)
@@ -44,23 +47,27 @@ func main() {
 	defer logger.Sync()
 
 	// Initialize database
-	db, err := database.New(cfg.Database)
+	db, err := database.New(&cfg.Database)
 	if err != nil {
 		logger.Fatal("Failed to connect database", zap.Error(err))
 	}
 	defer db.Close()
 	logger.Info("Database connected successfully")
 
+	{{if .WithRedis}}
 	// Initialize Redis
-	cacheClient, err := cache.New(cfg.Redis)
+	cacheClient, err := cache.New(&cfg.Redis)
 	if err != nil {
 		logger.Fatal("Failed to connect redis", zap.Error(err))
 	}
 	defer cacheClient.Close()
 	logger.Info("Redis connected successfully")
+	{{else}}
+	var cacheClient *cache.Cache
+	{{end}}
 
 	// Initialize Infrastructure Layer (Repositories)
-	repos := persistence.NewRepositories(db, cacheClient)
+	repos := persistence.NewRepositories(db.DB(), cacheClient)
 	logger.Info("Infrastructure layer initialized")
 
 	// Initialize Application Layer (Services)
@@ -68,7 +75,7 @@ func main() {
 	logger.Info("Application layer initialized")
 
 	// Initialize Interface Layer (Controllers)
-	controllers := http.NewControllers(applications)
+	controllers := httpapi.NewControllers(applications)
 	logger.Info("Interface layer initialized")
 
 	// Initialize router
@@ -81,19 +88,29 @@ func main() {
 	)
 
 	// Register routes
-	http.RegisterRoutes(r, controllers)
+	httpapi.RegisterRoutes(r.Engine(), controllers)
 
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
 		response.Success(c, gin.H{"status": "ok", "architecture": "DDD"})
 	})
 
+	{{if .WithSwagger}}
+	// Swagger UI: /swagger/index.html
+	docs.Register(r.Engine())
+	{{end}}
+
 	// Start server
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
 	logger.Info("Starting DDD server", zap.String("addr", addr))
 
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: r.Engine(),
+	}
+
 	go func() {
-		if err := r.Run(addr); err != nil && err != http.ErrServerClosed {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Fatal("Failed to start server", zap.Error(err))
 		}
 	}()
@@ -108,7 +125,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := r.Engine().Shutdown(ctx); err != nil {
+	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("Server forced to shutdown", zap.Error(err))
 	}
 
@@ -22,8 +22,7 @@ import (
 	"{{.Module}}/pkg/httpx/router"
 	"go.uber.org/zap"
 	{{if .WithSwagger}}
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"{{.Module}}/docs"
 	{{end}}
 )
 
@@ -178,8 +177,8 @@ func registerRoutes(r *router.Router, ctrl *controller.Controller) {
 	})
 
 	{{if .WithSwagger}}
-	// Swagger documentation
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Swagger UI: /swagger/index.html
+	docs.Register(r.Engine())
 	{{end}}
 
 	// API v1
@@ -8,7 +8,7 @@ import (
 )
 
 // GenerateApplicationPackage 生成 application 包
-func (g *DatabaseGenerator) GenerateApplicationPackage(modulePath string, modelNames []string) error {
+func (g *DatabaseGenerator) GenerateApplicationPackage(modulePath string) error {
 	outputDir := filepath.Join(g.config.Output, "internal/application")
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("创建 application 目录失败: %w", err)
@@ -48,8 +48,8 @@ var (
 	DB          *gorm.DB
 	Cache       *cache.Cache
 	{{range .ModelInfos}}
-	{{.LowerCamelCase}}Repo  repository.{{.Name}}Repo
-	{{.LowerCamelCase}}Service *service.{{.Name}}Service
+	{{if .HasRepository}}{{.LowerCamelCase}}Repo  repository.{{.Name}}Repo{{end}}
+	{{if .HasService}}{{.LowerCamelCase}}Service *service.{{.Name}}Service{{end}}
 	{{end}}
 )
 
@@ -129,16 +129,16 @@ func initCache() {
 
 // initRepositories 初始化 Repository 层
 func initRepositories() {
-	{{range .ModelInfos}}
+	{{range .ModelInfos}}{{if .HasRepository}}
 	{{.LowerCamelCase}}Repo = repository.New{{.Name}}Repository(DB)
-	{{end}}
+	{{end}}{{end}}
 }
 
 // initServices 初始化 Service 层
 func initServices() {
-	{{range .ModelInfos}}
-	{{.LowerCamelCase}}Service = service.New{{.Name}}Service({{.LowerCamelCase}}Repo, DB, Cache)
-	{{end}}
+	{{range .ModelInfos}}{{if .HasService}}
+	{{.LowerCamelCase}}Service = service.New{{.Name}}Service({{.LowerCamelCase}}Repo, DB{{if .WithCache}}, Cache{{end}})
+	{{end}}{{end}}
 }
 
 // initControllers 初始化 Controller 层
@@ -149,9 +149,9 @@ func initControllers() {
 // GetControllers 获取所有 Controller
 func GetControllers() *routes.Controllers {
 	return &routes.Controllers{
-		{{range .ModelInfos}}
+		{{range .ModelInfos}}{{if .HasController}}
 		{{.Name}}: controller.New{{.Name}}Controller({{.LowerCamelCase}}Service),
-		{{end}}
+		{{end}}{{end}}
 	}
 }
 
@@ -193,16 +193,27 @@ func getIntEnv(key string, defaultValue int) int {
 	}
 	defer f.Close()
 
-	// 准备模型名称和小驼峰名称的映射
+	// 准备模型名称、小驼峰名称及各层是否生成的映射
 	type ModelInfo struct {
 		Name           string
 		LowerCamelCase string
+		HasRepository  bool
+		HasService     bool
+		HasController  bool
+		WithCache      bool
 	}
 	var modelInfos []ModelInfo
-	for _, name := range modelNames {
+	var modelNames []string
+	for _, table := range g.config.Tables {
+		opts := g.options(table)
+		modelNames = append(modelNames, opts.ModelName)
 		modelInfos = append(modelInfos, ModelInfo{
-			Name:           name,
-			LowerCamelCase: toLowerCamelCaseMain(name),
+			Name:           opts.ModelName,
+			LowerCamelCase: toLowerCamelCaseMain(opts.ModelName),
+			HasRepository:  opts.has(LayerRepository),
+			HasService:     opts.has(LayerService),
+			HasController:  opts.has(LayerController),
+			WithCache:      opts.Cache,
 		})
 	}
 
@@ -0,0 +1,64 @@
+package gen
+
+// batchTmpl 批量操作的公共定义，所有仓储共用（生成到 internal/repository/batch.go）
+const batchTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Repository: 批量操作
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBatchSize 单次批量操作允许的最大记录数
+const MaxBatchSize = 1000
+
+// createBatchSize CreateBatch 每批插入的记录数
+const createBatchSize = 100
+
+var (
+	// ErrInvalidBatch 批量操作的记录数为 0 或超过 MaxBatchSize
+	ErrInvalidBatch = fmt.Errorf("批量操作的记录数必须在 1 到 %d 之间", MaxBatchSize)
+
+	// ErrInvalidItem 批量操作中的记录为空或缺少主键
+	ErrInvalidItem = errors.New("无效的记录")
+)
+
+// ItemError 批量操作中第 Index 条记录（从 0 开始）的错误
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("第 %d 条记录: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError 批量操作中出错的记录；返回 BatchError 时整批记录均未写入
+type BatchError []*ItemError
+
+// Add 记录第 index 条记录的错误
+func (e *BatchError) Add(index int, err error) {
+	*e = append(*e, &ItemError{Index: index, Err: err})
+}
+
+func (e BatchError) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d 条记录出错，%v 等", len(e), e[0])
+}
+
+// CheckBatchSize 校验批量操作的记录数
+func CheckBatchSize(n int) error {
+	if n <= 0 || n > MaxBatchSize {
+		return ErrInvalidBatch
+	}
+	return nil
+}
+`
@@ -0,0 +1,223 @@
+package gen
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/internal/buildtest"
+)
+
+// batchErrorTest 在生成项目的 repository 包中运行的测试：记录数校验与 BatchError 的汇总
+const batchErrorTest = `package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckBatchSize(t *testing.T) {
+	for n, wantErr := range map[int]bool{-1: true, 0: true, 1: false, MaxBatchSize: false, MaxBatchSize + 1: true} {
+		if err := CheckBatchSize(n); (err != nil) != wantErr || (err != nil && !errors.Is(err, ErrInvalidBatch)) {
+			t.Errorf("CheckBatchSize(%d) = %v, wantErr %v", n, err, wantErr)
+		}
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	var batchErr BatchError
+	batchErr.Add(2, ErrInvalidItem)
+	if got, want := batchErr.Error(), "第 2 条记录: 无效的记录"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	cause := errors.New("唯一约束冲突")
+	batchErr.Add(5, cause)
+	if got, want := batchErr.Error(), "2 条记录出错，第 2 条记录: 无效的记录 等"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if len(batchErr) != 2 || batchErr[1].Index != 5 || !errors.Is(batchErr[1], cause) {
+		t.Errorf("BatchError = %v, want items 2 and 5", batchErr)
+	}
+
+	var err error = batchErr
+	var got BatchError
+	if !errors.As(err, &got) || len(got) != 2 {
+		t.Errorf("errors.As(%v) = %v, want BatchError", err, got)
+	}
+}
+`
+
+// batchControllerTest 在生成项目的 controller 包中运行的测试：记录数超限返回 400；
+// 部分记录校验失败或事务回滚时返回 422 并列出每条出错记录，且不写入任何记录
+const batchControllerTest = `package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example.com/app/internal/dal/model"
+	"example.com/app/internal/repository"
+	"example.com/app/internal/repository/mock"
+	"example.com/app/internal/service"
+	"example.com/app/pkg/cache"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type batchItemError struct {
+	Index  int ` + "`json:\"index\"`" + `
+	Status int ` + "`json:\"status\"`" + `
+}
+
+func TestUsersController_Batch(t *testing.T) {
+	tooMany := "[" + strings.Repeat(` + "`" + `{"email": "a@example.com"},` + "`" + `, repository.MaxBatchSize) + ` + "`" + `{"email": "b@example.com"}]` + "`" + `
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		repoErr    error // 批量写入（事务）返回的错误
+		wantStatus int
+		wantErrors []batchItemError
+		wantWrites int
+	}{
+		{name: "empty", method: http.MethodPost, body: "[]", wantStatus: http.StatusBadRequest},
+		{name: "too many", method: http.MethodPost, body: tooMany, wantStatus: http.StatusBadRequest},
+		{
+			name: "create partial failure", method: http.MethodPost,
+			body:       ` + "`" + `[{"email": "a@example.com"}, null, {"email": "dup@example.com"}]` + "`" + `,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: []batchItemError{{1, http.StatusBadRequest}, {2, http.StatusConflict}},
+		},
+		{
+			name: "create", method: http.MethodPost,
+			body:       ` + "`" + `[{"email": "a@example.com"}, {"email": "b@example.com"}]` + "`" + `,
+			wantStatus: http.StatusOK, wantWrites: 1,
+		},
+		{
+			name: "update partial failure", method: http.MethodPatch,
+			body:       ` + "`" + `[{"id": 1, "level": 2}, {"level": 2}, {"id": 9, "level": 2}]` + "`" + `,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: []batchItemError{{1, http.StatusBadRequest}, {2, http.StatusNotFound}},
+		},
+		{
+			name: "update rolled back", method: http.MethodPatch,
+			body:       ` + "`" + `[{"id": 1, "level": 2}, {"id": 2, "level": 2}]` + "`" + `,
+			repoErr:    repository.BatchError{{Index: 1, Err: repository.ErrVersionConflict}},
+			wantStatus: http.StatusUnprocessableEntity, wantWrites: 1,
+			wantErrors: []batchItemError{{1, http.StatusConflict}},
+		},
+		{
+			name: "delete rolled back", method: http.MethodDelete,
+			body:       ` + "`" + `[{"id": 1}, {"id": 2}]` + "`" + `,
+			repoErr:    repository.BatchError{{Index: 0, Err: gorm.ErrRecordNotFound}},
+			wantStatus: http.StatusUnprocessableEntity, wantWrites: 1,
+			wantErrors: []batchItemError{{0, http.StatusNotFound}},
+		},
+		{
+			name: "delete failed", method: http.MethodDelete,
+			body:       ` + "`" + `[{"id": 1}]` + "`" + `,
+			repoErr:    errors.New("connection reset"),
+			wantStatus: http.StatusInternalServerError, wantWrites: 1,
+		},
+	}
+	writes := map[string]string{http.MethodPost: "CreateBatch", http.MethodPatch: "UpdateBatch", http.MethodDelete: "DeleteBatch"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write := func(ctx context.Context, userss []*model.Users) error { return tt.repoErr }
+			repo := &mock.UsersRepo{
+				GetByIDFunc: func(ctx context.Context, id uint64, preloads ...string) (*model.Users, error) {
+					if id == 9 {
+						return nil, gorm.ErrRecordNotFound
+					}
+					return &model.Users{ID: id, Version: 1}, nil
+				},
+				ByEmailUnscopedFunc: func(ctx context.Context, email string, preloads ...string) (*model.Users, error) {
+					if email == "dup@example.com" {
+						return &model.Users{ID: 7, Email: email}, nil
+					}
+					return nil, gorm.ErrRecordNotFound
+				},
+				CreateBatchFunc: write,
+				UpdateBatchFunc: write,
+				DeleteBatchFunc: write,
+			}
+			ctrl := NewUsersController(service.NewUsersService(repo, nil, cache.New()))
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/users/batch", ctrl.CreateBatch)
+			r.PATCH("/users/batch", ctrl.UpdateBatch)
+			r.DELETE("/users/batch", ctrl.DeleteBatch)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/users/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			var resp struct {
+				Data struct {
+					Errors []batchItemError ` + "`json:\"errors\"`" + `
+				} ` + "`json:\"data\"`" + `
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if !reflect.DeepEqual(resp.Data.Errors, tt.wantErrors) {
+				t.Errorf("errors = %+v, want %+v", resp.Data.Errors, tt.wantErrors)
+			}
+			if got := repo.Calls(writes[tt.method]); got != tt.wantWrites {
+				t.Errorf("%s called %d times, want %d", writes[tt.method], got, tt.wantWrites)
+			}
+		})
+	}
+}
+`
+
+// TestBatch 验证生成的批量操作：记录数校验、BatchError 汇总、部分失败与事务回滚时的响应，
+// 以及仓储层的批量写入都在事务中执行
+func TestBatch(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `email` varchar(100) NOT NULL,\n" +
+		"  `level` tinyint NOT NULL DEFAULT 1, `version` int unsigned NOT NULL DEFAULT 0, `deleted_at` datetime NULL,\n" +
+		"  PRIMARY KEY (`id`), UNIQUE KEY `uk_email` (`email`));"
+	dir := generateSQL(t, ddl, nil)
+
+	decls := funcDecls(t, dir, "internal/repository/users.go")
+	for _, method := range []string{"CreateBatch", "UpdateBatch", "DeleteBatch"} {
+		fn := decls["UsersRepository."+method]
+		if fn == nil {
+			t.Errorf("UsersRepository.%s not generated", method)
+			continue
+		}
+		transactional := false
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == "Transaction" {
+				transactional = true
+			}
+			return true
+		})
+		if !transactional {
+			t.Errorf("UsersRepository.%s does not run in a transaction", method)
+		}
+	}
+
+	for name, content := range map[string]string{
+		"internal/repository/batch_gen_test.go": batchErrorTest,
+		"internal/controller/batch_gen_test.go": batchControllerTest,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buildtest.Go(t, dir, testModule, "test", "-run", "CheckBatchSize|BatchError|Batch$", "./internal/repository/", "./internal/controller/")
+}
@@ -0,0 +1,28 @@
+package gen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Martindeeepdark/go-start/pkg/sdk"
+)
+
+// client 是否生成 Go 客户端包（--client 或 gen.yaml 中的 client: true）
+func (g *DatabaseGenerator) client() bool {
+	return g.config.Client || g.config.Options != nil && g.config.Options.Client
+}
+
+// GenerateClient 生成 client 包：接口与 OpenAPI 文档一致，结构体由模型结构生成，
+// 不依赖 internal 包，其他服务可以直接导入 <module>/client 调用
+func (g *DatabaseGenerator) GenerateClient() error {
+	doc, err := g.buildOpenAPI()
+	if err != nil {
+		return err
+	}
+	if err := sdk.WriteGo(filepath.Join(g.config.Output, "client"), doc); err != nil {
+		return err
+	}
+
+	fmt.Println("     ✓ client 包创建成功")
+	return nil
+}
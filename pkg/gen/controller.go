@@ -10,15 +10,24 @@ import (
 
 // ControllerConfig Controller 配置
 type ControllerConfig struct {
-	TableName   string // 表名
-	ModelName   string // 模型名称
-	PackageName string // 包名
-	ModulePath  string // 模块路径
+	TableName   string             // 表名
+	ModelName   string             // 模型名称
+	PackageName string             // 包名
+	ModulePath  string             // 模块路径
+	Key         PrimaryKey         // 主键
+	SoftDelete  bool               // 是否使用软删除，生成恢复、回收站和永久删除接口
+	ListColumns []ListColumn       // List 接口可过滤、排序、选择的列（用于接口文档）
+	Cursor      *ListColumn        // 游标分页使用的列，非 nil 时 List 按游标分页
+	Version     *ListColumn        // 乐观锁版本列，非 nil 时返回 ETag 并支持 If-Match
+	Finders     []ForeignKeyFinder // 外键查询方法（只生成带子资源路由的方法）
+	WithCache   bool               // Service 是否启用缓存（生成测试时用于构造 Service）
+	Uniques     []IndexFinder      // 唯一索引（生成唯一约束冲突的测试）
 }
 
 // GenerateController 生成 Controller 层代码
 func (g *DatabaseGenerator) GenerateController(table TableInfo, config ControllerConfig) error {
 	fmt.Printf("  📦 生成 %s Controller...\n", config.ModelName)
+	config.Key = config.Key.orDefault()
 
 	// 创建输出目录
 	outputDir := filepath.Join(g.config.Output, "internal/controller")
@@ -34,6 +43,11 @@ func (g *DatabaseGenerator) GenerateController(table TableInfo, config Controlle
 		return err
 	}
 
+	// 生成 httptest 测试
+	if err := g.GenerateControllerTest(config); err != nil {
+		return err
+	}
+
 	fmt.Printf("     ✓ %sController 创建成功\n", config.ModelName)
 	return nil
 }
@@ -47,12 +61,20 @@ func (g *DatabaseGenerator) renderControllerTemplate(outputPath string, config C
 package controller
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"{{.ModulePath}}/internal/model"
+	"github.com/gin-gonic/gin/binding"
+	"{{.ModulePath}}/internal/dal/model"
+	"{{.ModulePath}}/internal/repository"
 	"{{.ModulePath}}/internal/service"
+	{{- if .SoftDelete}}
+	"{{.ModulePath}}/pkg/httpx/middleware"
+	{{- end}}
 	"{{.ModulePath}}/pkg/httpx/response"
 )
 
@@ -88,6 +110,7 @@ func New{{.ModelName}}Controller(service *service.{{.ModelName}}Service) *{{.Mod
 // @Produce json
 // @Param {{ToLowerCamelCase .ModelName}} body model.{{.ModelName}} true "{{.ModelName}}信息"
 // @Success 200 {object} response.Response
+// @Failure 409 {object} response.Response "唯一约束冲突"
 // @Router /api/v1/{{ToLowerCamelCase .ModelName}}s [post]
 func (c *{{.ModelName}}Controller) Create(ctx *gin.Context) {
 	var {{ToLowerCamelCase .ModelName}} model.{{.ModelName}}
@@ -97,60 +120,77 @@ func (c *{{.ModelName}}Controller) Create(ctx *gin.Context) {
 	}
 
 	if err := c.service.Create(ctx, &{{ToLowerCamelCase .ModelName}}); err != nil {
+		if errors.Is(err, service.Err{{.ModelName}}AlreadyExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
 		response.Error(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	response.Success(ctx, gin.H{"id": {{ToLowerCamelCase .ModelName}}.ID})
+	response.Success(ctx, gin.H{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}"{{$f.Param}}": {{ToLowerCamelCase $.ModelName}}.{{$f.FieldName}}{{end -}} })
 }
 
 // GetByID 获取 {{.ModelName}} 详情
 //
 // @Summary 获取{{.ModelName}}详情
-// @Description 根据 ID 获取{{.ModelName}}详细信息
+// @Description 根据主键获取{{.ModelName}}详细信息
 // @Tags {{.ModelName}}
 // @Accept json
 // @Produce json
-// @Param id path int true "{{.ModelName}} ID"
-// @Success 200 {object} response.Response
-// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/{id} [get]
+{{template "keyParams" .}}
+// @Param preload query []string false "预加载的关联，可重复传入" collectionFormat(multi)
+// @Success 200 {object} response.Response{{if .Version}}
+// @Header 200 {string} ETag "当前版本（{{.Version.Column}}），更新时通过 If-Match 传回"{{end}}
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s{{.Key.SwaggerRoute}} [get]
 func (c *{{.ModelName}}Controller) GetByID(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		response.Error(ctx, http.StatusBadRequest, "无效的ID")
+	{{.Key.Args}}, ok := c.parseKey(ctx)
+	if !ok {
 		return
 	}
 
-	{{ToLowerCamelCase .ModelName}}, err := c.service.GetByID(ctx, uint(id))
+	{{ToLowerCamelCase .ModelName}}, err := c.service.GetByID(ctx, {{.Key.Args}}, ctx.QueryArray("preload")...)
 	if err != nil {
 		if err == service.Err{{.ModelName}}NotFound {
 			response.Error(ctx, http.StatusNotFound, err.Error())
 			return
 		}
+		if errors.Is(err, repository.ErrUnknownPreload) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
 		response.Error(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
+{{- if .Version}}
 
+	ctx.Header("ETag", repository.ETag({{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}))
+{{- end}}
 	response.Success(ctx, {{ToLowerCamelCase .ModelName}})
 }
 
 // Update 更新 {{.ModelName}}
 //
 // @Summary 更新{{.ModelName}}
-// @Description 更新指定的{{.ModelName}}
+// @Description 更新指定的{{.ModelName}}{{if .Version}}；按 {{.Version.Column}} 乐观锁，期望版本取自 If-Match 请求头或请求体中的 {{.Version.JSON}}，都未提供时以当前版本为准{{end}}
 // @Tags {{.ModelName}}
 // @Accept json
 // @Produce json
-// @Param id path int true "{{.ModelName}} ID"
+{{template "keyParams" .}}
+{{- if .Version}}
+// @Param If-Match header string false "GetByID 返回的 ETag，如 W/\"3\""
+{{- end}}
 // @Param {{ToLowerCamelCase .ModelName}} body model.{{.ModelName}} true "{{.ModelName}}信息"
 // @Success 200 {object} response.Response
-// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/{id} [put]
+// @Failure 404 {object} response.Response "记录不存在"
+// @Failure 409 {object} response.Response "唯一约束冲突{{if .Version}}，或请求体中的版本号已过期{{end}}"
+{{- if .Version}}
+// @Failure 412 {object} response.Response "If-Match 与当前版本不一致"
+{{- end}}
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s{{.Key.SwaggerRoute}} [put]
 func (c *{{.ModelName}}Controller) Update(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		response.Error(ctx, http.StatusBadRequest, "无效的ID")
+	{{.Key.Args}}, ok := c.parseKey(ctx)
+	if !ok {
 		return
 	}
 
@@ -160,34 +200,326 @@ func (c *{{.ModelName}}Controller) Update(ctx *gin.Context) {
 		return
 	}
 
-	{{ToLowerCamelCase .ModelName}}.ID = uint(id)
+{{- range .Key.Fields}}
+	{{ToLowerCamelCase $.ModelName}}.{{.FieldName}} = {{.Var}}
+{{- end}}
+{{- if .Version}}
+	version, ok := c.ifMatch(ctx)
+	if !ok {
+		return
+	}
+	if version != 0 {
+		{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}} = version
+	}
+{{- end}}
 	if err := c.service.Update(ctx, &{{ToLowerCamelCase .ModelName}}); err != nil {
+		if errors.Is(err, service.Err{{.ModelName}}NotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, service.Err{{.ModelName}}AlreadyExists) {
+			response.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+{{- if .Version}}
+		if errors.Is(err, service.ErrConcurrentModification) {
+			c.versionConflict(ctx, err, version != 0)
+			return
+		}
+{{- end}}
 		response.Error(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
+{{- if .Version}}
 
+	ctx.Header("ETag", repository.ETag({{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}))
+{{- end}}
 	response.Success(ctx, nil)
 }
 
+// Patch 部分更新 {{.ModelName}}
+//
+// @Summary 部分更新{{.ModelName}}
+// @Description 只更新请求体中出现的字段（指定 update_mask 时只更新 mask 中的字段），未出现的字段保持不变；主键与 created_at/updated_at 等只读字段不可修改
+// @Tags {{.ModelName}}
+// @Accept json
+// @Produce json
+{{template "keyParams" .}}
+// @Param update_mask query string false "要更新的字段，逗号分隔，如 title,status"
+{{- if .Version}}
+// @Param If-Match header string false "GetByID 返回的 ETag，如 W/\"3\""
+{{- end}}
+// @Param {{ToLowerCamelCase .ModelName}} body object true "要更新的字段与新值"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "字段不存在、为只读字段或取值不合法"
+// @Failure 404 {object} response.Response "记录不存在"
+// @Failure 409 {object} response.Response "唯一约束冲突{{if .Version}}，或更新时被并发修改{{end}}"
+{{- if .Version}}
+// @Failure 412 {object} response.Response "If-Match 与当前版本不一致"
+{{- end}}
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s{{.Key.SwaggerRoute}} [patch]
+func (c *{{.ModelName}}Controller) Patch(ctx *gin.Context) {
+	{{.Key.Args}}, ok := c.parseKey(ctx)
+	if !ok {
+		return
+	}
+
+	var body map[string]json.RawMessage
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "参数错误: "+err.Error())
+		return
+	}
+	fields, err := repository.PatchFields(body, ctx.Query("update_mask"))
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+{{- if .Version}}
+	version, ok := c.ifMatch(ctx)
+	if !ok {
+		return
+	}
+{{- end}}
+
+	{{ToLowerCamelCase .ModelName}}, err := c.service.Patch(ctx, {{.Key.Args}}, {{if .Version}}version, {{end}}body, fields)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidPatch):
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.Err{{.ModelName}}NotFound):
+			response.Error(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.Err{{.ModelName}}AlreadyExists):
+			response.Error(ctx, http.StatusConflict, err.Error())
+{{- if .Version}}
+		case errors.Is(err, service.ErrConcurrentModification):
+			c.versionConflict(ctx, err, version != 0)
+{{- end}}
+		default:
+			response.Error(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+{{- if .Version}}
+
+	ctx.Header("ETag", repository.ETag({{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}))
+{{- end}}
+	response.Success(ctx, {{ToLowerCamelCase .ModelName}})
+}
+
 // Delete 删除 {{.ModelName}}
 //
 // @Summary 删除{{.ModelName}}
+{{- if .SoftDelete}}
+// @Description 软删除指定的{{.ModelName}}（可通过 restore 恢复）；hard=true 时永久删除，需要 {{.TableName}}:hard_delete 权限
+{{- else}}
 // @Description 删除指定的{{.ModelName}}
+{{- end}}
 // @Tags {{.ModelName}}
 // @Accept json
 // @Produce json
-// @Param id path int true "{{.ModelName}} ID"
+{{template "keyParams" .}}
+{{- if .SoftDelete}}
+// @Param hard query bool false "是否永久删除"
+{{- end}}
 // @Success 200 {object} response.Response
-// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/{id} [delete]
+{{- if .SoftDelete}}
+// @Failure 403 {object} response.Response "没有永久删除权限"
+{{- end}}
+// @Failure 404 {object} response.Response "记录不存在"
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s{{.Key.SwaggerRoute}} [delete]
 func (c *{{.ModelName}}Controller) Delete(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	{{.Key.Args}}, ok := c.parseKey(ctx)
+	if !ok {
+		return
+	}
+
+	{{- if .SoftDelete}}
+
+	var err error
+	if ctx.Query("hard") == "true" {
+		if !middleware.HasPermission(ctx, "{{.TableName}}:hard_delete") {
+			response.Error(ctx, http.StatusForbidden, "权限不足")
+			return
+		}
+		err = c.service.HardDelete(ctx, {{.Key.Args}})
+	} else {
+		err = c.service.Delete(ctx, {{.Key.Args}})
+	}
 	if err != nil {
-		response.Error(ctx, http.StatusBadRequest, "无效的ID")
+	{{- else}}
+
+	if err := c.service.Delete(ctx, {{.Key.Args}}); err != nil {
+	{{- end}}
+		if errors.Is(err, service.Err{{.ModelName}}NotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(ctx, nil)
+}
+
+// CreateBatch 批量创建 {{.ModelName}}
+//
+// @Summary 批量创建{{.ModelName}}
+// @Description 在一个事务中创建多条{{.ModelName}}（最多 1000 条）；任一条失败时全部不写入，data.errors 列出出错记录的下标、状态码与原因
+// @Tags {{.ModelName}}
+// @Accept json
+// @Produce json
+// @Param items body []model.{{.ModelName}} true "{{.ModelName}}列表"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "请求体或记录数不合法"
+// @Failure 422 {object} response.Response "部分记录校验失败"
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/batch [post]
+func (c *{{.ModelName}}Controller) CreateBatch(ctx *gin.Context) {
+	{{ToLowerCamelCase .ModelName}}s, ok := c.bindBatch(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.CreateBatch(ctx, {{ToLowerCamelCase .ModelName}}s); err != nil {
+		c.batchError(ctx, err)
+		return
+	}
+
+	keys := make([]gin.H, len({{ToLowerCamelCase .ModelName}}s))
+	for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		keys[i] = gin.H{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}"{{$f.Param}}": {{ToLowerCamelCase $.ModelName}}.{{$f.FieldName}}{{end -}} }
+	}
+	response.Success(ctx, gin.H{"count": len(keys), "items": keys})
+}
+
+// UpdateBatch 批量更新 {{.ModelName}}
+//
+// @Summary 批量更新{{.ModelName}}
+// @Description 在一个事务中更新多条{{.ModelName}}（每条必须带主键，只更新非零值字段）；任一条失败时全部不更新，data.errors 列出出错记录
+// @Tags {{.ModelName}}
+// @Accept json
+// @Produce json
+// @Param items body []model.{{.ModelName}} true "{{.ModelName}}列表"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "请求体或记录数不合法"
+// @Failure 422 {object} response.Response "部分记录校验失败"
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/batch [patch]
+func (c *{{.ModelName}}Controller) UpdateBatch(ctx *gin.Context) {
+	{{ToLowerCamelCase .ModelName}}s, ok := c.bindBatch(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.UpdateBatch(ctx, {{ToLowerCamelCase .ModelName}}s); err != nil {
+		c.batchError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, gin.H{"count": len({{ToLowerCamelCase .ModelName}}s)})
+}
+
+// DeleteBatch 批量删除 {{.ModelName}}
+//
+// @Summary 批量删除{{.ModelName}}
+// @Description 在一个事务中按主键删除多条{{.ModelName}}{{if .SoftDelete}}（软删除）{{end}}，请求体为只含主键的对象数组，如 [{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}"{{$f.Column}}": 1{{end -}} }]；任一条失败时全部不删除
+// @Tags {{.ModelName}}
+// @Accept json
+// @Produce json
+// @Param items body []model.{{.ModelName}} true "只含主键的{{.ModelName}}列表"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "请求体或记录数不合法"
+// @Failure 422 {object} response.Response "部分记录不存在"
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/batch [delete]
+func (c *{{.ModelName}}Controller) DeleteBatch(ctx *gin.Context) {
+	{{ToLowerCamelCase .ModelName}}s, ok := c.bindBatch(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteBatch(ctx, {{ToLowerCamelCase .ModelName}}s); err != nil {
+		c.batchError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, gin.H{"count": len({{ToLowerCamelCase .ModelName}}s)})
+}
+
+// bindBatch 解析批量请求体并逐条按 binding 标签校验，校验失败时返回 422 并列出出错记录
+//
+// 不使用 ShouldBindJSON：gin 校验切片时遇到 null 记录会 panic，null 记录交给 service 按无效记录处理
+func (c *{{.ModelName}}Controller) bindBatch(ctx *gin.Context) ([]*model.{{.ModelName}}, bool) {
+	var {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&{{ToLowerCamelCase .ModelName}}s); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "参数错误: "+err.Error())
+		return nil, false
+	}
+
+	var batchErr repository.BatchError
+	for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		if {{ToLowerCamelCase .ModelName}} == nil {
+			continue
+		}
+		if err := binding.Validator.ValidateStruct({{ToLowerCamelCase .ModelName}}); err != nil {
+			batchErr.Add(i, fmt.Errorf("%w: %v", repository.ErrInvalidItem, err))
+		}
+	}
+	if len(batchErr) > 0 {
+		c.batchError(ctx, batchErr)
+		return nil, false
+	}
+	return {{ToLowerCamelCase .ModelName}}s, true
+}
+
+// batchError 返回批量操作的错误响应：记录校验失败时返回 422，data.errors 列出每条出错记录
+func (c *{{.ModelName}}Controller) batchError(ctx *gin.Context, err error) {
+	var batchErr repository.BatchError
+	if !errors.As(err, &batchErr) {
+		if errors.Is(err, repository.ErrInvalidBatch) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items := make([]gin.H, len(batchErr))
+	for i, e := range batchErr {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(e.Err, repository.ErrInvalidItem):
+			status = http.StatusBadRequest
+		case errors.Is(e.Err, service.Err{{.ModelName}}NotFound):
+			status = http.StatusNotFound
+		case errors.Is(e.Err, service.Err{{.ModelName}}AlreadyExists){{if .Version}}, errors.Is(e.Err, service.ErrConcurrentModification){{end}}:
+			status = http.StatusConflict
+		}
+		items[i] = gin.H{"index": e.Index, "status": status, "error": e.Err.Error()}
+	}
+	response.ErrorWithData(ctx, http.StatusUnprocessableEntity, "批量操作失败，所有记录均未写入", gin.H{"errors": items})
+}
+{{- if .SoftDelete}}
+
+// Restore 恢复已删除的 {{.ModelName}}
+//
+// @Summary 恢复{{.ModelName}}
+// @Description 从回收站恢复已软删除的{{.ModelName}}
+// @Tags {{.ModelName}}
+// @Accept json
+// @Produce json
+{{template "keyParams" .}}
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response "记录不存在或未被删除"
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s{{.Key.SwaggerRoute}}/restore [post]
+func (c *{{.ModelName}}Controller) Restore(ctx *gin.Context) {
+	{{.Key.Args}}, ok := c.parseKey(ctx)
+	if !ok {
 		return
 	}
 
-	if err := c.service.Delete(ctx, uint(id)); err != nil {
+	if err := c.service.Restore(ctx, {{.Key.Args}}); err != nil {
+		if errors.Is(err, service.Err{{.ModelName}}NotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
 		response.Error(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -195,34 +527,195 @@ func (c *{{.ModelName}}Controller) Delete(ctx *gin.Context) {
 	response.Success(ctx, nil)
 }
 
+// ListTrash 获取回收站中的 {{.ModelName}} 列表
+//
+// @Summary 获取{{.ModelName}}回收站
+// @Description 分页获取已软删除的{{.ModelName}}，按删除时间倒序
+// @Tags {{.ModelName}}
+// @Accept json
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} response.Response
+// @Router /api/v1/{{ToLowerCamelCase .ModelName}}s/trash [get]
+func (c *{{.ModelName}}Controller) ListTrash(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+
+	{{ToLowerCamelCase .ModelName}}s, total, err := c.service.ListTrash(ctx, page, pageSize)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(ctx, gin.H{
+		"list":      {{ToLowerCamelCase .ModelName}}s,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+{{- end}}
+
 // List 获取 {{.ModelName}} 列表
 //
 // @Summary 获取{{.ModelName}}列表
-// @Description 分页获取{{.ModelName}}列表
+{{- if .Cursor}}
+// @Description 按 {{.Cursor.Column}} 游标分页获取{{.ModelName}}列表（不返回总数），支持过滤（column=value 或 column[op]=value）和字段选择
+{{- else}}
+// @Description 分页获取{{.ModelName}}列表，支持过滤（column=value 或 column[op]=value）、排序和字段选择
+{{- end}}
+{{- range .ListColumns}}{{if .Filterable}}
+// @Description 过滤 {{.Column}}：{{range $i, $op := .Ops}}{{if $i}}, {{end}}{{$op}}{{end}}
+{{- end}}{{end}}
 // @Tags {{.ModelName}}
 // @Accept json
 // @Produce json
+{{- if .Cursor}}
+// @Param cursor query string false "上一页返回的 next_cursor，为空时从第一条开始"
+// @Param page_size query int false "每页数量" default(20)
+// @Param sort query string false "排序，只支持 {{.Cursor.Column}} 或 -{{.Cursor.Column}}"
+{{- else}}
 // @Param page query int false "页码" default(1)
 // @Param page_size query int false "每页数量" default(20)
+// @Param sort query string false "排序，逗号分隔，- 前缀为倒序，如 -created_at,id"
+{{- end}}
+// @Param fields query string false "返回的列，逗号分隔，如 id,title"
+// @Param preload query []string false "预加载的关联，可重复传入" collectionFormat(multi)
 // @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "查询参数不合法"
 // @Router /api/v1/{{ToLowerCamelCase .ModelName}}s [get]
 func (c *{{.ModelName}}Controller) List(ctx *gin.Context) {
+	{{- if not .Cursor}}
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	{{- end}}
 	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+	q := repository.ParseListQuery(ctx.Request.URL.Query())
+	preloads := ctx.QueryArray("preload")
 
-	{{ToLowerCamelCase .ModelName}}s, total, err := c.service.List(ctx, page, pageSize)
+	{{if .Cursor}}{{ToLowerCamelCase .ModelName}}s, next, pageSize, err := c.service.ListAfter(ctx, q, ctx.Query("cursor"), pageSize, preloads...)
+	{{- else}}{{ToLowerCamelCase .ModelName}}s, total, err := c.service.List(ctx, q, page, pageSize, preloads...)
+	{{- end}}
 	if err != nil {
+		if errors.Is(err, repository.ErrUnknownPreload) || errors.Is(err, repository.ErrInvalidQuery) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
 		response.Error(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	// 指定 fields 时只返回这些字段（以及主键和已预加载的关联）
+	var list interface{} = {{ToLowerCamelCase .ModelName}}s
+	if len(q.Fields) > 0 {
+		if list, err = repository.Project({{ToLowerCamelCase .ModelName}}s, repository.{{.ModelName}}JSONKeys(q.Fields, preloads)); err != nil {
+			response.Error(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	{{- if .Cursor}}
+
+	response.CursorPaginated(ctx, list, next, pageSize)
+	{{- else}}
+
 	response.Success(ctx, gin.H{
-		"list":      {{ToLowerCamelCase .ModelName}}s,
+		"list":      list,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+	{{- end}}
+}
+{{range .Finders}}{{if .Route}}
+// ListBy{{.FieldName}} 获取父资源下的 {{$.ModelName}} 列表
+//
+// @Summary 按 {{.Column}} 获取{{$.ModelName}}列表
+// @Description 分页获取 {{.Column}} 等于路径参数 id 的{{$.ModelName}}列表
+// @Tags {{$.ModelName}}
+// @Accept json
+// @Produce json
+// @Param id path {{if eq .GoType "string"}}string{{else}}int{{end}} true "{{.Column}}"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Param preload query []string false "预加载的关联，可重复传入" collectionFormat(multi)
+// @Success 200 {object} response.Response
+// @Router /api/v1{{.Route}} [get]
+func (c *{{$.ModelName}}Controller) ListBy{{.FieldName}}(ctx *gin.Context) {
+	{{- if eq .GoType "string"}}
+	{{ToLowerCamelCase .FieldName}} := ctx.Param("id")
+	{{- else}}
+	value, err := strconv.{{.ParseFunc}}(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "无效的ID")
+		return
+	}
+	{{ToLowerCamelCase .FieldName}} := {{.GoType}}(value)
+	{{- end}}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+
+	{{ToLowerCamelCase $.ModelName}}s, total, err := c.service.ListBy{{.FieldName}}(ctx, {{ToLowerCamelCase .FieldName}}, page, pageSize, ctx.QueryArray("preload")...)
+	if err != nil {
+		if errors.Is(err, repository.ErrUnknownPreload) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(ctx, gin.H{
+		"list":      {{ToLowerCamelCase $.ModelName}}s,
 		"total":     total,
 		"page":      page,
 		"page_size": pageSize,
 	})
 }
+{{end}}{{end}}
+{{if .Version -}}
+// ifMatch 解析 If-Match 请求头得到期望的 {{.Version.Column}}，未提供或为 * 时返回 0；格式错误时返回 400
+func (c *{{.ModelName}}Controller) ifMatch(ctx *gin.Context) (version {{.Version.GoType}}, ok bool) {
+	version, err := repository.Parse{{.ModelName}}ETag(ctx.GetHeader("If-Match"))
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
+		return 0, false
+	}
+	return version, true
+}
+
+// versionConflict 返回版本冲突：期望版本来自 If-Match 时返回 412，否则返回 409
+func (c *{{.ModelName}}Controller) versionConflict(ctx *gin.Context, err error, ifMatch bool) {
+	if ifMatch {
+		response.Error(ctx, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	response.Error(ctx, http.StatusConflict, err.Error())
+}
+
+{{end -}}
+// parseKey 解析路径中的主键参数，解析失败时返回 400
+func (c *{{.ModelName}}Controller) parseKey(ctx *gin.Context) ({{.Key.Params}}, ok bool) {
+{{- range .Key.Fields}}
+	{{- if .IsString}}
+	{{.Var}} = ctx.Param("{{.Param}}")
+	{{- else}}
+	{{.Var}}Value, err := strconv.{{.ParseFunc}}(ctx.Param("{{.Param}}"), 10, {{.BitSize}})
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "无效的{{if $.Key.Composite}}{{.Param}}{{else}}ID{{end}}")
+		return
+	}
+	{{.Var}} = {{.GoType}}({{.Var}}Value)
+	{{- end}}
+{{- end}}
+	return {{.Key.Args}}, true
+}
+{{- define "keyParams"}}
+{{- range $i, $f := .Key.Fields}}{{if $i}}
+{{end}}// @Param {{$f.Param}} path {{if $f.IsString}}string{{else}}int{{end}} true "{{if $.Key.Composite}}{{$f.Column}}{{else}}{{$.ModelName}} ID{{end}}"
+{{- end}}
+{{- end}}
 `
 
 	// 创建模板并添加辅助函数
@@ -247,6 +740,12 @@ func (c *{{.ModelName}}Controller) List(ctx *gin.Context) {
 		"ModelName":   config.ModelName,
 		"PackageName": config.PackageName,
 		"ModulePath":  config.ModulePath,
+		"Key":         config.Key,
+		"SoftDelete":  config.SoftDelete,
+		"Cursor":      config.Cursor,
+		"Version":     config.Version,
+		"ListColumns": config.ListColumns,
+		"Finders":     config.Finders,
 	}
 
 	if err := t.Execute(f, data); err != nil {
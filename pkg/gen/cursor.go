@@ -0,0 +1,29 @@
+package gen
+
+// cursorColumn 游标（keyset）分页使用的列：表配置为 cursor 分页且主键为单列整数或字符串时返回主键列，
+// 否则返回 nil，List 按偏移分页生成
+func cursorColumn(table *DetailedTableInfo, opts tableOptions) *ListColumn {
+	if opts.Pagination != PaginationCursor {
+		return nil
+	}
+
+	key := buildPrimaryKey(table, opts)
+	if len(key.Fields) != 1 {
+		return nil
+	}
+
+	col := ListColumn{KeyField: key.Fields[0], Kind: listColumnKind(key.Fields[0].GoType)}
+	if col.Pointer || col.Kind != "int" && col.Kind != "uint" && col.Kind != "string" {
+		return nil
+	}
+	return &col
+}
+
+// cursor 表的游标分页列，未启用游标分页或无法获取表结构时返回 nil
+func (g *DatabaseGenerator) cursor(tableName string) *ListColumn {
+	schema, err := g.tableSchema(tableName)
+	if err != nil {
+		return nil
+	}
+	return cursorColumn(schema, g.options(tableName))
+}
@@ -0,0 +1,151 @@
+package gen
+
+import (
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/internal/buildtest"
+)
+
+// TestCursorColumn 验证游标分页只用于配置为 cursor 且主键为单列整数或字符串的表
+func TestCursorColumn(t *testing.T) {
+	ddl := "CREATE TABLE `events` (`id` bigint unsigned NOT NULL, PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `tags` (`code` varchar(32) NOT NULL, PRIMARY KEY (`code`));\n" +
+		"CREATE TABLE `user_roles` (`user_id` bigint NOT NULL, `role_id` bigint NOT NULL, PRIMARY KEY (`user_id`, `role_id`));"
+
+	schema := parseSchema(t, ddl, nil)
+
+	cfg := &GenConfig{Pagination: PaginationCursor, Overrides: map[string]TableConfig{
+		"tags": {Pagination: PaginationOffset},
+	}}
+
+	tests := []struct {
+		table string
+		cfg   *GenConfig
+		want  string // 游标列，为空表示按偏移分页
+	}{
+		{"events", cfg, "id"},
+		{"events", nil, ""},
+		{"tags", cfg, ""},
+		{"tags", &GenConfig{Pagination: PaginationCursor}, "code"},
+		{"user_roles", cfg, ""},
+	}
+	for _, tt := range tests {
+		var got string
+		if col := cursorColumn(schema.Table(tt.table), tt.cfg.table(tt.table)); col != nil {
+			got = col.Column
+		}
+		if got != tt.want {
+			t.Errorf("cursorColumn(%s) = %q, want %q", tt.table, got, tt.want)
+		}
+	}
+
+	if err := (&GenConfig{Pagination: "keyset"}).Validate(); err == nil {
+		t.Error("Validate() expected error for unknown pagination mode")
+	}
+}
+
+// cursorControllerTest 在生成项目的 controller 包中运行的测试：page_size 不合法时按默认值查询，
+// 响应中的 page_size 是实际使用的值
+const cursorControllerTest = `package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"example.com/app/internal/dal/model"
+	"example.com/app/internal/repository"
+	"example.com/app/internal/repository/mock"
+	"example.com/app/internal/service"
+	"example.com/app/pkg/cache"
+	"github.com/gin-gonic/gin"
+)
+
+func TestEventsController_ListPageSize(t *testing.T) {
+	for query, want := range map[string]int{"": 20, "?page_size=5": 5, "?page_size=0": 20, "?page_size=1000": 20} {
+		var got int
+		repo := &mock.EventsRepo{
+			ListAfterFunc: func(ctx context.Context, q repository.ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.Events, string, error) {
+				got = pageSize
+				return nil, "", nil
+			},
+		}
+		ctrl := NewEventsController(service.NewEventsService(repo, nil, cache.New()))
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/events", ctrl.List)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET /events%s status = %d: %s", query, w.Code, w.Body)
+		}
+		var resp struct {
+			Data struct {
+				PageSize int ` + "`json:\"page_size\"`" + `
+			} ` + "`json:\"data\"`" + `
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if got != want || resp.Data.PageSize != want {
+			t.Errorf("GET /events%s: queried %d, page_size %d, want %d", query, got, resp.Data.PageSize, want)
+		}
+	}
+}
+`
+
+// TestCursorGenerated 验证游标分页的表生成 ListAfter 且 List 接口按游标分页、返回实际使用的每页数量，
+// 偏移分页的表不生成，且生成的代码能编译
+func TestCursorGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `events` (`id` bigint unsigned NOT NULL, `name` varchar(50) NOT NULL, PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `tags` (`code` varchar(32) NOT NULL, PRIMARY KEY (`code`));\n" +
+		"CREATE TABLE `logs` (`id` bigint unsigned NOT NULL, PRIMARY KEY (`id`));"
+	dir := generateSQL(t, ddl, &GenConfig{Pagination: PaginationCursor, Overrides: map[string]TableConfig{
+		"logs": {Pagination: PaginationOffset},
+	}})
+
+	decls := funcDecls(t, dir, "internal/repository/events.go", "internal/repository/tags.go", "internal/repository/logs.go",
+		"internal/service/events.go", "internal/controller/events.go")
+	got := signatures(decls, "EventsRepository.ListAfter", "TagsRepository.ListAfter", "LogsRepository.ListAfter", "EventsService.ListAfter")
+	want := map[string]string{
+		"EventsRepository.ListAfter": "func(ctx context.Context, q ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.Events, string, error)",
+		"TagsRepository.ListAfter":   "func(ctx context.Context, q ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.Tags, string, error)",
+		"LogsRepository.ListAfter":   "",
+		"EventsService.ListAfter":    "func(ctx context.Context, q repository.ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.Events, string, int, error)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("signatures =\n%v\nwant\n%v", got, want)
+	}
+
+	// List 接口调用 ListAfter 并返回游标分页响应
+	list := decls["EventsController.List"]
+	if list == nil {
+		t.Fatal("EventsController.List not generated")
+	}
+	calls := make(map[string]bool)
+	ast.Inspect(list, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			calls[types.ExprString(call.Fun)] = true
+		}
+		return true
+	})
+	for _, call := range []string{"c.service.ListAfter", "response.CursorPaginated"} {
+		if !calls[call] {
+			t.Errorf("EventsController.List does not call %s", call)
+		}
+	}
+	vetGenerated(t, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "internal/controller/cursor_gen_test.go"), []byte(cursorControllerTest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buildtest.Go(t, dir, testModule, "test", "-run", "ListPageSize", "./internal/controller/")
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
 )
 
 // DDDGenerator DDD 架构代码生成器
@@ -53,7 +55,7 @@ func (g *DDDGenerator) generateDomainLayer() error {
 	fmt.Println("\n📦 生成 Domain 层...")
 
 	for _, tableName := range g.config.Tables {
-		modelName := toModelName(tableName)
+		modelName := g.config.Options.table(tableName).ModelName
 
 		// 1. 生成领域模型（Entity）
 		if err := g.generateDomainEntity(tableName, modelName); err != nil {
@@ -88,10 +90,14 @@ func (g *DDDGenerator) generateDomainEntity(tableName, modelName string) error {
 // Source: {{.TableName}}
 
 package {{ToLowerCamelCase .ModelName}}
+{{- if .Imports}}
 
 import (
-	"time"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
 )
+{{- end}}
 
 // {{.ModelName}} {{.ModelName}} 领域实体
 //
@@ -105,6 +111,9 @@ import (
 //   - 通过方法封装业务规则，而不是贫血模型
 //   - 使用值对象（Value Object）表示概念
 type {{.ModelName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}{{if .Comment}} // {{.Comment}}{{end}}
+{{- else}}
 	ID        uint
 	// TODO: 根据数据库表结构添加字段
 	// 例如：
@@ -113,6 +122,7 @@ type {{.ModelName}} struct {
 	// Age      int
 	// CreatedAt time.Time
 	// UpdatedAt time.Time
+{{- end}}
 
 	// 领域事件（可选）
 	// events []domain.Event
@@ -183,15 +193,16 @@ func (e *{{.ModelName}}) Validate() error {
 // }
 `
 
-	t, err := template.New("domain_entity").Parse(tmpl)
+	funcMap := template.FuncMap{
+		"ToLowerCamelCase": toLowerCamelCase,
+	}
+
+	t, err := template.New("domain_entity").Funcs(funcMap).Parse(tmpl)
 	if err != nil {
 		return err
 	}
 
-	funcMap := template.FuncMap{
-		"ToLowerCamelCase": toLowerCamelCase,
-	}
-	t = t.Funcs(funcMap)
+	fields, imports := g.entityFields(tableName)
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -202,11 +213,53 @@ func (e *{{.ModelName}}) Validate() error {
 	data := map[string]interface{}{
 		"TableName": tableName,
 		"ModelName": modelName,
+		"Fields":    fields,
+		"Imports":   imports,
 	}
 
 	return t.Execute(f, data)
 }
 
+// entityField 领域实体的字段
+type entityField struct {
+	Name    string
+	Type    string
+	Comment string
+}
+
+// entityFields 根据表结构生成领域实体的字段及需要导入的包，
+// 字段类型与 MVC 模式下的模型一致（遵循 gen.yaml 的 types 与列级覆盖）；
+// 无法读取表结构时返回空，由模板生成占位字段
+func (g *DDDGenerator) entityFields(tableName string) ([]entityField, []string) {
+	schema, err := GetTableSchema(g.config.DSN, tableName)
+	if err != nil {
+		fmt.Printf("  ⚠️  无法读取 %s 的表结构，领域实体只生成占位字段: %v\n", tableName, err)
+		return nil, nil
+	}
+
+	opts := g.config.Options.table(tableName)
+
+	var fields []entityField
+	var goTypes []string
+	for _, f := range schema.Fields {
+		if opts.ignored(f.Name) {
+			continue
+		}
+		goType := opts.Columns[f.Name].Type
+		if goType == "" {
+			goType = mapToGoType(opts.Types, f)
+		}
+		fields = append(fields, entityField{
+			Name:    columnFieldName(f.Name),
+			Type:    goType,
+			Comment: strings.ReplaceAll(f.Comment, "\n", " "),
+		})
+		goTypes = append(goTypes, goType)
+	}
+
+	return fields, typemap.Imports(goTypes...)
+}
+
 // generateRepositoryInterface 生成仓储接口
 func (g *DDDGenerator) generateRepositoryInterface(tableName, modelName string) error {
 	outputDir := filepath.Join(g.config.Output, "internal/domain", strings.ToLower(modelName))
@@ -271,15 +324,14 @@ type {{.ModelName}}Repository interface {
 }
 `
 
-	t, err := template.New("repository_interface").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
-	t = t.Funcs(funcMap)
+
+	t, err := template.New("repository_interface").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -346,15 +398,14 @@ func New{{.ModelName}}Service(/* TODO: 添加参数 */) *{{.ModelName}}Service {
 // }
 `
 
-	t, err := template.New("domain_service").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
-	t = t.Funcs(funcMap)
+
+	t, err := template.New("domain_service").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -375,7 +426,7 @@ func (g *DDDGenerator) generateApplicationLayer() error {
 	fmt.Println("\n📦 生成 Application 层...")
 
 	for _, tableName := range g.config.Tables {
-		modelName := toModelName(tableName)
+		modelName := g.config.Options.table(tableName).ModelName
 
 		// 生成应用服务（Application Service）
 		if err := g.generateApplicationService(tableName, modelName); err != nil {
@@ -466,15 +517,14 @@ type Update{{.ModelName}}Command struct {
 }
 `
 
-	t, err := template.New("application_service").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
-	t = t.Funcs(funcMap)
+
+	t, err := template.New("application_service").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -496,7 +546,7 @@ func (g *DDDGenerator) generateInfrastructureLayer() error {
 	fmt.Println("\n📦 生成 Infrastructure 层...")
 
 	for _, tableName := range g.config.Tables {
-		modelName := toModelName(tableName)
+		modelName := g.config.Options.table(tableName).ModelName
 
 		// 生成仓储实现
 		if err := g.generateRepositoryImpl(tableName, modelName); err != nil {
@@ -612,15 +662,14 @@ func (r *{{.ModelName}}RepositoryImpl) Delete(ctx context.Context, id uint) erro
 // }
 `
 
-	t, err := template.New("repository_impl").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
-	t = t.Funcs(funcMap)
+
+	t, err := template.New("repository_impl").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -642,7 +691,7 @@ func (g *DDDGenerator) generateInterfaceLayer() error {
 	fmt.Println("\n📦 生成 Interface 层...")
 
 	for _, tableName := range g.config.Tables {
-		modelName := toModelName(tableName)
+		modelName := g.config.Options.table(tableName).ModelName
 
 		// 生成 HTTP 控制器
 		if err := g.generateHTTPController(tableName, modelName); err != nil {
@@ -740,15 +789,14 @@ func New{{.ModelName}}Controller(
 // }
 `
 
-	t, err := template.New("http_controller").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
-	t = t.Funcs(funcMap)
+
+	t, err := template.New("http_controller").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -808,15 +856,14 @@ type Controllers struct {
 }
 `
 
-	t, err := template.New("ddd_routes").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
-	t = t.Funcs(funcMap)
+
+	t, err := template.New("ddd_routes").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -826,7 +873,7 @@ type Controllers struct {
 
 	var modelNames []string
 	for _, table := range tables {
-		modelNames = append(modelNames, toModelName(table))
+		modelNames = append(modelNames, g.config.Options.table(table).ModelName)
 	}
 
 	data := map[string]interface{}{
@@ -0,0 +1,112 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// parseEnumValues 解析 ENUM 定义中的取值列表，支持 enum('a','b') 与 'a','b' 两种形式
+func (l sqlLexer) parseEnumValues(def string) []string {
+	inner := strings.TrimSpace(def)
+	// 'a','b' 形式（含 PostgreSQL 的 E'...'）的取值中可能含括号，只有 enum(...) 形式才去掉外层括号
+	if open, end := strings.Index(def, "("), strings.LastIndex(def, ")"); !strings.HasPrefix(strings.TrimLeft(inner, "Ee"), "'") && open >= 0 && end > open {
+		inner = def[open+1 : end]
+	}
+
+	var values []string
+	for _, part := range l.splitTopLevel(inner, ',') {
+		values = append(values, l.unquoteString(part))
+	}
+	return values
+}
+
+// applyEnums 为带枚举取值的列生成 Go 枚举类型（类型名为模型名+字段名，如 ArticlesStatus），
+// 并把字段的 Go 类型改为枚举类型；被忽略或在 gen.yaml 中指定了 type 的列保持不变
+func applyEnums(table *DetailedTableInfo, opts tableOptions) []typemap.Enum {
+	var enums []typemap.Enum
+	for i := range table.Fields {
+		f := &table.Fields[i]
+		if len(f.EnumValues) == 0 || opts.ignored(f.Name) || opts.Columns[f.Name].Type != "" {
+			continue
+		}
+
+		comment := f.Comment
+		if comment == "" {
+			comment = fmt.Sprintf("%s.%s 的取值", table.Name, f.Name)
+		}
+		enum := typemap.NewStringEnum(opts.ModelName+columnFieldName(f.Name), comment, f.EnumValues)
+
+		f.Enum = &enum
+		f.GoType = enum.TypeName
+		if f.Nullable && !f.PrimaryKey {
+			f.GoType = opts.Types.NullableType(enum.TypeName)
+		}
+		enums = append(enums, enum)
+	}
+	return enums
+}
+
+// enumOpts 连接数据库生成模型时，把枚举列的类型改为枚举类型并加上 oneof 校验
+func enumOpts(table *DetailedTableInfo) []gen.ModelOpt {
+	var result []gen.ModelOpt
+	for _, f := range table.Fields {
+		if f.Enum == nil {
+			continue
+		}
+		result = append(result, gen.FieldType(f.Name, f.GoType))
+		if binding := f.Enum.BindingTag(); binding != "" {
+			result = append(result, gen.FieldTag(f.Name, func(tag field.Tag) field.Tag {
+				return tag.Set("binding", binding)
+			}))
+		}
+	}
+	return result
+}
+
+// generateEnums 在模型目录下为每张含枚举列的表生成 <表名>_enums.go
+func (g *DatabaseGenerator) generateEnums() error {
+	modelDir := filepath.Join(g.config.Output, "internal/dal/model")
+
+	for _, tableName := range g.config.Tables {
+		schema := g.schemas[tableName]
+		if schema == nil {
+			continue
+		}
+
+		var enums []typemap.Enum
+		for _, f := range schema.Fields {
+			if f.Enum != nil {
+				enums = append(enums, *f.Enum)
+			}
+		}
+		if len(enums) == 0 {
+			continue
+		}
+
+		src, err := typemap.RenderEnums("model", enums)
+		if err != nil {
+			return fmt.Errorf("表 %s: %w", tableName, err)
+		}
+
+		if err := os.MkdirAll(modelDir, 0755); err != nil {
+			return fmt.Errorf("创建目录失败: %w", err)
+		}
+		fileName := strings.ReplaceAll(tableName, ".", "_") + "_enums.go"
+		if err := os.WriteFile(filepath.Join(modelDir, fileName), src, 0644); err != nil {
+			return fmt.Errorf("写入 %s 失败: %w", fileName, err)
+		}
+
+		for _, enum := range enums {
+			fmt.Printf("  🏷️  %s (%d 个取值)\n", enum.TypeName, len(enum.Values))
+		}
+	}
+
+	return nil
+}
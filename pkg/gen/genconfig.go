@@ -0,0 +1,346 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// 可生成的代码层
+const (
+	LayerModel      = "model"      // GORM Gen 模型与查询代码
+	LayerRepository = "repository" // 数据访问层
+	LayerService    = "service"    // 业务逻辑层
+	LayerController = "controller" // HTTP 处理器
+	LayerRoutes     = "routes"     // 路由注册
+)
+
+// List 接口的分页方式
+const (
+	PaginationOffset = "offset" // page/page_size 偏移分页，返回总数
+	PaginationCursor = "cursor" // 按主键的游标（keyset）分页，不统计总数
+)
+
+// allLayers 按依赖顺序排列的全部代码层，后一层依赖前一层
+var allLayers = []string{LayerModel, LayerRepository, LayerService, LayerController, LayerRoutes}
+
+// GenConfig gen.yaml 配置文件
+//
+// 示例：
+//
+//	dsn: "root:${DB_PASSWORD}@tcp(localhost:3306)/mydb"
+//	module: github.com/user/my-api
+//	tables: ["user*", "articles"]
+//	exclude: ["*_log"]
+//	cache: true
+//	openapi: true     # 生成 docs/openapi.yaml 与 Swagger UI（/swagger/index.html）
+//	client: true      # 生成 client 包，供其他 Go 服务调用
+//	types:
+//	  nullable: pointer   # 可空列：pointer（*string）或 sql_null（sql.NullString）
+//	  decimal: string     # decimal/numeric：string 或 shopspring（decimal.Decimal）
+//	overrides:
+//	  users:
+//	    model: Member
+//	    ignore_columns: [password_hash]
+//	    columns:
+//	      nick_name: {json: nickname}
+//	      status: {filter: [eq, in], sort: false}
+//	      email: {readonly: true}
+//	  articles:
+//	    cache: false
+//	    layers: [model, repository]
+//	  events:
+//	    pagination: cursor  # 大表用游标分页，不统计总数
+//	  orders:
+//	    version_column: revision  # 乐观锁版本列（默认 version）
+type GenConfig struct {
+	DSN           string                 `yaml:"dsn"`            // 数据库连接字符串（命令行 --dsn 优先）
+	Output        string                 `yaml:"output"`         // 输出目录
+	Module        string                 `yaml:"module"`         // Go 模块路径
+	Arch          string                 `yaml:"arch"`           // 架构类型：mvc 或 ddd
+	Tables        []string               `yaml:"tables"`         // 要生成的表，支持通配符
+	Exclude       []string               `yaml:"exclude"`        // 排除的表，支持通配符
+	Cache         *bool                  `yaml:"cache"`          // 默认是否在 Service 层启用缓存（默认 true）
+	Layers        []string               `yaml:"layers"`         // 默认生成的代码层（默认全部）
+	Pagination    string                 `yaml:"pagination"`     // 默认 List 分页方式：offset（默认）或 cursor
+	VersionColumn string                 `yaml:"version_column"` // 默认乐观锁版本列（默认 version，"-" 表示不启用）
+	OpenAPI       bool                   `yaml:"openapi"`        // 是否生成 OpenAPI 文档与 Swagger UI（命令行 --openapi 同样生效）
+	Client        bool                   `yaml:"client"`         // 是否生成 Go 客户端包（命令行 --client 同样生效）
+	Types         typemap.Options        `yaml:"types"`          // 数据库类型到 Go 类型的映射策略
+	Overrides     map[string]TableConfig `yaml:"overrides"`      // 表级覆盖，key 为表名
+}
+
+// TableConfig 表级覆盖配置
+type TableConfig struct {
+	Model         string                  `yaml:"model"`          // 模型名（默认由表名推导）
+	Cache         *bool                   `yaml:"cache"`          // 是否在 Service 层启用缓存
+	Layers        []string                `yaml:"layers"`         // 要生成的代码层
+	Pagination    string                  `yaml:"pagination"`     // List 分页方式：offset 或 cursor
+	VersionColumn string                  `yaml:"version_column"` // 乐观锁版本列，"-" 表示不启用
+	IgnoreColumns []string                `yaml:"ignore_columns"` // 不生成到模型中的列
+	Columns       map[string]ColumnConfig `yaml:"columns"`        // 列级覆盖，key 为列名
+}
+
+// ColumnConfig 列级覆盖配置
+type ColumnConfig struct {
+	JSON     string   `yaml:"json"`     // JSON 标签
+	Type     string   `yaml:"type"`     // Go 类型（如 int64、*string、json.RawMessage）
+	Ignore   bool     `yaml:"ignore"`   // 不生成到模型中
+	Filter   []string `yaml:"filter"`   // List 接口允许的过滤运算符（默认按类型推导，[] 表示不可过滤）
+	Sort     *bool    `yaml:"sort"`     // List 接口是否允许按该列排序（默认按类型推导）
+	ReadOnly bool     `yaml:"readonly"` // PATCH 接口不允许修改（主键与 created_at/updated_at 默认只读）
+}
+
+// tableOptions 合并默认值与表级覆盖后的最终配置
+type tableOptions struct {
+	ModelName     string
+	Cache         bool
+	Layers        map[string]bool
+	Pagination    string
+	VersionColumn string
+	IgnoreColumns []string
+	Columns       map[string]ColumnConfig
+	Types         typemap.Options
+}
+
+// has 是否生成指定代码层
+func (o tableOptions) has(layer string) bool {
+	return o.Layers[layer]
+}
+
+// LoadGenConfig 读取并校验 gen.yaml
+//
+// dsn 支持环境变量（如 ${DB_PASSWORD}），避免把密码提交到仓库。
+func LoadGenConfig(filename string) (*GenConfig, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	var cfg GenConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("解析 YAML 失败: %w", err)
+	}
+	cfg.DSN = os.ExpandEnv(cfg.DSN)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("配置验证失败: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate 校验配置
+func (c *GenConfig) Validate() error {
+	if c.Arch != "" && c.Arch != "mvc" && c.Arch != "ddd" {
+		return fmt.Errorf("不支持的架构类型: %s (支持: mvc, ddd)", c.Arch)
+	}
+
+	for _, pattern := range append(append([]string{}, c.Tables...), c.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("无效的通配符模式 '%s': %w", pattern, err)
+		}
+	}
+
+	if err := validateLayers(c.Layers); err != nil {
+		return err
+	}
+
+	if err := c.Types.Validate(); err != nil {
+		return err
+	}
+
+	if err := validatePagination(c.Pagination); err != nil {
+		return err
+	}
+
+	for table, override := range c.Overrides {
+		if err := validateLayers(override.Layers); err != nil {
+			return fmt.Errorf("表 %s: %w", table, err)
+		}
+		if err := validatePagination(override.Pagination); err != nil {
+			return fmt.Errorf("表 %s: %w", table, err)
+		}
+		for column, col := range override.Columns {
+			if col.Type != "" && strings.ContainsAny(col.Type, " \t;`") {
+				return fmt.Errorf("表 %s 列 %s: 无效的 Go 类型 %q", table, column, col.Type)
+			}
+			for _, op := range col.Filter {
+				if !isFilterOp(op) {
+					return fmt.Errorf("表 %s 列 %s: 未知的过滤运算符 %q (支持: eq, ne, gt, gte, lt, lte, like, in)", table, column, op)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateLayers 校验代码层名称及依赖关系
+func validateLayers(layers []string) error {
+	if len(layers) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool)
+	for _, layer := range layers {
+		if !slices.Contains(allLayers, layer) {
+			return fmt.Errorf("未知的代码层 %q (支持: %s)", layer, strings.Join(allLayers, ", "))
+		}
+		set[layer] = true
+	}
+
+	for i := 1; i < len(allLayers); i++ {
+		if set[allLayers[i]] && !set[allLayers[i-1]] {
+			return fmt.Errorf("代码层 %s 依赖 %s，请一并启用", allLayers[i], allLayers[i-1])
+		}
+	}
+
+	return nil
+}
+
+// validatePagination 校验分页方式
+func validatePagination(mode string) error {
+	switch mode {
+	case "", PaginationOffset, PaginationCursor:
+		return nil
+	}
+	return fmt.Errorf("不支持的分页方式 %q (支持: %s, %s)", mode, PaginationOffset, PaginationCursor)
+}
+
+// ResolveTables 按 tables/exclude 规则从候选表中筛选要生成的表
+//
+// patterns 不为空时代替配置文件中的 tables（用于命令行 --tables 覆盖）。
+func (c *GenConfig) ResolveTables(all []string, patterns []string) ([]string, error) {
+	var exclude []string
+	if c != nil {
+		if len(patterns) == 0 {
+			patterns = c.Tables
+		}
+		exclude = c.Exclude
+	}
+
+	return selectTables(all, patterns, exclude)
+}
+
+// table 返回指定表合并默认值后的配置，c 为 nil 时返回默认配置
+func (c *GenConfig) table(name string) tableOptions {
+	opts := tableOptions{
+		ModelName:     toModelName(name),
+		Cache:         true,
+		Layers:        make(map[string]bool),
+		Pagination:    PaginationOffset,
+		VersionColumn: defaultVersionColumn,
+	}
+
+	layers := allLayers
+	if c != nil {
+		opts.Types = c.Types
+		if c.Cache != nil {
+			opts.Cache = *c.Cache
+		}
+		if len(c.Layers) > 0 {
+			layers = c.Layers
+		}
+		if c.Pagination != "" {
+			opts.Pagination = c.Pagination
+		}
+		if c.VersionColumn != "" {
+			opts.VersionColumn = c.VersionColumn
+		}
+
+		if override, ok := c.Overrides[name]; ok {
+			if override.Model != "" {
+				opts.ModelName = override.Model
+			}
+			if override.Cache != nil {
+				opts.Cache = *override.Cache
+			}
+			if len(override.Layers) > 0 {
+				layers = override.Layers
+			}
+			if override.Pagination != "" {
+				opts.Pagination = override.Pagination
+			}
+			if override.VersionColumn != "" {
+				opts.VersionColumn = override.VersionColumn
+			}
+			opts.IgnoreColumns = override.IgnoreColumns
+			opts.Columns = override.Columns
+		}
+	}
+
+	for _, layer := range layers {
+		opts.Layers[layer] = true
+	}
+
+	return opts
+}
+
+// types 返回类型映射配置，c 为 nil 时返回默认配置
+func (c *GenConfig) types() typemap.Options {
+	if c == nil {
+		return typemap.Options{}
+	}
+	return c.Types
+}
+
+// ignored 列是否被忽略
+func (o tableOptions) ignored(column string) bool {
+	return slices.Contains(o.IgnoreColumns, column) || o.Columns[column].Ignore
+}
+
+// selectTables 按通配符筛选表名，保持候选表的顺序并去重
+//
+// 不含通配符的表名在候选表中不存在时返回错误；patterns 为空表示全部表。
+func selectTables(all []string, patterns, exclude []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"*"}
+	}
+
+	selected := make(map[string]bool)
+	for _, pattern := range patterns {
+		matched := false
+		for _, name := range all {
+			ok, err := path.Match(pattern, name)
+			if err != nil {
+				return nil, fmt.Errorf("无效的通配符模式 '%s': %w", pattern, err)
+			}
+			if ok {
+				selected[name] = true
+				matched = true
+			}
+		}
+
+		if !matched && !strings.ContainsAny(pattern, "*?[") {
+			return nil, fmt.Errorf("❌ 表 '%s' 不存在\n\n📋 可用的表:\n  • %s",
+				pattern, strings.Join(all, "\n  • "))
+		}
+	}
+
+	var result []string
+	for _, name := range all {
+		if !selected[name] || matchAny(exclude, name) {
+			continue
+		}
+		result = append(result, name)
+	}
+
+	return result, nil
+}
+
+// matchAny 表名是否匹配任一通配符
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
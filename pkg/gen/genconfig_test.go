@@ -0,0 +1,74 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestGenConfigResolveTables 验证通配符、排除规则与命令行覆盖
+func TestGenConfigResolveTables(t *testing.T) {
+	all := []string{"users", "user_roles", "articles", "audit_log"}
+	cfg := &GenConfig{
+		Tables:  []string{"user*", "articles"},
+		Exclude: []string{"user_roles"},
+	}
+
+	got, err := cfg.ResolveTables(all, nil)
+	if err != nil {
+		t.Fatalf("ResolveTables() unexpected error: %v", err)
+	}
+	if want := []string{"users", "articles"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveTables() = %v, want %v", got, want)
+	}
+
+	got, err = cfg.ResolveTables(all, []string{"*"})
+	if err != nil {
+		t.Fatalf("ResolveTables() unexpected error: %v", err)
+	}
+	if want := []string{"users", "articles", "audit_log"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveTables(*) = %v, want %v", got, want)
+	}
+
+	if _, err := cfg.ResolveTables(all, []string{"missing"}); err == nil {
+		t.Errorf("ResolveTables(missing) expected error, got nil")
+	}
+}
+
+// TestGenConfigTableOptions 验证默认值与表级覆盖的合并
+func TestGenConfigTableOptions(t *testing.T) {
+	off := false
+	cfg := &GenConfig{
+		Layers: []string{LayerModel, LayerRepository, LayerService},
+		Overrides: map[string]TableConfig{
+			"users": {
+				Model:         "Member",
+				Cache:         &off,
+				IgnoreColumns: []string{"password_hash"},
+				Columns:       map[string]ColumnConfig{"token": {Ignore: true}},
+			},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	users := cfg.table("users")
+	if users.ModelName != "Member" || users.Cache {
+		t.Errorf("users options = %+v", users)
+	}
+	if !users.ignored("password_hash") || !users.ignored("token") || users.ignored("email") {
+		t.Errorf("users ignored columns = %v", users.IgnoreColumns)
+	}
+	if !users.has(LayerService) || users.has(LayerController) {
+		t.Errorf("users layers = %v", users.Layers)
+	}
+
+	if def := (*GenConfig)(nil).table("user_roles"); def.ModelName != "UserRoles" || !def.Cache || !def.has(LayerRoutes) {
+		t.Errorf("default options = %+v", def)
+	}
+
+	invalid := &GenConfig{Layers: []string{LayerModel, LayerController}}
+	if err := invalid.Validate(); err == nil {
+		t.Errorf("Validate() expected error for controller without service, got nil")
+	}
+}
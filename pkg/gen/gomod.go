@@ -5,10 +5,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
 )
 
-// GenerateGoMod 生成 go.mod 文件
+// GenerateGoMod 生成 go.mod 文件，已存在时跳过
 func (g *DatabaseGenerator) GenerateGoMod() error {
 	modulePath := getModulePath(g.config.Module)
 	if modulePath == "" {
@@ -42,7 +45,7 @@ require (
 	go.uber.org/zap v1.27.0
 	gorm.io/driver/mysql v1.5.2
 	gorm.io/gorm v1.25.5
-)
+%s)
 
 require (
 	github.com/bytedance/sonic v1.10.2 // indirect
@@ -89,16 +92,35 @@ require (
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
-`, modulePath)
+`, modulePath, g.typeRequires())
 
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
 	if err := os.WriteFile(goModPath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("写入 go.mod 失败: %w", err)
 	}
 
 	fmt.Println("     ✓ go.mod 创建成功")
 	fmt.Printf("     模块路径: %s\n", modulePath)
+	g.goModCreated = true
+
+	return nil
+}
+
+// tidyGoMod 对本次生成的 go.mod 运行 go mod tidy，不阻塞
+//
+// 需要在全部代码生成之后调用，否则 tidy 会删掉尚未生成的代码所需的依赖
+func (g *DatabaseGenerator) tidyGoMod() {
+	if !g.goModCreated {
+		return
+	}
+
+	outputPath := g.config.Output
+	if outputPath == "./internal" {
+		outputPath = "."
+	}
 
-	// 尝试运行 go mod tidy，但不阻塞
 	fmt.Println("📦 正在运行 go mod tidy (可能需要几分钟)...")
 	go func() {
 		if err := g.runGoModTidy(outputPath); err != nil {
@@ -108,8 +130,29 @@ require (
 			fmt.Println("     ✓ go mod tidy 完成")
 		}
 	}()
+}
 
-	return nil
+// typeRequires 模型字段类型依赖的第三方模块（datatypes.JSON、decimal.Decimal、pq 数组）
+func (g *DatabaseGenerator) typeRequires() string {
+	var goTypes []string
+	for _, schema := range g.schemas {
+		for _, f := range schema.Fields {
+			goTypes = append(goTypes, f.GoType)
+		}
+	}
+
+	modules := typemap.Modules(goTypes...)
+	paths := make([]string, 0, len(modules))
+	for path := range modules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "\t%s %s\n", path, modules[path])
+	}
+	return b.String()
 }
 
 // runGoModTidy 运行 go mod tidy
@@ -0,0 +1,180 @@
+package gen
+
+import (
+	"sort"
+	"strings"
+)
+
+// IndexFinder 由索引生成的 Repository 查询方法
+//
+// 唯一索引的完整列组合返回单条记录（By<列>），其它组合（非唯一索引、索引的最左前缀）返回分页列表（ListBy<列>）。
+type IndexFinder struct {
+	Name   string     // 方法名中的列部分，如 UserIDAndStatus
+	Index  string     // 来源索引名
+	Unique bool       // 是否为唯一索引的完整列组合
+	Fields []KeyField // 查询列，顺序与索引一致
+
+	// Unscoped 软删除表的唯一索引：已软删除的记录仍占用唯一值，额外生成包括已删除记录的 <Method>Unscoped
+	Unscoped bool
+}
+
+// Method 方法名，如 ByEmail、ListByUserIDAndStatus
+func (f IndexFinder) Method() string {
+	if f.Unique {
+		return "By" + f.Name
+	}
+	return "ListBy" + f.Name
+}
+
+// CheckMethod Service 检查唯一约束冲突时调用的方法，软删除表为 <Method>Unscoped
+func (f IndexFinder) CheckMethod() string {
+	if f.Unscoped {
+		return f.Method() + "Unscoped"
+	}
+	return f.Method()
+}
+
+// Params 方法参数声明，如 "userID uint64, status string"
+func (f IndexFinder) Params() string {
+	return fieldParams(f.Fields)
+}
+
+// Args 从模型变量取查询参数，如 "user.Email" 或 "*user.Phone"
+func (f IndexFinder) Args(variable string) string {
+	args := make([]string, len(f.Fields))
+	for i, field := range f.Fields {
+		args[i] = field.Value(variable)
+	}
+	return strings.Join(args, ", ")
+}
+
+// Guard 模型中所有查询列都已赋值的条件表达式，无法判断时返回空；
+// 有零值列时不做唯一性检查（Updates 不会更新零值字段，NULL 也不会违反唯一索引）
+func (f IndexFinder) Guard(variable string) string {
+	var conds []string
+	for _, field := range f.Fields {
+		if cond := field.NonZero(variable); cond != "" {
+			conds = append(conds, cond)
+		}
+	}
+	return strings.Join(conds, " && ")
+}
+
+// Columns 查询列，如 "user_id, status"
+func (f IndexFinder) Columns() string {
+	columns := make([]string, len(f.Fields))
+	for i, field := range f.Fields {
+		columns[i] = field.Column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// buildIndexFinders 为表的索引生成查询方法：每个索引的完整列组合及其最左前缀各生成一个方法，
+// 相同的列组合只生成一次（唯一索引优先）；与主键相同、含忽略列或与外键 ListBy 方法重名的组合跳过。
+// 软删除表的 deleted_at 列由 GORM 自动过滤，索引只取其之前的列（且不再视为唯一）
+func buildIndexFinders(table *DetailedTableInfo, opts tableOptions, fkFinders []ForeignKeyFinder) []IndexFinder {
+	fields := make(map[string]FieldInfo)
+	for _, f := range table.Fields {
+		fields[f.Name] = f
+	}
+
+	var pkColumns []string
+	for _, f := range buildPrimaryKey(table, opts).Fields {
+		pkColumns = append(pkColumns, f.Column)
+	}
+	pkSet := columnSet(pkColumns)
+
+	softDelete := hasSoftDelete(table, opts)
+
+	taken := make(map[string]bool)
+	for _, f := range fkFinders {
+		taken["ListBy"+f.FieldName] = true
+	}
+
+	var finders []IndexFinder
+	seen := make(map[string]int)
+	for _, idx := range table.Indexes {
+		if idx.Primary {
+			continue
+		}
+
+		var columns []KeyField
+		unique := idx.Unique
+		for _, name := range idx.Columns {
+			if name == "deleted_at" && softDelete {
+				unique = false
+				break
+			}
+			f, ok := fields[name]
+			if !ok || opts.ignored(name) {
+				columns = nil
+				break
+			}
+			columns = append(columns, indexField(f, opts))
+		}
+
+		for n := 1; n <= len(columns); n++ {
+			finder := IndexFinder{Index: idx.Name, Unique: unique && n == len(columns), Fields: columns[:n]}
+			finder.Unscoped = softDelete && finder.Unique
+
+			var names, cols []string
+			for _, f := range finder.Fields {
+				names = append(names, f.FieldName)
+				cols = append(cols, f.Column)
+			}
+			finder.Name = strings.Join(names, "And")
+
+			if pkSet != "" && columnSet(cols) == pkSet || taken[finder.Method()] {
+				continue
+			}
+
+			key := strings.Join(cols, ",")
+			if i, ok := seen[key]; ok {
+				// 唯一索引优先；同为列表查询时，注释中标注列组合完全一致的索引
+				if finder.Unique && !finders[i].Unique || !finders[i].Unique && n == len(columns) {
+					finders[i] = finder
+				}
+				continue
+			}
+			seen[key] = len(finders)
+			finders = append(finders, finder)
+		}
+	}
+
+	return finders
+}
+
+// indexField 索引列对应的查询参数，参数类型与模型字段一致（去掉指针），枚举类型带 model 包前缀
+func indexField(f FieldInfo, opts tableOptions) KeyField {
+	goType := strings.TrimPrefix(opts.Columns[f.Name].Type, "*")
+	if goType == "" {
+		goType = strings.TrimPrefix(f.GoType, "*")
+		if f.Enum != nil {
+			goType = "model." + goType
+		}
+	}
+	if goType == "" {
+		goType = opts.Types.BaseType(typeColumn(f))
+	}
+
+	modelType := opts.Columns[f.Name].Type
+	if modelType == "" {
+		modelType = f.GoType
+	}
+
+	fieldName := columnFieldName(f.Name)
+	return KeyField{
+		Column:    f.Name,
+		FieldName: fieldName,
+		GoType:    goType,
+		Var:       paramName(fieldName),
+		Pointer:   strings.HasPrefix(modelType, "*"),
+	}
+}
+
+// columnSet 列集合的规范表示（与顺序无关）
+func columnSet(columns []string) string {
+	sorted := append([]string(nil), columns...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
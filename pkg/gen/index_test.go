@@ -0,0 +1,77 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBuildIndexFinders 验证复合索引、最左前缀、唯一索引优先、与主键和外键方法的去重，以及唯一性检查的取值
+func TestBuildIndexFinders(t *testing.T) {
+	ddl := "CREATE TABLE `orders` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `user_id` bigint unsigned NOT NULL,\n" +
+		"  `status` int NOT NULL,\n" +
+		"  `shop_id` int NOT NULL,\n" +
+		"  `email` varchar(100) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_user_status_shop` (`user_id`, `status`, `shop_id`),\n" +
+		"  KEY `idx_user_status` (`user_id`, `status`),\n" +
+		"  KEY `idx_id_status` (`id`, `status`),\n" +
+		"  KEY `idx_email` (`email`),\n" +
+		"  UNIQUE KEY `uk_email` (`email`)\n" +
+		");"
+
+	table := parseSchema(t, ddl, nil).Table("orders")
+
+	fkFinders := []ForeignKeyFinder{{Column: "user_id", FieldName: "UserID", GoType: "uint64"}}
+	finders := buildIndexFinders(table, (*GenConfig)(nil).table("orders"), fkFinders)
+
+	var got []string
+	for _, f := range finders {
+		got = append(got, f.Method()+"("+f.Params()+") "+f.Index)
+	}
+	want := []string{
+		"ListByUserIDAndStatus(userID uint64, status int32) idx_user_status",
+		"ByUserIDAndStatusAndShopID(userID uint64, status int32, shopID int32) uk_user_status_shop",
+		"ListByIDAndStatus(id uint64, status int32) idx_id_status",
+		"ByEmail(email string) uk_email",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildIndexFinders() =\n%v\nwant\n%v", got, want)
+	}
+
+	// 唯一性检查：可空列解引用，零值不检查
+	email := finders[3]
+	if got := email.Args("orders"); got != "*orders.Email" {
+		t.Errorf("ByEmail Args() = %q, want *orders.Email", got)
+	}
+	if got := finders[1].Guard("orders"); got != "orders.UserID != 0 && orders.Status != 0 && orders.ShopID != 0" {
+		t.Errorf("Guard() = %q", got)
+	}
+	if got := email.Guard("orders"); got != "orders.Email != nil" {
+		t.Errorf("ByEmail Guard() = %q, want orders.Email != nil", got)
+	}
+}
+
+// TestIndexFindersGenerated 验证索引查询方法与唯一性检查生成到仓储和服务中，且生成的代码能编译
+func TestIndexFindersGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `orders` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `user_id` bigint unsigned NOT NULL,\n" +
+		"  `status` int NOT NULL, `shop_id` int NOT NULL, `email` varchar(100) DEFAULT NULL, PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_user_status_shop` (`user_id`, `status`, `shop_id`), KEY `idx_user_status` (`user_id`, `status`),\n" +
+		"  UNIQUE KEY `uk_email` (`email`));"
+	dir := generateSQL(t, ddl, nil)
+
+	decls := funcDecls(t, dir, "internal/repository/orders.go", "internal/service/orders.go")
+	got := signatures(decls, "OrdersRepository.ListByUserIDAndStatus", "OrdersRepository.ByUserIDAndStatusAndShopID",
+		"OrdersRepository.ByEmail", "OrdersService.checkUnique")
+	want := map[string]string{
+		"OrdersRepository.ListByUserIDAndStatus":      "func(ctx context.Context, userID uint64, status int32, page, pageSize int, preloads ...string) ([]*model.Orders, int64, error)",
+		"OrdersRepository.ByUserIDAndStatusAndShopID": "func(ctx context.Context, userID uint64, status int32, shopID int32, preloads ...string) (*model.Orders, error)",
+		"OrdersRepository.ByEmail":                    "func(ctx context.Context, email string, preloads ...string) (*model.Orders, error)",
+		"OrdersService.checkUnique":                   "func(ctx context.Context, orders *model.Orders, updating bool) error",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("signatures =\n%v\nwant\n%v", got, want)
+	}
+	vetGenerated(t, dir)
+}
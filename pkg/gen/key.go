@@ -0,0 +1,274 @@
+package gen
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// KeyField 主键中的一列
+type KeyField struct {
+	Column    string // 列名，如 user_id
+	FieldName string // 模型字段名，如 UserID
+	GoType    string // Go 类型（不含指针），如 int64、string
+	Var       string // 方法参数名，如 userID
+	Param     string // 路由参数名：单列主键为 id，复合主键为列名
+	Pointer   bool   // 模型字段是否为指针（可空列），取值时需要解引用
+}
+
+// ParseFunc 从路径参数解析整数主键使用的 strconv 函数
+func (f KeyField) ParseFunc() string {
+	if strings.HasPrefix(f.GoType, "uint") {
+		return "ParseUint"
+	}
+	return "ParseInt"
+}
+
+// BitSize 解析整数主键时的位数，保证转换不会溢出
+func (f KeyField) BitSize() int {
+	switch strings.TrimPrefix(strings.TrimPrefix(f.GoType, "u"), "int") {
+	case "8":
+		return 8
+	case "16":
+		return 16
+	case "32":
+		return 32
+	}
+	return 64
+}
+
+// IsString 主键是否为字符串（如 UUID），路径参数无需转换
+func (f KeyField) IsString() bool {
+	return f.GoType == "string"
+}
+
+// Zero 主键的零值字面量，用于判断请求是否带了主键；非字符串、非数值类型返回空
+func (f KeyField) Zero() string {
+	switch {
+	case f.IsString():
+		return `""`
+	case strings.HasPrefix(f.GoType, "int"), strings.HasPrefix(f.GoType, "uint"), strings.HasPrefix(f.GoType, "float"):
+		return "0"
+	}
+	return ""
+}
+
+// Value 从模型变量取该列值的表达式，指针字段解引用，如 *user.Email
+func (f KeyField) Value(variable string) string {
+	if f.Pointer {
+		return "*" + variable + "." + f.FieldName
+	}
+	return variable + "." + f.FieldName
+}
+
+// NonZero 判断模型字段已赋值的表达式，如 user.Email != ""；无法判断的类型返回空
+func (f KeyField) NonZero(variable string) string {
+	expr := variable + "." + f.FieldName
+	switch {
+	case f.Pointer:
+		return expr + " != nil"
+	case f.IsString(), strings.HasPrefix(f.GoType, "model."): // 数据库枚举均为字符串枚举
+		return expr + ` != ""`
+	case f.Zero() == "0":
+		return expr + " != 0"
+	case f.GoType == "bool":
+		return expr
+	case f.GoType == "time.Time", f.GoType == "decimal.Decimal":
+		return "!" + expr + ".IsZero()"
+	case strings.HasPrefix(f.GoType, "sql.Null"), f.GoType == "decimal.NullDecimal":
+		return expr + ".Valid"
+	case strings.HasPrefix(f.GoType, "[]"), f.GoType == "datatypes.JSON", strings.HasPrefix(f.GoType, "pq."):
+		return "len(" + expr + ") > 0"
+	}
+	return ""
+}
+
+// routable 主键值能否从路径参数解析
+func (f KeyField) routable() bool {
+	switch f.GoType {
+	case "string", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// PrimaryKey 表的主键，决定 GetByID/Delete 的参数与路由参数
+type PrimaryKey struct {
+	Fields []KeyField
+}
+
+// Composite 是否为复合主键
+func (k PrimaryKey) Composite() bool {
+	return len(k.Fields) > 1
+}
+
+// Params 方法参数声明，如 "id int64" 或 "userID int64, roleID int64"
+func (k PrimaryKey) Params() string {
+	return fieldParams(k.Fields)
+}
+
+// Args 调用参数，如 "id" 或 "userID, roleID"
+func (k PrimaryKey) Args() string {
+	return fieldArgs(k.Fields)
+}
+
+// ModelArgs 从模型变量取主键的调用参数，如 "user.ID" 或 "userRole.UserID, userRole.RoleID"
+func (k PrimaryKey) ModelArgs(variable string) string {
+	parts := make([]string, len(k.Fields))
+	for i, f := range k.Fields {
+		parts[i] = variable + "." + f.FieldName
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Route 路由参数路径，如 /:id 或 /:user_id/:role_id
+func (k PrimaryKey) Route() string {
+	var b strings.Builder
+	for _, f := range k.Fields {
+		b.WriteString("/:" + f.Param)
+	}
+	return b.String()
+}
+
+// SwaggerRoute swagger 格式的路由参数路径，如 /{id} 或 /{user_id}/{role_id}
+func (k PrimaryKey) SwaggerRoute() string {
+	var b strings.Builder
+	for _, f := range k.Fields {
+		b.WriteString("/{" + f.Param + "}")
+	}
+	return b.String()
+}
+
+// CacheFormat 缓存键中主键部分的格式串，如 %v 或 %v:%v
+func (k PrimaryKey) CacheFormat() string {
+	return strings.TrimSuffix(strings.Repeat("%v:", len(k.Fields)), ":")
+}
+
+// Imports 主键类型需要的导入路径（如 time），exclude 为模板中已导入的包
+func (k PrimaryKey) Imports(exclude ...string) []string {
+	return fieldImports(k.Fields, exclude...)
+}
+
+// routable 主键的每一列都能从路径参数解析
+func (k PrimaryKey) routable() bool {
+	for _, f := range k.Fields {
+		if !f.routable() {
+			return false
+		}
+	}
+	return true
+}
+
+// primaryKey 获取表的主键，无法获取表结构时按 id uint 处理
+func (g *DatabaseGenerator) primaryKey(tableName string) PrimaryKey {
+	schema, err := g.tableSchema(tableName)
+	if err != nil {
+		return PrimaryKey{}.orDefault()
+	}
+	return buildPrimaryKey(schema, g.options(tableName)).orDefault()
+}
+
+// buildPrimaryKey 从表结构推导主键（忽略的列除外），没有主键时返回空
+func buildPrimaryKey(table *DetailedTableInfo, opts tableOptions) PrimaryKey {
+	var key PrimaryKey
+	for _, f := range table.Fields {
+		if !f.PrimaryKey || opts.ignored(f.Name) {
+			continue
+		}
+
+		goType := strings.TrimPrefix(opts.Columns[f.Name].Type, "*")
+		if goType == "" {
+			goType = opts.Types.BaseType(typeColumn(f))
+		}
+		key.Fields = append(key.Fields, KeyField{
+			Column:    f.Name,
+			FieldName: columnFieldName(f.Name),
+			GoType:    goType,
+			Var:       paramName(columnFieldName(f.Name)),
+			Param:     f.Name,
+		})
+	}
+
+	return key
+}
+
+// orDefault 单列主键的参数统一命名为 id；没有主键时按 id uint 处理（与早期版本生成的代码一致）
+func (k PrimaryKey) orDefault() PrimaryKey {
+	switch len(k.Fields) {
+	case 0:
+		return PrimaryKey{Fields: []KeyField{{Column: "id", FieldName: "ID", GoType: "uint", Var: "id", Param: "id"}}}
+	case 1:
+		f := k.Fields[0]
+		f.Var, f.Param = "id", "id"
+		return PrimaryKey{Fields: []KeyField{f}}
+	}
+	return k
+}
+
+// paramName 字段名转换为参数名：ID -> id，UserID -> userID，UUID -> uuid
+func paramName(fieldName string) string {
+	runes := []rune(fieldName)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	// 大写前缀后面紧跟小写字母时，最后一个大写字母属于下一个单词（URLPath -> urlPath）
+	if n > 1 && n < len(runes) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	name := string(runes)
+	if token.IsKeyword(name) || reservedParams[name] {
+		name += "Key"
+	}
+	return name
+}
+
+// reservedParams 生成的方法中已占用的变量名，列参数与之同名时需要改名
+var reservedParams = map[string]bool{
+	"ctx": true, "page": true, "pageSize": true, "preloads": true, "do": true, "err": true, "ok": true,
+}
+
+// fieldParams 列的方法参数声明，如 "userID int64, status string"
+func fieldParams(fields []KeyField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.Var + " " + f.GoType
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fieldArgs 列的调用参数，如 "userID, status"
+func fieldArgs(fields []KeyField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.Var
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fieldImports 列类型需要的导入路径，exclude 为模板中已导入的包
+func fieldImports(fields []KeyField, exclude ...string) []string {
+	var goTypes []string
+	for _, f := range fields {
+		goTypes = append(goTypes, f.GoType)
+	}
+
+	skip := make(map[string]bool)
+	for _, path := range exclude {
+		skip[path] = true
+	}
+
+	var result []string
+	for _, path := range typemap.Imports(goTypes...) {
+		if !skip[path] {
+			result = append(result, path)
+		}
+	}
+	return result
+}
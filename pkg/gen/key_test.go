@@ -0,0 +1,78 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBuildPrimaryKey 验证 UUID 主键、复合主键与无主键表的方法参数和路由参数
+func TestBuildPrimaryKey(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` char(36) NOT NULL, `name` varchar(100), PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `user_roles` (`user_id` char(36) NOT NULL, `role_id` int unsigned NOT NULL, PRIMARY KEY (`user_id`, `role_id`));\n" +
+		"CREATE TABLE `logs` (`message` text);"
+
+	schema := parseSchema(t, ddl, nil)
+
+	tests := []struct {
+		table      string
+		params     string
+		route      string
+		cache      string
+		modelArgs  string
+		pathParams []string
+	}{
+		{"users", "id string", "/:id", "%v", "users.ID", []string{"id"}},
+		{"user_roles", "userID string, roleID uint32", "/:user_id/:role_id", "%v:%v", "userRoles.UserID, userRoles.RoleID", []string{"user_id", "role_id"}},
+		{"logs", "id uint", "/:id", "%v", "logs.ID", []string{"id"}},
+	}
+
+	for _, tt := range tests {
+		opts := (*GenConfig)(nil).table(tt.table)
+		key := buildPrimaryKey(schema.Table(tt.table), opts).orDefault()
+
+		if got := key.Params(); got != tt.params {
+			t.Errorf("%s Params() = %q, want %q", tt.table, got, tt.params)
+		}
+		if got := key.Route(); got != tt.route {
+			t.Errorf("%s Route() = %q, want %q", tt.table, got, tt.route)
+		}
+		if got := key.CacheFormat(); got != tt.cache {
+			t.Errorf("%s CacheFormat() = %q, want %q", tt.table, got, tt.cache)
+		}
+		if got := key.ModelArgs(toLowerCamelCase(opts.ModelName)); got != tt.modelArgs {
+			t.Errorf("%s ModelArgs() = %q, want %q", tt.table, got, tt.modelArgs)
+		}
+		for i, f := range key.Fields {
+			if f.Param != tt.pathParams[i] {
+				t.Errorf("%s Fields[%d].Param = %q, want %q", tt.table, i, f.Param, tt.pathParams[i])
+			}
+		}
+	}
+
+	if got := paramName("URLPath"); got != "urlPath" {
+		t.Errorf("paramName(URLPath) = %q, want urlPath", got)
+	}
+	if got := paramName("Type"); got != "typeKey" {
+		t.Errorf("paramName(Type) = %q, want typeKey", got)
+	}
+}
+
+// TestPrimaryKeyGenerated 验证 UUID 主键与复合主键生成的仓储与控制器方法签名，且生成的代码能编译
+func TestPrimaryKeyGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` char(36) NOT NULL, `name` varchar(100), PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `user_roles` (`user_id` char(36) NOT NULL, `role_id` int unsigned NOT NULL, PRIMARY KEY (`user_id`, `role_id`));"
+	dir := generateSQL(t, ddl, nil)
+
+	decls := funcDecls(t, dir, "internal/repository/users.go", "internal/repository/userroles.go", "internal/controller/userroles.go")
+	got := signatures(decls, "UsersRepository.GetByID", "UserRolesRepository.GetByID", "UserRolesRepository.Delete", "UserRolesController.parseKey")
+	want := map[string]string{
+		"UsersRepository.GetByID":      "func(ctx context.Context, id string, preloads ...string) (*model.Users, error)",
+		"UserRolesRepository.GetByID":  "func(ctx context.Context, userID string, roleID uint32, preloads ...string) (*model.UserRoles, error)",
+		"UserRolesRepository.Delete":   "func(ctx context.Context, userID string, roleID uint32) error",
+		"UserRolesController.parseKey": "func(ctx *gin.Context) (userID string, roleID uint32, ok bool)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("signatures =\n%v\nwant\n%v", got, want)
+	}
+	vetGenerated(t, dir)
+}
@@ -0,0 +1,470 @@
+package gen
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// 列表查询的过滤运算符，查询参数写作 column[op]=value，省略 [op] 时为 eq
+const (
+	FilterEq   = "eq"   // 等于
+	FilterNe   = "ne"   // 不等于
+	FilterGt   = "gt"   // 大于
+	FilterGte  = "gte"  // 大于等于
+	FilterLt   = "lt"   // 小于
+	FilterLte  = "lte"  // 小于等于
+	FilterLike = "like" // 模糊匹配（包含），仅字符串列
+	FilterIn   = "in"   // 在列表中，多个值用逗号分隔
+)
+
+// filterOps 各类列默认允许的运算符
+var filterOps = map[string][]string{
+	"string": {FilterEq, FilterNe, FilterLike, FilterIn},
+	"int":    {FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn},
+	"uint":   {FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn},
+	"float":  {FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn},
+	"time":   {FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte},
+	"bool":   {FilterEq, FilterNe},
+	"enum":   {FilterEq, FilterNe, FilterIn},
+}
+
+// isFilterOp 是否为支持的过滤运算符
+func isFilterOp(op string) bool {
+	return op == FilterLike || slices.Contains(filterOps["int"], op)
+}
+
+// ListColumn List 接口可过滤、排序或选择的列（生成时确定的白名单）
+type ListColumn struct {
+	KeyField
+	JSON     string   // JSON 字段名，fields 参数按它裁剪响应
+	Kind     string   // 列类别：string、int、uint、float、time、bool、enum，为空时只能出现在 fields 中
+	Numeric  bool     // 枚举是否为整数枚举
+	Ops      []string // 允许的过滤运算符
+	Sortable bool     // 是否允许排序
+}
+
+// OpsList 运算符列表的 Go 字面量，如 []string{OpEq, OpIn}
+func (c ListColumn) OpsList() string {
+	parts := make([]string, len(c.Ops))
+	for i, op := range c.Ops {
+		parts[i] = "Op" + strings.ToUpper(op[:1]) + op[1:]
+	}
+	return "[]string{" + strings.Join(parts, ", ") + "}"
+}
+
+// Parser 把查询参数转换为列类型的函数表达式（对应生成的 repository/query.go 中的解析函数）
+func (c ListColumn) Parser() string {
+	switch c.Kind {
+	case "int", "uint":
+		return fmt.Sprintf("parse%s[%s](%d)", strings.TrimPrefix(c.ParseFunc(), "Parse"), c.GoType, c.BitSize())
+	case "float":
+		return fmt.Sprintf("parseFloat[%s](%s)", c.GoType, strings.TrimPrefix(c.GoType, "float"))
+	case "time":
+		return "parseTime"
+	case "enum":
+		if c.Numeric {
+			return fmt.Sprintf("valuer(parseInt[%s](64))", c.GoType)
+		}
+		return fmt.Sprintf("valuer(parseString[%s])", c.GoType)
+	}
+	return "parseString[" + c.GoType + "]"
+}
+
+// Filterable 是否允许过滤
+func (c ListColumn) Filterable() bool {
+	return len(c.Ops) > 0
+}
+
+// buildListColumns 推导表的列表查询白名单：按模型字段类型确定可用运算符，
+// gen.yaml 中列的 filter/sort 可收窄或关闭；忽略的列和软删除列不参与
+func buildListColumns(table *DetailedTableInfo, opts tableOptions) []ListColumn {
+	softDelete := hasSoftDelete(table, opts)
+
+	var columns []ListColumn
+	for _, f := range table.Fields {
+		if opts.ignored(f.Name) || f.Name == "deleted_at" && softDelete {
+			continue
+		}
+
+		override := opts.Columns[f.Name]
+		col := ListColumn{KeyField: indexField(f, opts), JSON: f.Name}
+		if override.JSON != "" {
+			col.JSON = override.JSON
+		}
+
+		col.Kind = listColumnKind(col.GoType)
+		if f.Enum != nil && override.Type == "" {
+			col.Kind = "enum"
+			col.Numeric = f.Enum.BaseType != "string"
+		}
+		if col.Kind != "" {
+			col.Ops = filterOps[col.Kind]
+			col.Sortable = col.Kind != "bool"
+		}
+
+		if override.Filter != nil {
+			var ops []string
+			for _, op := range override.Filter {
+				if slices.Contains(col.Ops, op) {
+					ops = append(ops, op)
+				}
+			}
+			col.Ops = ops
+		}
+		if override.Sort != nil {
+			col.Sortable = *override.Sort && col.Kind != ""
+		}
+
+		columns = append(columns, col)
+	}
+
+	return columns
+}
+
+// filterableColumns 是否有可过滤的列
+func filterableColumns(columns []ListColumn) bool {
+	for _, c := range columns {
+		if c.Filterable() {
+			return true
+		}
+	}
+	return false
+}
+
+// sortableColumns 可排序列的 case 列表，如 "id", "created_at"；没有可排序列时返回空
+func sortableColumns(columns []ListColumn) string {
+	var names []string
+	for _, c := range columns {
+		if c.Sortable {
+			names = append(names, strconv.Quote(c.Column))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// listSelectAlways 选择字段时始终查询的列：主键与外键（预加载 belongsTo 关联需要外键值）
+func listSelectAlways(config RepositoryConfig) []KeyField {
+	fields := append([]KeyField(nil), config.Key.Fields...)
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		seen[f.Column] = true
+	}
+	for _, f := range config.Finders {
+		if !seen[f.Column] {
+			seen[f.Column] = true
+			fields = append(fields, KeyField{Column: f.Column, FieldName: f.FieldName})
+		}
+	}
+	return fields
+}
+
+// listColumnKind Go 类型对应的列类别，不支持比较的类型（JSON、decimal.Decimal、sql.Null* 等）返回空
+func listColumnKind(goType string) string {
+	switch {
+	case goType == "string":
+		return "string"
+	case goType == "bool":
+		return "bool"
+	case goType == "time.Time":
+		return "time"
+	case goType == "float32", goType == "float64":
+		return "float"
+	case strings.HasPrefix(goType, "uint") && goType != "uintptr":
+		return "uint"
+	case strings.HasPrefix(goType, "int"):
+		return "int"
+	}
+	return ""
+}
+
+// listQueryTmpl 列表查询的公共定义（解析查询参数、编译过滤条件、裁剪响应字段），所有仓储共用
+const listQueryTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Repository: 列表查询（过滤、排序、字段选择）
+
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gen/field"
+)
+
+// 过滤运算符，查询参数写作 column[op]=value，省略 [op] 时为 eq
+const (
+	OpEq   = "eq"   // 等于
+	OpNe   = "ne"   // 不等于
+	OpGt   = "gt"   // 大于
+	OpGte  = "gte"  // 大于等于
+	OpLt   = "lt"   // 小于
+	OpLte  = "lte"  // 小于等于
+	OpLike = "like" // 模糊匹配（包含）
+	OpIn   = "in"   // 在列表中，多个值用逗号分隔
+)
+
+// ErrInvalidQuery 列表查询参数不合法：列不在白名单中、运算符不支持或取值无法解析
+var ErrInvalidQuery = errors.New("无效的查询参数")
+
+// Filter 一个过滤条件
+type Filter struct {
+	Column string   // 列名
+	Op     string   // 运算符
+	Values []string // 取值，in 运算符可有多个
+}
+
+// Sort 一个排序条件
+type Sort struct {
+	Column string // 列名
+	Desc   bool   // 是否倒序
+}
+
+// ListQuery 列表查询条件，由 ParseListQuery 从 URL 查询参数解析，
+// 各仓储的 List 方法按生成时的白名单校验列名和运算符
+type ListQuery struct {
+	Filters []Filter // 过滤条件，多个条件之间为 AND
+	Sorts   []Sort   // 排序，按出现顺序
+	Fields  []string // 返回的列，为空时返回全部列
+}
+
+// listQueryReserved 不作为过滤条件的查询参数
+var listQueryReserved = map[string]bool{
+	"page": true, "page_size": true, "preload": true, "sort": true, "fields": true, "cursor": true,
+}
+
+// ParseListQuery 解析列表查询参数，例如：
+//
+//	?status=1&created_at[gte]=2024-01-01&id[in]=1,2,3&sort=-created_at,id&fields=id,title
+func ParseListQuery(values url.Values) ListQuery {
+	var q ListQuery
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !listQueryReserved[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		column, op := key, OpEq
+		if i := strings.Index(key, "["); i > 0 && strings.HasSuffix(key, "]") {
+			column, op = key[:i], key[i+1:len(key)-1]
+		}
+		for _, value := range values[key] {
+			f := Filter{Column: column, Op: op, Values: []string{value}}
+			if op == OpIn {
+				f.Values = strings.Split(value, ",")
+			}
+			q.Filters = append(q.Filters, f)
+		}
+	}
+
+	for _, s := range splitList(values["sort"]) {
+		if strings.HasPrefix(s, "-") {
+			q.Sorts = append(q.Sorts, Sort{Column: s[1:], Desc: true})
+		} else {
+			q.Sorts = append(q.Sorts, Sort{Column: strings.TrimPrefix(s, "+")})
+		}
+	}
+	q.Fields = splitList(values["fields"])
+
+	return q
+}
+
+// Project 只保留每条记录中指定的 JSON 字段，用于 fields 参数裁剪响应
+func Project[T any](items []T, keys []string) ([]map[string]json.RawMessage, error) {
+	result := make([]map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+
+		picked := make(map[string]json.RawMessage, len(keys))
+		for _, key := range keys {
+			if value, ok := all[key]; ok {
+				picked[key] = value
+			}
+		}
+		result[i] = picked
+	}
+	return result, nil
+}
+
+// cursorOrder 游标分页的排序方向：只允许按游标列排序，未指定时升序
+func cursorOrder(sorts []Sort, column string) (desc bool, err error) {
+	if len(sorts) == 0 {
+		return false, nil
+	}
+	if len(sorts) > 1 || sorts[0].Column != column {
+		return false, fmt.Errorf("%w: 游标分页只支持按 %s 排序", ErrInvalidQuery, column)
+	}
+	return sorts[0].Desc, nil
+}
+
+// encodeCursor 把游标列的值编码为不透明的游标，客户端原样传回 cursor 参数
+func encodeCursor(value interface{}) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprint(value)))
+}
+
+// decodeCursor 解析 encodeCursor 生成的游标
+func decodeCursor[T any](cursor string, parse func(string) (T, error)) (T, error) {
+	var value T
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		value, err = parse(string(data))
+	}
+	if err != nil {
+		return value, fmt.Errorf("%w: 无效的游标 %q", ErrInvalidQuery, cursor)
+	}
+	return value, nil
+}
+
+// splitList 合并逗号分隔的多个参数值，去掉空白项
+func splitList(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
+// orderedField 支持比较运算的 GORM Gen 字段，如 field.String、field.Int64、field.Time
+type orderedField[T any] interface {
+	Eq(T) field.Expr
+	Neq(T) field.Expr
+	Gt(T) field.Expr
+	Gte(T) field.Expr
+	Lt(T) field.Expr
+	Lte(T) field.Expr
+	In(...T) field.Expr
+}
+
+// compare 将过滤条件编译为字段表达式，ops 为该列允许的运算符，parse 把查询参数转换为列类型
+func compare[T any](col orderedField[T], f Filter, ops []string, parse func(string) (T, error)) (field.Expr, error) {
+	if err := checkOp(f, ops); err != nil {
+		return nil, err
+	}
+	if f.Op == OpLike {
+		if s, ok := col.(interface{ Like(string) field.Expr }); ok {
+			return s.Like("%" + f.Values[0] + "%"), nil
+		}
+	}
+
+	values := make([]T, len(f.Values))
+	for i, s := range f.Values {
+		v, err := parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s 的取值 %q 无法解析", ErrInvalidQuery, f.Column, s)
+		}
+		values[i] = v
+	}
+
+	switch f.Op {
+	case OpEq:
+		return col.Eq(values[0]), nil
+	case OpNe:
+		return col.Neq(values[0]), nil
+	case OpGt:
+		return col.Gt(values[0]), nil
+	case OpGte:
+		return col.Gte(values[0]), nil
+	case OpLt:
+		return col.Lt(values[0]), nil
+	case OpLte:
+		return col.Lte(values[0]), nil
+	case OpIn:
+		return col.In(values...), nil
+	}
+	return nil, fmt.Errorf("%w: %s 不支持运算符 %s", ErrInvalidQuery, f.Column, f.Op)
+}
+
+// compareBool 将布尔列的过滤条件编译为字段表达式
+func compareBool(col field.Bool, f Filter, ops []string) (field.Expr, error) {
+	if err := checkOp(f, ops); err != nil {
+		return nil, err
+	}
+	v, err := strconv.ParseBool(f.Values[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s 的取值 %q 无法解析", ErrInvalidQuery, f.Column, f.Values[0])
+	}
+	if f.Op == OpNe {
+		v = !v
+	}
+	return col.Is(v), nil
+}
+
+// checkOp 校验运算符是否在该列的白名单中
+func checkOp(f Filter, ops []string) error {
+	for _, op := range ops {
+		if op == f.Op {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s 不支持运算符 %s", ErrInvalidQuery, f.Column, f.Op)
+}
+
+// parseString 字符串（及字符串枚举）列的取值
+func parseString[T ~string](s string) (T, error) {
+	return T(s), nil
+}
+
+// parseInt 有符号整数列的取值，bitSize 保证转换不会溢出
+func parseInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseInt(s, 10, bitSize)
+		return T(v), err
+	}
+}
+
+// parseUint 无符号整数列的取值
+func parseUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseUint(s, 10, bitSize)
+		return T(v), err
+	}
+}
+
+// parseFloat 浮点数列的取值
+func parseFloat[T ~float32 | ~float64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseFloat(s, bitSize)
+		return T(v), err
+	}
+}
+
+// parseTime 时间列的取值，支持 RFC3339、"2006-01-02 15:04:05" 与 "2006-01-02"
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法解析时间 %q", s)
+}
+
+// valuer 把枚举列的取值转换为 driver.Valuer（枚举字段在 GORM Gen 中为 field.Field）
+func valuer[T driver.Valuer](parse func(string) (T, error)) func(string) (driver.Valuer, error) {
+	return func(s string) (driver.Valuer, error) {
+		return parse(s)
+	}
+}
+`
@@ -0,0 +1,115 @@
+package gen
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestBuildListColumns 验证列表查询白名单按列类型推导运算符，并可在 gen.yaml 中收窄或关闭
+func TestBuildListColumns(t *testing.T) {
+	ddl := "CREATE TABLE `articles` (`id` bigint unsigned NOT NULL, `title` varchar(100) NOT NULL,\n" +
+		"  `status` enum('draft','published') NOT NULL, `views` int NOT NULL, `meta` json,\n" +
+		"  `created_at` datetime NOT NULL, `deleted_at` datetime NULL, PRIMARY KEY (`id`));"
+
+	table := parseSchema(t, ddl, nil).Table("articles")
+
+	noSort := false
+	cfg := &GenConfig{Overrides: map[string]TableConfig{"articles": {Columns: map[string]ColumnConfig{
+		"title": {Filter: []string{"eq", "gt"}, Sort: &noSort},
+		"views": {Filter: []string{}},
+	}}}}
+	applyEnums(table, cfg.table("articles"))
+
+	got := make(map[string][]string)
+	var sortable []string
+	for _, c := range buildListColumns(table, cfg.table("articles")) {
+		got[c.Column] = c.Ops
+		if c.Sortable {
+			sortable = append(sortable, c.Column)
+		}
+	}
+
+	want := map[string][]string{
+		"id":         {"eq", "ne", "gt", "gte", "lt", "lte", "in"},
+		"title":      {"eq"}, // gt 不适用于字符串列
+		"status":     {"eq", "ne", "in"},
+		"views":      nil,
+		"meta":       nil,
+		"created_at": {"eq", "ne", "gt", "gte", "lt", "lte"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildListColumns() ops =\n%v\nwant\n%v", got, want)
+	}
+	if want := []string{"id", "status", "views", "created_at"}; !reflect.DeepEqual(sortable, want) {
+		t.Errorf("buildListColumns() sortable = %v, want %v", sortable, want)
+	}
+
+	if err := (&GenConfig{Overrides: map[string]TableConfig{"articles": {Columns: map[string]ColumnConfig{
+		"title": {Filter: []string{"between"}},
+	}}}}).Validate(); err == nil {
+		t.Error("Validate() expected error for unknown filter operator")
+	}
+}
+
+// TestListQueryGenerated 验证仓储的 applyListQuery 只接受白名单中的过滤列、运算符与排序列，且生成的代码能编译
+func TestListQueryGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `articles` (`id` bigint unsigned NOT NULL, `title` varchar(100) NOT NULL,\n" +
+		"  `status` enum('draft','published') NOT NULL, `views` int NOT NULL, `meta` json,\n" +
+		"  `created_at` datetime NOT NULL, `deleted_at` datetime NULL, PRIMARY KEY (`id`));"
+	noSort := false
+	dir := generateSQL(t, ddl, &GenConfig{Overrides: map[string]TableConfig{"articles": {Columns: map[string]ColumnConfig{
+		"title": {Filter: []string{"eq", "gt"}, Sort: &noSort},
+		"views": {Filter: []string{}},
+	}}}})
+
+	fn := funcDecls(t, dir, "internal/repository/articles.go")["ArticlesRepository.applyListQuery"]
+	if fn == nil {
+		t.Fatal("ArticlesRepository.applyListQuery not generated")
+	}
+	// 过滤的 switch 中每个 case 对应一列，compare 的第三个参数为允许的运算符；排序的 switch 只有一个 case 列出可排序列
+	filters := make(map[string][]string)
+	var sortable []string
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		sw, ok := n.(*ast.SwitchStmt)
+		if !ok {
+			return true
+		}
+		for _, stmt := range sw.Body.List {
+			clause := stmt.(*ast.CaseClause)
+			for _, expr := range clause.List {
+				column, _ := strconv.Unquote(expr.(*ast.BasicLit).Value)
+				if types.ExprString(sw.Tag) == "s.Column" {
+					sortable = append(sortable, column)
+					continue
+				}
+				ast.Inspect(clause, func(n ast.Node) bool {
+					if lit, ok := n.(*ast.CompositeLit); ok {
+						for _, op := range lit.Elts {
+							filters[column] = append(filters[column], strings.ToLower(strings.TrimPrefix(types.ExprString(op), "Op")))
+						}
+					}
+					return true
+				})
+			}
+		}
+		return true
+	})
+
+	want := map[string][]string{
+		"id":         {"eq", "ne", "gt", "gte", "lt", "lte", "in"},
+		"title":      {"eq"},
+		"status":     {"eq", "ne", "in"},
+		"created_at": {"eq", "ne", "gt", "gte", "lt", "lte"},
+	}
+	if !reflect.DeepEqual(filters, want) {
+		t.Errorf("applyListQuery filters =\n%v\nwant\n%v", filters, want)
+	}
+	if want := []string{"id", "status", "views", "created_at"}; !reflect.DeepEqual(sortable, want) {
+		t.Errorf("applyListQuery sortable = %v, want %v", sortable, want)
+	}
+	vetGenerated(t, dir)
+}
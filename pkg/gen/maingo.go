@@ -32,7 +32,7 @@ func (g *DatabaseGenerator) GenerateMainGo() error {
 	// 准备模型名称列表
 	var modelNames []string
 	for _, table := range tables {
-		modelNames = append(modelNames, toModelName(table))
+		modelNames = append(modelNames, g.options(table).ModelName)
 	}
 
 	// 渲染模板
@@ -43,7 +43,7 @@ func (g *DatabaseGenerator) GenerateMainGo() error {
 	fmt.Println("     ✓ cmd/server/main.go 创建成功")
 
 	// 生成 application 包
-	if err := g.GenerateApplicationPackage(modulePath, modelNames); err != nil {
+	if err := g.GenerateApplicationPackage(modulePath); err != nil {
 		return err
 	}
 
@@ -52,8 +52,12 @@ func (g *DatabaseGenerator) GenerateMainGo() error {
 		return err
 	}
 
-	// 🔥 新增：生成完整项目文件
-	if err := g.generateProjectFiles(modulePath, modelNames); err != nil {
+	// 🔥 新增：生成完整项目文件（只列出生成了路由的模型）
+	var routeModels []string
+	for _, table := range g.tablesWithLayer(LayerRoutes) {
+		routeModels = append(routeModels, g.options(table).ModelName)
+	}
+	if err := g.generateProjectFiles(modulePath, routeModels); err != nil {
 		return err
 	}
 
@@ -336,7 +340,19 @@ make run
 
 ` + apiEndpoints + `
 
-## 开发
+## 列表查询
+
+列表接口支持过滤、排序和字段选择，可用的列在生成时确定（gen.yaml 中列的 ` + "`filter`/`sort`" + ` 可调整），其它列返回 400：
+
+` + "```bash" + `
+GET /api/v1/articless?status=published&created_at[gte]=2024-01-01&sort=-created_at,id&fields=id,title
+` + "```" + `
+
+- 过滤：` + "`column=value`" + ` 等于，` + "`column[op]=value`" + ` 使用运算符：eq、ne、gt、gte、lt、lte、like（包含）、in（逗号分隔）
+- 排序：` + "`sort=-created_at,id`" + `，` + "`-`" + ` 前缀为倒序
+- 字段：` + "`fields=id,title`" + ` 只返回这些字段（始终包含主键）
+
+` + g.listQueryDocs() + `## 开发
 
 ` + "```bash" + `
 make test     # 运行测试
@@ -371,13 +387,53 @@ func (g *DatabaseGenerator) generateAPIEndpoints(modelNames []string) string {
 		endpoints += fmt.Sprintf("- 获取详情: `GET /api/v1/%s/:id`\n", lowerName)
 		endpoints += fmt.Sprintf("- 创建: `POST /api/v1/%s`\n", lowerName)
 		endpoints += fmt.Sprintf("- 更新: `PUT /api/v1/%s/:id`\n", lowerName)
+		endpoints += fmt.Sprintf("- 部分更新: `PATCH /api/v1/%s/:id`（可选 `?update_mask=a,b`）\n", lowerName)
 		endpoints += fmt.Sprintf("- 删除: `DELETE /api/v1/%s/:id`\n", lowerName)
+		endpoints += fmt.Sprintf("- 批量创建/更新/删除: `POST|PATCH|DELETE /api/v1/%s/batch`\n", lowerName)
 		endpoints += "\n"
 	}
 
 	return endpoints
 }
 
+// listQueryDocs 生成各模型列表接口可过滤、可排序的列说明
+func (g *DatabaseGenerator) listQueryDocs() string {
+	var b strings.Builder
+	for _, table := range g.tablesWithLayer(LayerRoutes) {
+		schema, err := g.tableSchema(table)
+		if err != nil {
+			continue
+		}
+
+		var filters, sorts []string
+		for _, c := range buildListColumns(schema, g.options(table)) {
+			if c.Filterable() {
+				filters = append(filters, fmt.Sprintf("`%s`(%s)", c.Column, strings.Join(c.Ops, ",")))
+			}
+			if c.Sortable {
+				sorts = append(sorts, "`"+c.Column+"`")
+			}
+		}
+
+		fmt.Fprintf(&b, "### %s\n", g.options(table).ModelName)
+		fmt.Fprintf(&b, "- 可过滤: %s\n", orNone(filters))
+		if cursor := cursorColumn(schema, g.options(table)); cursor != nil {
+			fmt.Fprintf(&b, "- 分页: 按 `%s` 游标分页（`?cursor=<next_cursor>&page_size=20`，不返回总数），只能按 `%s` 排序\n\n", cursor.Column, cursor.Column)
+			continue
+		}
+		fmt.Fprintf(&b, "- 可排序: %s\n\n", orNone(sorts))
+	}
+	return b.String()
+}
+
+// orNone 逗号连接，列表为空时返回“无”
+func orNone(items []string) string {
+	if len(items) == 0 {
+		return "无"
+	}
+	return strings.Join(items, ", ")
+}
+
 // generateMakefile 生成 Makefile
 func (g *DatabaseGenerator) generateMakefile() error {
 	outputPath := filepath.Join(g.config.Output, "Makefile")
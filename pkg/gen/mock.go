@@ -0,0 +1,257 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// mockMethod 仓储接口中的一个方法，用于生成 Mock
+type mockMethod struct {
+	Name    string // 方法名
+	Params  string // 形参列表，如 ctx context.Context, preloads ...string
+	Args    string // 转发给 Func 字段的实参，如 ctx, preloads...
+	Results string // 具名返回值，如 (r0 *model.User, err error)
+	Types   string // Func 字段的返回类型，如 (*model.User, error)
+	Error   bool   // 最后一个返回值是否为 error
+}
+
+// GenerateMock 生成仓储接口的 Mock（internal/repository/mock），方法列表从渲染后的接口解析，
+// 保证 Mock 与接口（索引查询、外键查询、软删除等方法）始终一致
+func (g *DatabaseGenerator) GenerateMock(config RepositoryConfig) error {
+	block, err := renderRepoInterface(config)
+	if err != nil {
+		return err
+	}
+	methods, err := repoMockMethods(block)
+	if err != nil {
+		return fmt.Errorf("解析 %sRepo 接口失败: %w", config.ModelName, err)
+	}
+
+	outputDir := filepath.Join(g.config.Output, "internal/repository/mock")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "mock.go"), []byte(mockCommonTmpl), 0644); err != nil {
+		return fmt.Errorf("创建 Mock 公共定义文件失败: %w", err)
+	}
+
+	t, err := template.New("mock").Parse(mockTmpl)
+	if err != nil {
+		return fmt.Errorf("解析 Mock 模板失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	data := map[string]interface{}{
+		"ModelName":  config.ModelName,
+		"ModulePath": config.ModulePath,
+		"Imports":    config.Imports,
+		"Methods":    methods,
+	}
+	if err := t.Execute(&buf, data); err != nil {
+		return fmt.Errorf("执行 Mock 模板失败: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("格式化 %sRepo Mock 失败: %w", config.ModelName, err)
+	}
+	outputPath := filepath.Join(outputDir, strings.ToLower(config.ModelName)+".go")
+	if err := os.WriteFile(outputPath, src, 0644); err != nil {
+		return fmt.Errorf("写入 Mock 文件失败: %w", err)
+	}
+	return nil
+}
+
+// repoMockMethods 解析仓储接口定义，得到 Mock 需要实现的方法；
+// repository 包内的类型（如 ListQuery）改为带包名的形式
+func repoMockMethods(block string) ([]mockMethod, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", "package repository\n\n"+block, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var iface *ast.InterfaceType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if t, ok := n.(*ast.InterfaceType); ok && iface == nil {
+			iface = t
+		}
+		return iface == nil
+	})
+	if iface == nil {
+		return nil, fmt.Errorf("没有找到接口定义")
+	}
+
+	var methods []mockMethod
+	for _, m := range iface.Methods.List {
+		fn, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			continue
+		}
+
+		method := mockMethod{Name: m.Names[0].Name}
+		var params, args []string
+		for i, p := range fn.Params.List {
+			typ := exprString(fset, qualifyRepoTypes(p.Type))
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", i))}
+			}
+			var group []string
+			for _, name := range names {
+				group = append(group, name.Name)
+				if _, variadic := p.Type.(*ast.Ellipsis); variadic {
+					args = append(args, name.Name+"...")
+				} else {
+					args = append(args, name.Name)
+				}
+			}
+			params = append(params, strings.Join(group, ", ")+" "+typ)
+		}
+		method.Params = strings.Join(params, ", ")
+		method.Args = strings.Join(args, ", ")
+
+		if fn.Results != nil {
+			var named, types []string
+			for i, r := range fn.Results.List {
+				typ := exprString(fset, qualifyRepoTypes(r.Type))
+				name := fmt.Sprintf("r%d", i)
+				if i == len(fn.Results.List)-1 && typ == "error" {
+					name = "err"
+					method.Error = true
+				}
+				named = append(named, name+" "+typ)
+				types = append(types, typ)
+			}
+			method.Results = "(" + strings.Join(named, ", ") + ")"
+			method.Types = "(" + strings.Join(types, ", ") + ")"
+		}
+		methods = append(methods, method)
+	}
+	return methods, nil
+}
+
+// qualifyRepoTypes 给 repository 包内定义的导出类型加上包名，如 ListQuery -> repository.ListQuery
+func qualifyRepoTypes(expr ast.Expr) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(t.Name[0])) {
+			return &ast.SelectorExpr{X: ast.NewIdent("repository"), Sel: ast.NewIdent(t.Name)}
+		}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyRepoTypes(t.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: qualifyRepoTypes(t.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualifyRepoTypes(t.Key), Value: qualifyRepoTypes(t.Value)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualifyRepoTypes(t.Elt)}
+	}
+	return expr
+}
+
+// exprString 类型表达式的源码形式
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+// mockCommonTmpl Mock 的公共定义，所有 Mock 共用（生成到 internal/repository/mock/mock.go）
+const mockCommonTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Mock: 公共定义
+
+// Package mock 提供仓储接口的 Mock 实现，用于 Service 与 Controller 的单元测试
+//
+// 用法：为需要的方法设置 XxxFunc 字段，未设置的方法返回 ErrNotMocked；Calls 返回方法的调用次数
+//
+//	repo := &mock.UserRepo{
+//		GetByIDFunc: func(ctx context.Context, id uint64, preloads ...string) (*model.User, error) {
+//			return nil, gorm.ErrRecordNotFound
+//		},
+//	}
+package mock
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotMocked 调用了未设置 Func 字段的 Mock 方法
+var ErrNotMocked = errors.New("mock: 方法未设置")
+
+// calls 记录 Mock 方法的调用次数，并发安全
+type calls struct {
+	mu sync.Mutex
+	n  map[string]int
+}
+
+// add 记录一次调用
+func (c *calls) add(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.n == nil {
+		c.n = make(map[string]int)
+	}
+	c.n[method]++
+}
+
+// Calls 返回方法被调用的次数
+func (c *calls) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n[method]
+}
+`
+
+// mockTmpl 单个仓储接口的 Mock（生成到 internal/repository/mock/<model>.go）
+const mockTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Mock: {{.ModelName}}Repo
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
+
+	"{{.ModulePath}}/internal/dal/model"
+	"{{.ModulePath}}/internal/repository"
+)
+
+// {{.ModelName}}Repo repository.{{.ModelName}}Repo 的 Mock 实现
+type {{.ModelName}}Repo struct {
+{{- range .Methods}}
+	{{.Name}}Func func({{.Params}}) {{.Types}}
+{{- end}}
+
+	calls
+}
+
+// 确保 {{.ModelName}}Repo 实现了 repository.{{.ModelName}}Repo 接口
+var _ repository.{{.ModelName}}Repo = (*{{.ModelName}}Repo)(nil)
+{{range .Methods}}
+// {{.Name}} 调用 {{.Name}}Func，未设置时返回 ErrNotMocked
+func (m *{{$.ModelName}}Repo) {{.Name}}({{.Params}}) {{.Results}} {
+	m.add("{{.Name}}")
+	if m.{{.Name}}Func == nil {
+		{{- if .Error}}
+		err = fmt.Errorf("%w: {{$.ModelName}}Repo.{{.Name}}", ErrNotMocked)
+		{{- end}}
+		return
+	}
+	{{if .Results}}return {{end}}m.{{.Name}}Func({{.Args}})
+}
+{{end}}`
@@ -0,0 +1,47 @@
+package gen
+
+import "testing"
+
+// TestRepoMockMethods 验证 Mock 的方法签名从仓储接口解析：repository 包内的类型加包名，可变参数原样转发
+func TestRepoMockMethods(t *testing.T) {
+	block := `type UserRepo interface {
+	// GetByID 根据主键获取 User
+	GetByID(ctx context.Context, id uint64, preloads ...string) (*model.User, error)
+
+	List(ctx context.Context, q ListQuery, page, pageSize int) ([]*model.User, int64, error)
+
+	Count(ctx context.Context) (int64, error)
+}`
+
+	methods, err := repoMockMethods(block)
+	if err != nil {
+		t.Fatalf("repoMockMethods() unexpected error: %v", err)
+	}
+	if len(methods) != 3 {
+		t.Fatalf("repoMockMethods() returned %d methods, want 3", len(methods))
+	}
+
+	tests := []mockMethod{
+		{
+			Name:    "GetByID",
+			Params:  "ctx context.Context, id uint64, preloads ...string",
+			Args:    "ctx, id, preloads...",
+			Results: "(r0 *model.User, err error)",
+			Types:   "(*model.User, error)",
+			Error:   true,
+		},
+		{
+			Name:    "List",
+			Params:  "ctx context.Context, q repository.ListQuery, page, pageSize int",
+			Args:    "ctx, q, page, pageSize",
+			Results: "(r0 []*model.User, r1 int64, err error)",
+			Types:   "([]*model.User, int64, error)",
+			Error:   true,
+		},
+	}
+	for i, want := range tests {
+		if methods[i] != want {
+			t.Errorf("repoMockMethods()[%d] = %+v, want %+v", i, methods[i], want)
+		}
+	}
+}
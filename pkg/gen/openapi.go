@@ -0,0 +1,375 @@
+package gen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// openAPI 是否生成 OpenAPI 文档与 Swagger UI（--openapi 或 gen.yaml 中的 openapi: true）
+func (g *DatabaseGenerator) openAPI() bool {
+	return g.config.OpenAPI || g.config.Options != nil && g.config.Options.OpenAPI
+}
+
+// GenerateOpenAPI 生成 docs 包：openapi.yaml、openapi.json 与 Swagger UI 路由
+func (g *DatabaseGenerator) GenerateOpenAPI() error {
+	doc, err := g.buildOpenAPI()
+	if err != nil {
+		return err
+	}
+	if err := openapi.WriteDocs(filepath.Join(g.config.Output, "docs"), doc); err != nil {
+		return err
+	}
+
+	fmt.Println("     ✓ docs/openapi.yaml、docs/openapi.json 与 Swagger UI 创建成功")
+	return nil
+}
+
+// buildOpenAPI 按生成的路由构建 OpenAPI 文档，所有模型都生成到 components.schemas
+func (g *DatabaseGenerator) buildOpenAPI() (*openapi.Document, error) {
+	modulePath := getModulePath(g.config.Module)
+	doc := openapi.New(path.Base(modulePath)+" API", "1.0.0", "由 go-start 根据数据库表结构生成")
+	doc.Servers = []openapi.Server{{URL: "/api/v1"}}
+
+	for _, tableName := range g.config.Tables {
+		schema, err := g.tableSchema(tableName)
+		if err != nil {
+			return nil, fmt.Errorf("读取表 %s 结构失败: %w", tableName, err)
+		}
+		opts := g.options(tableName)
+		doc.Components.Schemas[opts.ModelName] = modelSchema(schema, opts, g.relationsOf(tableName).Relations)
+		doc.AddTag(opts.ModelName, schema.Comment)
+	}
+
+	for _, tableName := range g.tablesWithLayer(LayerRoutes) {
+		if err := g.addResourceOperations(doc, tableName); err != nil {
+			return nil, err
+		}
+	}
+
+	// 子资源路由注册在父表的路由组下，处理方法属于子表的 Controller
+	for _, tableName := range g.tablesWithLayer(LayerController) {
+		modelName := g.options(tableName).ModelName
+		for _, finder := range g.relationsOf(tableName).Finders {
+			if finder.Route == "" {
+				continue
+			}
+			op := &openapi.Operation{
+				Tags:        []string{modelName},
+				Summary:     fmt.Sprintf("按 %s 获取%s列表", finder.Column, modelName),
+				OperationID: "List" + modelName + "By" + finder.FieldName,
+				Parameters: []*openapi.Parameter{
+					{Name: "id", In: "path", Required: true, Description: finder.Column, Schema: openapi.SchemaFor(finder.GoType)},
+				},
+			}
+			g.preloadParam(op, tableName)
+			op.Success("成功", offsetPage(op, openapi.Ref(modelName)))
+			op.Error(400, "无效的 ID 或预加载关联")
+			if err := doc.AddOperation("GET", finder.Route, op); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// modelSchema 模型的结构：列的 JSON 名与类型与 GORM Gen 生成的模型一致，关联字段只在预加载时返回
+//
+// 非空、没有默认值且不自增的列标记为必填；自增主键、自动时间戳、乐观锁版本与软删除列为只读。
+func modelSchema(table *DetailedTableInfo, opts tableOptions, relations []RelationInfo) *openapi.Schema {
+	fields := make(map[string]FieldInfo, len(table.Fields))
+	for _, f := range table.Fields {
+		fields[f.Name] = f
+	}
+
+	version := versionColumn(table, opts)
+	s := openapi.Object(map[string]*openapi.Schema{})
+	s.Description = table.Comment
+
+	for _, c := range buildListColumns(table, opts) {
+		f := fields[c.Column]
+
+		var prop *openapi.Schema
+		if f.Enum != nil && opts.Columns[c.Column].Type == "" {
+			prop = openapi.EnumSchema(*f.Enum)
+		} else {
+			prop = openapi.SchemaFor(c.GoType)
+		}
+		if c.Pointer {
+			prop.Nullable()
+		}
+		prop.Describe(f.Comment)
+
+		prop.ReadOnly = f.AutoIncrement || slices.Contains(autoTimestampColumns, c.Column) || version != nil && version.Column == c.Column
+		if !f.Nullable && f.DefaultValue == "" && !prop.ReadOnly {
+			s.Required = append(s.Required, c.JSON)
+		}
+		s.AddProperty(c.JSON, prop)
+	}
+
+	if hasSoftDelete(table, opts) {
+		deletedAt := &openapi.Schema{Type: openapi.Types{"string", "null"}, Format: "date-time", ReadOnly: true}
+		s.AddProperty("deleted_at", deletedAt.Describe("删除时间，未删除时为 null"))
+	}
+
+	for _, rel := range relations {
+		prop := openapi.Ref(rel.ModelName)
+		if rel.Kind != RelationBelongsTo {
+			prop = openapi.ArrayOf(prop)
+		}
+		prop.Description = "关联的 " + rel.ModelName + "，仅在 preload=" + rel.FieldName + " 时返回"
+		s.AddProperty(rel.JSONName(), prop)
+	}
+
+	return s
+}
+
+// addResourceOperations 添加一张表的 CRUD、批量、回收站接口（与 auto_routes.go 中注册的路由一致）
+func (g *DatabaseGenerator) addResourceOperations(doc *openapi.Document, tableName string) error {
+	schema, err := g.tableSchema(tableName)
+	if err != nil {
+		return fmt.Errorf("读取表 %s 结构失败: %w", tableName, err)
+	}
+	opts := g.options(tableName)
+	name := opts.ModelName
+	base := "/" + toLowerCamelCase(name) + "s"
+	key := g.primaryKey(tableName)
+	item := base + key.Route()
+	version := g.version(tableName)
+	model := openapi.Ref(name)
+
+	// 主键对象，如 {"id": 1}，用于创建结果与批量删除的请求体
+	keyObject := openapi.Object(map[string]*openapi.Schema{})
+	for _, f := range key.Fields {
+		keyObject.Properties[f.Param] = openapi.SchemaFor(f.GoType)
+		keyObject.Required = append(keyObject.Required, f.Param)
+	}
+
+	newOp := func(summary, operationID string) *openapi.Operation {
+		return &openapi.Operation{Tags: []string{name}, Summary: summary, OperationID: operationID + name}
+	}
+	withKey := func(op *openapi.Operation) *openapi.Operation {
+		for _, f := range key.Fields {
+			op.Parameters = append(op.Parameters, &openapi.Parameter{
+				Name: f.Param, In: "path", Required: true, Description: f.Column, Schema: openapi.SchemaFor(f.GoType),
+			})
+		}
+		op.Error(400, "无效的主键或参数错误")
+		op.Error(404, "记录不存在")
+		return op
+	}
+	withETag := func(resp *openapi.Response) {
+		if version != nil {
+			resp.Headers = map[string]*openapi.Header{
+				"ETag": {Description: "当前版本（" + version.Column + "），更新时通过 If-Match 传回", Schema: openapi.String()},
+			}
+		}
+	}
+	withIfMatch := func(op *openapi.Operation) {
+		if version == nil {
+			return
+		}
+		op.Parameters = append(op.Parameters, &openapi.Parameter{
+			Name: "If-Match", In: "header", Description: "GET 返回的 ETag，版本不一致时返回 412", Schema: openapi.String(),
+		})
+		op.Error(412, "If-Match 与当前版本不一致")
+	}
+
+	var addErr error
+	add := func(method, route string, op *openapi.Operation) {
+		op.Error(500, "服务器内部错误")
+		if err := doc.AddOperation(method, route, op); err != nil && addErr == nil {
+			addErr = err
+		}
+	}
+
+	// 创建
+	create := newOp("创建"+name, "Create")
+	create.JSONBody(name, model)
+	create.Success("创建成功，返回主键", keyObject)
+	create.Error(400, "参数错误")
+	create.Error(409, "违反唯一约束")
+	add("POST", base, create)
+
+	// 列表
+	list := newOp("获取"+name+"列表", "List")
+	g.listParams(list, schema, opts)
+	g.preloadParam(list, tableName)
+	if cursor := g.cursor(tableName); cursor != nil {
+		list.Description = "按 " + cursor.Column + " 游标分页，不返回总数"
+		list.Success("成功", cursorPage(list, model))
+	} else {
+		list.Success("成功", offsetPage(list, model))
+	}
+	list.Error(400, "无效的查询参数")
+	add("GET", base, list)
+
+	// 详情
+	get := withKey(newOp("获取"+name+"详情", "Get"))
+	g.preloadParam(get, tableName)
+	withETag(get.Success("成功", model))
+	add("GET", item, get)
+
+	// 全量更新
+	update := withKey(newOp("更新"+name, "Update"))
+	update.JSONBody(name, model)
+	withIfMatch(update)
+	withETag(update.Success("更新成功", nil))
+	update.Error(409, "违反唯一约束或版本冲突")
+	add("PUT", item, update)
+
+	// 部分更新
+	patch := withKey(newOp("部分更新"+name, "Patch"))
+	patch.Description = "只更新请求体中出现的字段；update_mask 指定时只更新其中列出的字段"
+	patch.Query("update_mask", "要更新的字段，逗号分隔", openapi.String())
+	patchBody := openapi.Object(map[string]*openapi.Schema{})
+	for _, c := range buildPatchColumns(schema, opts) {
+		patchBody.Properties[c.JSON] = &openapi.Schema{Ref: "#/components/schemas/" + name + "/properties/" + c.JSON}
+	}
+	patch.JSONBody("要修改的字段", patchBody)
+	withIfMatch(patch)
+	withETag(patch.Success("更新成功，返回更新后的记录", model))
+	patch.Error(409, "违反唯一约束或版本冲突")
+	add("PATCH", item, patch)
+
+	// 删除
+	del := withKey(newOp("删除"+name, "Delete"))
+	if g.softDelete(tableName) {
+		del.Description = "软删除（可通过 restore 恢复）；hard=true 时永久删除，需要 " + tableName + ":hard_delete 权限"
+		del.Query("hard", "是否永久删除", openapi.Boolean())
+		del.Error(403, "没有永久删除权限")
+	}
+	del.Success("删除成功", nil)
+	add("DELETE", item, del)
+
+	// 批量操作：一个事务内完成，任一条失败时全部不写入
+	batchErr := func(op *openapi.Operation) {
+		op.Error(400, "请求体或记录数不合法")
+		resp := op.Error(422, "批量操作失败，data.errors 列出每条出错记录")
+		resp.Content["application/json"] = openapi.MediaType{Schema: openapi.Envelope(openapi.Object(map[string]*openapi.Schema{
+			"errors": openapi.ArrayOf(openapi.Object(map[string]*openapi.Schema{
+				"index":  openapi.Integer(),
+				"status": openapi.Integer(),
+				"error":  openapi.String(),
+			})),
+		}))}
+	}
+	count := map[string]*openapi.Schema{"count": openapi.Integer()}
+
+	createBatch := newOp("批量创建"+name, "CreateBatch")
+	createBatch.JSONBody(name+" 列表", openapi.ArrayOf(model))
+	createBatch.Success("创建成功，按请求顺序返回主键", openapi.Object(map[string]*openapi.Schema{
+		"count": openapi.Integer(),
+		"items": openapi.ArrayOf(keyObject),
+	}))
+	batchErr(createBatch)
+	add("POST", base+"/batch", createBatch)
+
+	updateBatch := newOp("批量更新"+name, "UpdateBatch")
+	updateBatch.JSONBody("带主键的 "+name+" 列表", openapi.ArrayOf(model))
+	updateBatch.Success("更新成功", openapi.Object(count))
+	batchErr(updateBatch)
+	add("PATCH", base+"/batch", updateBatch)
+
+	deleteBatch := newOp("批量删除"+name, "DeleteBatch")
+	deleteBatch.JSONBody("只含主键的对象列表", openapi.ArrayOf(keyObject))
+	deleteBatch.Success("删除成功", openapi.Object(count))
+	batchErr(deleteBatch)
+	add("DELETE", base+"/batch", deleteBatch)
+
+	// 回收站
+	if g.softDelete(tableName) {
+		trash := newOp("获取"+name+"回收站", "ListTrash")
+		trash.Description = "分页获取已软删除的记录，按删除时间倒序"
+		trash.Success("成功", offsetPage(trash, model))
+		add("GET", base+"/trash", trash)
+
+		restore := withKey(newOp("恢复已删除的"+name, "Restore"))
+		restore.Success("恢复成功", nil)
+		add("POST", item+"/restore", restore)
+	}
+
+	return addErr
+}
+
+// listParams List 接口的过滤、排序与字段选择参数（按生成时的白名单）
+//
+// 过滤参数写作 column=value（等于）或 column[op]=value，in 的取值用逗号分隔。
+func (g *DatabaseGenerator) listParams(op *openapi.Operation, table *DetailedTableInfo, opts tableOptions) {
+	var sorts, fields []string
+	for _, c := range buildListColumns(table, opts) {
+		fields = append(fields, c.JSON)
+		if c.Sortable {
+			sorts = append(sorts, c.Column)
+		}
+
+		for _, filter := range c.Ops {
+			var schema *openapi.Schema
+			switch {
+			case filter == FilterIn || filter == FilterLike:
+				schema = openapi.String()
+			case c.Kind == "enum" && c.Numeric:
+				schema = openapi.Integer()
+			case c.Kind == "enum":
+				schema = openapi.String()
+			default:
+				schema = openapi.SchemaFor(c.GoType)
+			}
+
+			name, description := c.Column, c.Column+" 等于"
+			if filter != FilterEq {
+				name, description = c.Column+"["+filter+"]", c.Column+" "+filter
+			}
+			if filter == FilterIn {
+				description += "（逗号分隔）"
+			}
+			op.Query(name, description, schema)
+		}
+	}
+
+	if g.cursor(table.Name) == nil && len(sorts) > 0 {
+		op.Query("sort", "排序，逗号分隔，- 前缀表示倒序，可选: "+strings.Join(sorts, ", "), openapi.String())
+	}
+	op.Query("fields", "返回的字段，逗号分隔，可选: "+strings.Join(fields, ", "), openapi.String())
+}
+
+// preloadParam 添加 preload 查询参数（可重复传入），没有关联时不添加
+func (g *DatabaseGenerator) preloadParam(op *openapi.Operation, tableName string) {
+	var names []interface{}
+	for _, rel := range g.relationsOf(tableName).Relations {
+		names = append(names, rel.FieldName)
+	}
+	if len(names) == 0 {
+		return
+	}
+	op.Query("preload", "预加载的关联，可重复传入", openapi.ArrayOf(&openapi.Schema{Type: openapi.Types{"string"}, Enum: names}))
+}
+
+// offsetPage 添加 page/page_size 参数，返回偏移分页响应的 data 结构
+func offsetPage(op *openapi.Operation, items *openapi.Schema) *openapi.Schema {
+	op.Query("page", "页码", &openapi.Schema{Type: openapi.Types{"integer"}, Minimum: openapi.Float(1), Default: 1})
+	op.Query("page_size", "每页数量", &openapi.Schema{Type: openapi.Types{"integer"}, Minimum: openapi.Float(1), Default: 20})
+	return openapi.Object(map[string]*openapi.Schema{
+		"list":      openapi.ArrayOf(items),
+		"total":     &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64"},
+		"page":      openapi.Integer(),
+		"page_size": openapi.Integer(),
+	}, "list", "total", "page", "page_size")
+}
+
+// cursorPage 添加 cursor/page_size 参数，返回游标分页响应的 data 结构
+func cursorPage(op *openapi.Operation, items *openapi.Schema) *openapi.Schema {
+	op.Query("cursor", "上一页返回的 next_cursor，为空时从第一页开始", openapi.String())
+	op.Query("page_size", "每页数量", &openapi.Schema{Type: openapi.Types{"integer"}, Minimum: openapi.Float(1), Default: 20})
+	return openapi.Object(map[string]*openapi.Schema{
+		"list":        openapi.ArrayOf(items),
+		"next_cursor": openapi.String(),
+		"has_more":    openapi.Boolean(),
+		"page_size":   openapi.Integer(),
+	}, "list", "next_cursor", "has_more", "page_size")
+}
@@ -0,0 +1,63 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestVersionColumn 验证乐观锁只用于整数版本列，列名可在 gen.yaml 中指定或关闭，且版本列不可通过 PATCH 修改
+func TestVersionColumn(t *testing.T) {
+	ddl := "CREATE TABLE `orders` (`id` bigint NOT NULL, `version` int unsigned NOT NULL, `revision` bigint NOT NULL, PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `docs` (`id` bigint NOT NULL, `version` varchar(16) NOT NULL, PRIMARY KEY (`id`));"
+
+	schema := parseSchema(t, ddl, nil)
+
+	tests := []struct {
+		table string
+		cfg   *GenConfig
+		want  string // 版本列，为空表示不启用乐观锁
+	}{
+		{"orders", nil, "version"},
+		{"orders", &GenConfig{Overrides: map[string]TableConfig{"orders": {VersionColumn: "revision"}}}, "revision"},
+		{"orders", &GenConfig{VersionColumn: "-"}, ""},
+		{"docs", nil, ""}, // 字符串列不能作为版本号
+	}
+	for _, tt := range tests {
+		var got string
+		if col := versionColumn(schema.Table(tt.table), tt.cfg.table(tt.table)); col != nil {
+			got = col.Column
+		}
+		if got != tt.want {
+			t.Errorf("versionColumn(%s) = %q, want %q", tt.table, got, tt.want)
+		}
+	}
+
+	for _, c := range buildPatchColumns(schema.Table("orders"), (*GenConfig)(nil).table("orders")) {
+		if c.Column == "version" {
+			t.Error("buildPatchColumns() should exclude the version column")
+		}
+	}
+}
+
+// TestVersionGenerated 验证有版本列的表生成按版本更新、ETag 解析与 If-Match 检查，没有版本列的表不生成，且生成的代码能编译
+func TestVersionGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `orders` (`id` bigint NOT NULL, `version` int unsigned NOT NULL, PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `docs` (`id` bigint NOT NULL, `version` varchar(16) NOT NULL, PRIMARY KEY (`id`));"
+	dir := generateSQL(t, ddl, nil)
+
+	decls := funcDecls(t, dir, "internal/repository/orders.go", "internal/controller/orders.go",
+		"internal/repository/docs.go", "internal/controller/docs.go")
+	got := signatures(decls, "OrdersRepository.updateVersioned", "ParseOrdersETag", "OrdersController.ifMatch",
+		"DocsRepository.updateVersioned", "DocsController.ifMatch")
+	want := map[string]string{
+		"OrdersRepository.updateVersioned": "func(do query.IOrdersDo, orders *model.Orders) error",
+		"ParseOrdersETag":                  "func(etag string) (uint32, error)",
+		"OrdersController.ifMatch":         "func(ctx *gin.Context) (version uint32, ok bool)",
+		"DocsRepository.updateVersioned":   "",
+		"DocsController.ifMatch":           "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("signatures =\n%v\nwant\n%v", got, want)
+	}
+	vetGenerated(t, dir)
+}
@@ -0,0 +1,94 @@
+package gen
+
+import "slices"
+
+// autoTimestampColumns 由 GORM 自动维护的时间列，不允许通过 PATCH 修改
+var autoTimestampColumns = []string{"created_at", "updated_at"}
+
+// buildPatchColumns 推导 PATCH 接口可更新的列：模型中的列去掉主键、自动时间戳、软删除列、
+// 乐观锁版本列与 gen.yaml 中标记为 readonly 的列
+func buildPatchColumns(table *DetailedTableInfo, opts tableOptions) []ListColumn {
+	readOnly := make(map[string]bool)
+	for _, f := range buildPrimaryKey(table, opts).Fields {
+		readOnly[f.Column] = true
+	}
+	if version := versionColumn(table, opts); version != nil {
+		readOnly[version.Column] = true
+	}
+
+	var columns []ListColumn
+	for _, c := range buildListColumns(table, opts) {
+		if readOnly[c.Column] || slices.Contains(autoTimestampColumns, c.Column) || opts.Columns[c.Column].ReadOnly {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// patchTmpl 部分更新的公共定义，所有仓储共用（生成到 internal/repository/patch.go）
+const patchTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Repository: 部分更新（PATCH）
+
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ErrInvalidPatch 部分更新的字段不存在、为只读字段（主键、created_at 等）或取值无法解析
+var ErrInvalidPatch = errors.New("无效的更新字段")
+
+// PatchFields 部分更新要写入的字段（JSON 字段名）
+//
+// mask 为 update_mask 参数（逗号分隔）：指定时只更新 mask 中的字段，且这些字段必须出现在请求体中；
+// 未指定时更新请求体中出现的全部字段。
+func PatchFields(body map[string]json.RawMessage, mask string) ([]string, error) {
+	var fields []string
+	if mask == "" {
+		for name := range body {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+	} else {
+		fields = splitList([]string{mask})
+		for _, name := range fields {
+			if _, ok := body[name]; !ok {
+				return nil, fmt.Errorf("%w: update_mask 中的 %s 不在请求体中", ErrInvalidPatch, name)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: 没有要更新的字段", ErrInvalidPatch)
+	}
+	return fields, nil
+}
+
+// MergePatch 把请求体中 fields 对应的值合并到 dst（通常是数据库中的现有记录），其余字段保持不变
+//
+// 合并后的记录与 Create/Update 一样按 binding 标签校验（如枚举的 oneof），不合法时返回 ErrInvalidPatch
+func MergePatch(dst interface{}, body map[string]json.RawMessage, fields []string) error {
+	patch := make(map[string]json.RawMessage, len(fields))
+	for _, name := range fields {
+		patch[name] = body[name]
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if err := binding.Validator.ValidateStruct(dst); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
+`
@@ -0,0 +1,94 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/internal/buildtest"
+)
+
+// TestBuildPatchColumns 验证 PATCH 可更新列排除主键、自动时间戳、软删除列与 readonly 列，并使用覆盖后的 JSON 名
+func TestBuildPatchColumns(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` bigint unsigned NOT NULL, `email` varchar(100) NOT NULL, `nick_name` varchar(50),\n" +
+		"  `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `deleted_at` datetime NULL, PRIMARY KEY (`id`));"
+
+	table := parseSchema(t, ddl, nil).Table("users")
+
+	cfg := &GenConfig{Overrides: map[string]TableConfig{"users": {Columns: map[string]ColumnConfig{
+		"email":     {ReadOnly: true},
+		"nick_name": {JSON: "nickname"},
+	}}}}
+
+	got := make(map[string]string)
+	for _, c := range buildPatchColumns(table, cfg.table("users")) {
+		got[c.JSON] = c.Column
+	}
+	if want := map[string]string{"nickname": "nick_name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("buildPatchColumns() = %v, want %v", got, want)
+	}
+}
+
+// patchInvalidTest 在生成项目的 controller 包中运行的测试：不合法的 PATCH 返回 400 且不写入
+const patchInvalidTest = `package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/app/internal/dal/model"
+	"example.com/app/internal/repository/mock"
+	"example.com/app/internal/service"
+	"example.com/app/pkg/cache"
+	"github.com/gin-gonic/gin"
+)
+
+func TestUsersController_PatchValidation(t *testing.T) {
+	tests := []struct {
+		body       string
+		wantStatus int
+	}{
+		{` + "`" + `{"status": "banned"}` + "`" + `, http.StatusOK},
+		{` + "`" + `{"status": "deleted"}` + "`" + `, http.StatusBadRequest},
+		{` + "`" + `{"status": ""}` + "`" + `, http.StatusBadRequest},
+		{` + "`" + `{"level": "high"}` + "`" + `, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		patched := false
+		repo := &mock.UsersRepo{
+			GetByIDFunc: func(ctx context.Context, id uint64, preloads ...string) (*model.Users, error) {
+				return &model.Users{ID: id, Status: model.UsersStatusActive}, nil
+			},
+			PatchFunc: func(ctx context.Context, users *model.Users, columns []string) error {
+				patched = true
+				return nil
+			},
+		}
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.PATCH("/users/:id", NewUsersController(service.NewUsersService(repo, nil, cache.New())).Patch)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(tt.body)))
+		if w.Code != tt.wantStatus || patched != (tt.wantStatus == http.StatusOK) {
+			t.Errorf("PATCH %s status = %d (patched %v), want %d, body: %s", tt.body, w.Code, patched, tt.wantStatus, w.Body.String())
+		}
+	}
+}
+`
+
+// TestPatchValidation 验证生成的 PATCH 接口对合并后的记录做与 Create/Update 相同的校验
+func TestPatchValidation(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `status` enum('active','banned') NOT NULL,\n" +
+		"  `level` tinyint NOT NULL DEFAULT 1, PRIMARY KEY (`id`));"
+	dir := generateSQL(t, ddl, nil)
+
+	if err := os.WriteFile(filepath.Join(dir, "internal/controller/patch_validation_test.go"), []byte(patchInvalidTest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buildtest.Go(t, dir, testModule, "test", "-run", "PatchValidation", "./internal/controller/")
+}
@@ -0,0 +1,227 @@
+package gen
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// PostgreSQL 表结构读取
+//
+// 表名支持 schema.table 形式；未指定 schema 时按连接的 search_path 解析
+// （与 current_schema() 一致）。information_schema 无法给出主键、自增和
+// 注释信息，因此直接查询 pg_catalog。
+
+// pgTablesQuery 列出所有用户 schema 中的表，current_schema() 中的表不带 schema 前缀
+const pgTablesQuery = `SELECT
+		CASE WHEN n.nspname = current_schema() THEN c.relname ELSE n.nspname || '.' || c.relname END AS name,
+		obj_description(c.oid, 'pg_class') AS comment
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'p')
+		AND NOT c.relispartition
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND n.nspname NOT LIKE 'pg\_toast%'
+		AND n.nspname NOT LIKE 'pg\_temp%'
+	ORDER BY n.nspname <> current_schema(), n.nspname, c.relname`
+
+// pgFieldsQuery 读取列定义：类型、可空、默认值、identity、注释、是否属于主键及枚举类型的取值
+const pgFieldsQuery = `SELECT
+		a.attname,
+		format_type(a.atttypid, NULL) AS data_type,
+		format_type(a.atttypid, a.atttypmod) AS column_type,
+		NOT a.attnotnull AS nullable,
+		COALESCE(pg_get_expr(d.adbin, d.adrelid), '') AS column_default,
+		a.attidentity IN ('a', 'd') AS is_identity,
+		COALESCE(col_description(a.attrelid, a.attnum), '') AS comment,
+		EXISTS (
+			SELECT 1 FROM pg_index i
+			WHERE i.indrelid = a.attrelid AND i.indisprimary AND a.attnum = ANY (i.indkey)
+		) AS is_primary,
+		COALESCE((
+			SELECT string_agg(quote_literal(e.enumlabel), ',' ORDER BY e.enumsortorder)
+			FROM pg_enum e WHERE e.enumtypid = a.atttypid
+		), '') AS enum_values
+	FROM pg_attribute a
+	LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+	WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+	ORDER BY a.attnum`
+
+// pgIndexesQuery 读取索引及其键列（按索引中的顺序，不含 INCLUDE 列），跳过表达式索引
+const pgIndexesQuery = `SELECT
+		ic.relname AS index_name,
+		array_to_string(ARRAY(
+			SELECT a.attname
+			FROM unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+			JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+			WHERE k.ord <= i.indnkeyatts
+			ORDER BY k.ord
+		), ',') AS columns,
+		i.indisunique AND i.indpred IS NULL AS is_unique,
+		i.indisprimary
+	FROM pg_index i
+	JOIN pg_class ic ON ic.oid = i.indexrelid
+	WHERE i.indrelid = $1::regclass AND i.indexprs IS NULL
+	ORDER BY i.indisprimary DESC, ic.relname`
+
+// pgForeignKeysQuery 读取外键约束及其列对应关系，引用表不在 current_schema() 时带 schema 前缀
+const pgForeignKeysQuery = `SELECT
+		con.conname,
+		a.attname,
+		CASE WHEN rn.nspname = current_schema() THEN rc.relname ELSE rn.nspname || '.' || rc.relname END AS ref_table,
+		ra.attname AS ref_column
+	FROM pg_constraint con
+	CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, ref_attnum, ord)
+	JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+	JOIN pg_class rc ON rc.oid = con.confrelid
+	JOIN pg_namespace rn ON rn.oid = rc.relnamespace
+	JOIN pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.ref_attnum
+	WHERE con.conrelid = $1::regclass AND con.contype = 'f'
+	ORDER BY con.conname, k.ord`
+
+// getPGFields 获取 PostgreSQL 表的字段列表
+func getPGFields(db *sql.DB, tableName string) ([]FieldInfo, error) {
+	rows, err := db.Query(pgFieldsQuery, pgRegclass(tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []FieldInfo
+	for rows.Next() {
+		var field FieldInfo
+		var identity bool
+		var enumValues string
+
+		if err := rows.Scan(&field.Name, &field.Type, &field.ColumnType, &field.Nullable,
+			&field.DefaultValue, &identity, &field.Comment, &field.PrimaryKey, &enumValues); err != nil {
+			return nil, err
+		}
+		fields = append(fields, pgField(field, identity, enumValues))
+	}
+
+	return fields, rows.Err()
+}
+
+// pgField 补全从 pg_catalog 读取的列：解析枚举取值（quote_literal 后以逗号连接），
+// identity 列与 serial 列（默认值为 nextval(...)）视为自增，默认值去掉类型转换
+func pgField(field FieldInfo, identity bool, enumValues string) FieldInfo {
+	if enumValues != "" {
+		field.EnumValues = pgLexer.parseEnumValues(enumValues)
+	}
+
+	if identity || strings.HasPrefix(field.DefaultValue, "nextval(") {
+		field.AutoIncrement = true
+		field.DefaultValue = ""
+	}
+	field.DefaultValue = normalizePGDefault(field.DefaultValue)
+	field.GoType = mapToGoType(typemap.Options{}, field)
+	return field
+}
+
+// getPGIndexes 获取 PostgreSQL 表的索引列表
+func getPGIndexes(db *sql.DB, tableName string) ([]IndexInfo, error) {
+	rows, err := db.Query(pgIndexesQuery, pgRegclass(tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []IndexInfo
+	for rows.Next() {
+		var index IndexInfo
+		var columns string
+
+		if err := rows.Scan(&index.Name, &columns, &index.Unique, &index.Primary); err != nil {
+			return nil, err
+		}
+		if columns == "" {
+			continue
+		}
+
+		index.Columns = strings.Split(columns, ",")
+		indexes = append(indexes, index)
+	}
+
+	return indexes, rows.Err()
+}
+
+// getPGForeignKeys 获取 PostgreSQL 表的外键列表
+func getPGForeignKeys(db *sql.DB, tableName string) ([]ForeignKeyInfo, error) {
+	rows, err := db.Query(pgForeignKeysQuery, pgRegclass(tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKeyInfo
+	for rows.Next() {
+		var name, column, refTable, refColumn string
+		if err := rows.Scan(&name, &column, &refTable, &refColumn); err != nil {
+			return nil, err
+		}
+		foreignKeys = appendForeignKeyColumn(foreignKeys, name, column, refTable, refColumn)
+	}
+
+	return foreignKeys, rows.Err()
+}
+
+// pgTableExists 检查表是否存在（支持 schema.table）
+func pgTableExists(db *sql.DB, tableName string) bool {
+	var exists bool
+	err := db.QueryRow("SELECT to_regclass($1::text) IS NOT NULL", pgRegclass(tableName)).Scan(&exists)
+	return err == nil && exists
+}
+
+// pgRegclass 把 schema.table 转换为带引号的 regclass 文本，保留大小写
+func pgRegclass(tableName string) string {
+	schema, table := splitSchemaTable(tableName)
+	if schema == "" {
+		return quotePGIdent(table)
+	}
+	return quotePGIdent(schema) + "." + quotePGIdent(table)
+}
+
+// splitSchemaTable 拆分 schema.table，未指定 schema 时 schema 为空
+func splitSchemaTable(name string) (schema, table string) {
+	if idx := strings.LastIndex(name, "."); idx > 0 {
+		return name[:idx], name[idx+1:]
+	}
+	return "", name
+}
+
+// quotePGIdent 为标识符加双引号
+func quotePGIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// normalizePGDefault 去掉默认值最外层的类型转换并还原字符串，如 'draft'::character varying -> draft、
+// E'C:\\temp'::text -> C:\temp；函数调用（如 now()、nextval('seq'::regclass)）中的类型转换保持不变
+func normalizePGDefault(value string) string {
+	if idx := pgCastIndex(value); idx > 0 {
+		value = value[:idx]
+	}
+	return pgLexer.unquoteString(value)
+}
+
+// pgCastIndex 返回不在字符串和括号内的第一个 :: 的位置，没有时返回 -1
+func pgCastIndex(value string) int {
+	depth, quoted := 0, false
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case quoted:
+			// 字符串内的 '' 视为先结束再开始，不影响结果
+			quoted = c != '\''
+		case c == '\'':
+			quoted = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ':' && depth == 0 && strings.HasPrefix(value[i:], "::"):
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package gen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNormalizePGDefault 验证只去掉最外层的类型转换，函数调用中的转换保持不变
+func TestNormalizePGDefault(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"'draft'::character varying", "draft"},
+		{"'it''s'::text", "it's"},
+		{"'a::b'::text", "a::b"},
+		{"'{}'::jsonb", "{}"},
+		{`E'C:\\temp'::text`, `C:\temp`},
+		{"0", "0"},
+		{"(-1)::integer", "(-1)"},
+		{"now()", "now()"},
+		{"CURRENT_TIMESTAMP", "CURRENT_TIMESTAMP"},
+		{"nextval('x_id_seq'::regclass)", "nextval('x_id_seq'::regclass)"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePGDefault(tt.value); got != tt.want {
+			t.Errorf("normalizePGDefault(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestPGRegclass 验证 schema 限定名与大小写混合的表名被正确拆分并加引号
+func TestPGRegclass(t *testing.T) {
+	tests := []struct {
+		name         string
+		schema       string
+		table        string
+		wantRegclass string
+	}{
+		{"users", "", "users", `"users"`},
+		{"billing.invoices", "billing", "invoices", `"billing"."invoices"`},
+		{"Sales.OrderItems", "Sales", "OrderItems", `"Sales"."OrderItems"`},
+		{"UserProfiles", "", "UserProfiles", `"UserProfiles"`},
+		{`we"ird`, "", `we"ird`, `"we""ird"`},
+	}
+
+	for _, tt := range tests {
+		schema, table := splitSchemaTable(tt.name)
+		if schema != tt.schema || table != tt.table {
+			t.Errorf("splitSchemaTable(%q) = %q, %q, want %q, %q", tt.name, schema, table, tt.schema, tt.table)
+		}
+		if got := pgRegclass(tt.name); got != tt.wantRegclass {
+			t.Errorf("pgRegclass(%q) = %s, want %s", tt.name, got, tt.wantRegclass)
+		}
+	}
+}
+
+// TestPGField 验证 identity 与 serial 列视为自增，枚举类型的取值按 quote_literal 的结果解析
+func TestPGField(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      FieldInfo
+		identity   bool
+		enumValues string
+		wantAuto   bool
+		wantDef    string
+		wantEnum   []string
+	}{
+		{
+			name:     "identity",
+			field:    FieldInfo{Name: "id", Type: "bigint", ColumnType: "bigint", PrimaryKey: true},
+			identity: true, wantAuto: true,
+		},
+		{
+			name:     "serial",
+			field:    FieldInfo{Name: "id", Type: "integer", ColumnType: "integer", DefaultValue: "nextval('users_id_seq'::regclass)"},
+			wantAuto: true,
+		},
+		{
+			name:    "default",
+			field:   FieldInfo{Name: "level", Type: "integer", ColumnType: "integer", DefaultValue: "1"},
+			wantDef: "1",
+		},
+		{
+			name:       "enum",
+			field:      FieldInfo{Name: "status", Type: "order_status", ColumnType: "order_status", DefaultValue: "'draft'::order_status"},
+			enumValues: `'draft','it''s','a,b','in (review)',E'a\\b'`,
+			wantDef:    "draft",
+			wantEnum:   []string{"draft", "it's", "a,b", "in (review)", `a\b`},
+		},
+	}
+
+	for _, tt := range tests {
+		got := pgField(tt.field, tt.identity, tt.enumValues)
+		if got.AutoIncrement != tt.wantAuto || got.DefaultValue != tt.wantDef {
+			t.Errorf("%s: AutoIncrement = %v, DefaultValue = %q, want %v, %q", tt.name, got.AutoIncrement, got.DefaultValue, tt.wantAuto, tt.wantDef)
+		}
+		if !reflect.DeepEqual(got.EnumValues, tt.wantEnum) {
+			t.Errorf("%s: EnumValues = %q, want %q", tt.name, got.EnumValues, tt.wantEnum)
+		}
+	}
+}
+
+// TestCheckModelNames 验证不同 schema 下的同名表不会生成同名模型
+func TestCheckModelNames(t *testing.T) {
+	tables := []string{"a.users", "b.users"}
+
+	g := &DatabaseGenerator{config: Config{Tables: tables}}
+	err := g.checkModelNames()
+	if err == nil || !strings.Contains(err.Error(), "a.users") || !strings.Contains(err.Error(), "b.users") {
+		t.Errorf("checkModelNames() = %v, want collision between a.users and b.users", err)
+	}
+
+	g.config.Options = &GenConfig{Overrides: map[string]TableConfig{"b.users": {Model: "BUsers"}}}
+	if err := g.checkModelNames(); err != nil {
+		t.Errorf("checkModelNames() with override = %v, want nil", err)
+	}
+}
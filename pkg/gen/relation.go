@@ -0,0 +1,345 @@
+package gen
+
+import (
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/schema"
+)
+
+// 关联类型
+const (
+	RelationBelongsTo = "belongs_to" // 外键所在的表 -> 被引用的表
+	RelationHasMany   = "has_many"   // 被引用的表 -> 外键所在的表
+	RelationMany2Many = "many2many"  // 通过中间表关联的两张表
+)
+
+// RelationInfo 模型上的关联字段
+type RelationInfo struct {
+	Kind      string // 关联类型
+	FieldName string // 结构体字段名
+	Table     string // 关联的表
+	ModelName string // 关联的模型名
+	GORMTag   string // gorm 标签
+}
+
+// GoType 字段类型：belongsTo 为指针，hasMany/many2many 为切片
+func (r RelationInfo) GoType() string {
+	if r.Kind == RelationBelongsTo {
+		return "*" + r.ModelName
+	}
+	return "[]*" + r.ModelName
+}
+
+// JSONName JSON 字段名，如 Comments -> comments
+func (r RelationInfo) JSONName() string {
+	return schema.NamingStrategy{}.ColumnName("", r.FieldName)
+}
+
+// JSONTag json 标签，未预加载时不输出
+func (r RelationInfo) JSONTag() string {
+	return r.JSONName() + ",omitempty"
+}
+
+// ForeignKeyFinder 按外键列分页查询的方法（ListBy<FieldName>）
+type ForeignKeyFinder struct {
+	Column    string // 外键列
+	FieldName string // 模型字段名，如 ArticleID
+	GoType    string // 参数类型
+	Route     string // 对应的子资源路由（swagger 格式），为空时不生成 Controller 方法
+}
+
+// ParseFunc 从路径参数解析整数外键值使用的 strconv 函数
+func (f ForeignKeyFinder) ParseFunc() string {
+	if strings.HasPrefix(f.GoType, "uint") {
+		return "ParseUint"
+	}
+	return "ParseInt"
+}
+
+// routable 外键值能否从路径参数解析
+func (f ForeignKeyFinder) routable() bool {
+	switch f.GoType {
+	case "string", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// NestedRoute 父资源下的子资源列表路由，如 GET /articles/:id/comments
+type NestedRoute struct {
+	Path      string // 相对父资源路由组的路径，如 /:id/comments
+	ModelName string // 子资源模型名
+	Handler   string // 子资源 Controller 的方法名
+}
+
+// tableRelations 单表需要生成的关联代码
+type tableRelations struct {
+	Relations []RelationInfo     // 模型关联字段（即可预加载的关联）
+	Finders   []ForeignKeyFinder // Repository/Service/Controller 的 ListBy 方法
+	Nested    []NestedRoute      // 注册在本表路由组下的子资源路由
+}
+
+// relationOpts 把关联字段转换为 GORM Gen 的模型选项
+//
+// 关联字段以普通字段的形式加入模型（没有列名），GORM 通过 gorm 标签识别关联，
+// GORM Gen 不会为其生成查询字段。
+func relationOpts(rels *tableRelations) []gen.ModelOpt {
+	var result []gen.ModelOpt
+	for _, rel := range rels.Relations {
+		result = append(result, gen.FieldNew(rel.FieldName, rel.GoType(), field.Tag{
+			field.TagKeyGorm: rel.GORMTag,
+			field.TagKeyJson: rel.JSONTag(),
+		}))
+	}
+	return result
+}
+
+// relationBuilder 根据外键推导所选表之间的关联
+type relationBuilder struct {
+	schemas map[string]*DetailedTableInfo
+	options func(string) tableOptions
+	result  map[string]*tableRelations
+	used    map[string]map[string]bool // 每张表已占用的字段名
+}
+
+// buildRelations 推导所选表之间的关联
+//
+// 规则：
+//   - 只处理单列外键，且两端的表都在本次生成范围内、外键列未被忽略
+//   - 外键所在的表生成 belongsTo 字段，被引用的表生成 hasMany 字段
+//   - 只包含两个外键（及 id、时间戳列）的表视为中间表，
+//     两端的表互相生成 many2many 字段，不再生成指向中间表的 hasMany
+//   - 外键列生成 ListBy 方法，父资源下生成子资源列表路由
+func buildRelations(tables []string, schemas map[string]*DetailedTableInfo, options func(string) tableOptions) map[string]*tableRelations {
+	b := &relationBuilder{
+		schemas: schemas,
+		options: options,
+		result:  make(map[string]*tableRelations),
+		used:    make(map[string]map[string]bool),
+	}
+
+	for _, table := range tables {
+		b.result[table] = &tableRelations{}
+		b.used[table] = make(map[string]bool)
+		for _, f := range schemas[table].Fields {
+			if !options(table).ignored(f.Name) {
+				b.used[table][columnFieldName(f.Name)] = true
+			}
+		}
+	}
+
+	for _, table := range tables {
+		b.addForeignKeys(table)
+	}
+	for _, table := range tables {
+		if fks := b.foreignKeys(table); b.isJoinTable(table, fks) {
+			b.addMany2Many(table, fks[0], fks[1])
+		}
+	}
+
+	return b.result
+}
+
+// foreignKeys 返回可生成关联的外键：单列、引用的表在生成范围内、两端的列都未被忽略
+func (b *relationBuilder) foreignKeys(table string) []ForeignKeyInfo {
+	var result []ForeignKeyInfo
+	for _, fk := range b.schemas[table].ForeignKeys {
+		if len(fk.Columns) != 1 || b.schemas[fk.RefTable] == nil || b.result[fk.RefTable] == nil {
+			continue
+		}
+		if len(fk.RefColumns) == 0 {
+			pk := primaryKeyColumn(b.schemas[fk.RefTable])
+			if pk == "" {
+				continue
+			}
+			fk.RefColumns = []string{pk}
+		}
+		if len(fk.RefColumns) != 1 || b.options(table).ignored(fk.Columns[0]) || b.options(fk.RefTable).ignored(fk.RefColumns[0]) {
+			continue
+		}
+		result = append(result, fk)
+	}
+	return result
+}
+
+// isJoinTable 是否为多对多中间表：恰好两个外键，其它列只有 id 与时间戳
+func (b *relationBuilder) isJoinTable(table string, fks []ForeignKeyInfo) bool {
+	if len(fks) != 2 || fks[0].RefTable == fks[1].RefTable {
+		return false
+	}
+	for _, f := range b.schemas[table].Fields {
+		if f.Name == fks[0].Columns[0] || f.Name == fks[1].Columns[0] {
+			continue
+		}
+		switch f.Name {
+		case "id", "created_at", "updated_at", "deleted_at":
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// addForeignKeys 为外键生成 belongsTo、hasMany、ListBy 方法与子资源路由
+func (b *relationBuilder) addForeignKeys(table string) {
+	fks := b.foreignKeys(table)
+	joinTable := b.isJoinTable(table, fks)
+	childModel := b.options(table).ModelName
+
+	// 同一张父表被多个外键引用时（如 sender_id、receiver_id），hasMany 字段与路由需要区分
+	refCount := make(map[string]int)
+	for _, fk := range fks {
+		refCount[fk.RefTable]++
+	}
+
+	for _, fk := range fks {
+		column, refColumn := fk.Columns[0], fk.RefColumns[0]
+		parentModel := b.options(fk.RefTable).ModelName
+		tag := "foreignKey:" + columnFieldName(column) + ";references:" + columnFieldName(refColumn)
+
+		// belongsTo：comments.article_id -> Comments.Article
+		name := b.fieldName(table, strings.TrimSuffix(columnFieldName(column), "ID"), parentModel)
+		b.result[table].Relations = append(b.result[table].Relations, RelationInfo{
+			Kind:      RelationBelongsTo,
+			FieldName: name,
+			Table:     fk.RefTable,
+			ModelName: parentModel,
+			GORMTag:   tag,
+		})
+
+		finder := b.addFinder(table, column)
+
+		if joinTable {
+			continue
+		}
+
+		// hasMany：articles -> Articles.Comments
+		candidate := childModel
+		if refCount[fk.RefTable] > 1 {
+			candidate = childModel + "By" + name
+		}
+		b.result[fk.RefTable].Relations = append(b.result[fk.RefTable].Relations, RelationInfo{
+			Kind:      RelationHasMany,
+			FieldName: b.fieldName(fk.RefTable, candidate),
+			Table:     table,
+			ModelName: childModel,
+			GORMTag:   tag,
+		})
+
+		// 子资源路由：GET /articles/:id/comments（父表的 /:id 必须就是外键引用的列）
+		if finder != nil && finder.routable() && b.options(table).has(LayerController) && b.options(fk.RefTable).has(LayerRoutes) &&
+			primaryKeyColumn(b.schemas[fk.RefTable]) == refColumn {
+			_, short := splitSchemaTable(table)
+			path := "/:id/" + short
+			if refCount[fk.RefTable] > 1 {
+				path += "/" + schema.NamingStrategy{}.ColumnName("", name)
+			}
+			finder.Route = "/" + toLowerCamelCase(parentModel) + "s" + strings.Replace(path, ":id", "{id}", 1)
+			b.result[fk.RefTable].Nested = append(b.result[fk.RefTable].Nested, NestedRoute{
+				Path:      path,
+				ModelName: childModel,
+				Handler:   "ListBy" + finder.FieldName,
+			})
+		}
+	}
+}
+
+// addFinder 为外键列登记 ListBy 方法（需要生成 Repository 层）
+func (b *relationBuilder) addFinder(table, column string) *ForeignKeyFinder {
+	opts := b.options(table)
+	if !opts.has(LayerRepository) {
+		return nil
+	}
+
+	rels := b.result[table]
+	for i := range rels.Finders {
+		if rels.Finders[i].Column == column {
+			return &rels.Finders[i]
+		}
+	}
+
+	goType := strings.TrimPrefix(opts.Columns[column].Type, "*")
+	if goType == "" {
+		for _, f := range b.schemas[table].Fields {
+			if f.Name == column {
+				goType = opts.Types.BaseType(typeColumn(f))
+			}
+		}
+	}
+
+	rels.Finders = append(rels.Finders, ForeignKeyFinder{
+		Column:    column,
+		FieldName: columnFieldName(column),
+		GoType:    goType,
+	})
+	return &rels.Finders[len(rels.Finders)-1]
+}
+
+// addMany2Many 为中间表两端的表互相生成 many2many 字段
+func (b *relationBuilder) addMany2Many(joinTable string, left, right ForeignKeyInfo) {
+	_, short := splitSchemaTable(joinTable)
+
+	for _, pair := range [][2]ForeignKeyInfo{{left, right}, {right, left}} {
+		from, to := pair[0], pair[1]
+		toModel := b.options(to.RefTable).ModelName
+
+		b.result[from.RefTable].Relations = append(b.result[from.RefTable].Relations, RelationInfo{
+			Kind:      RelationMany2Many,
+			FieldName: b.fieldName(from.RefTable, toModel),
+			Table:     to.RefTable,
+			ModelName: toModel,
+			GORMTag: "many2many:" + short +
+				";foreignKey:" + columnFieldName(from.RefColumns[0]) +
+				";joinForeignKey:" + columnFieldName(from.Columns[0]) +
+				";references:" + columnFieldName(to.RefColumns[0]) +
+				";joinReferences:" + columnFieldName(to.Columns[0]),
+		})
+	}
+}
+
+// fieldName 从候选名中选出未被占用的字段名，全部冲突时在最后一个候选名后追加 Ref
+func (b *relationBuilder) fieldName(table string, candidates ...string) string {
+	used := b.used[table]
+
+	var name string
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		name = candidate
+		if !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+
+	for used[name] {
+		name += "Ref"
+	}
+	used[name] = true
+	return name
+}
+
+// primaryKeyColumn 返回单列主键的列名，复合主键或无主键时返回空
+func primaryKeyColumn(table *DetailedTableInfo) string {
+	var pk string
+	for _, f := range table.Fields {
+		if f.PrimaryKey {
+			if pk != "" {
+				return ""
+			}
+			pk = f.Name
+		}
+	}
+	return pk
+}
+
+// columnFieldName 列名对应的模型字段名（如 user_id -> UserID）
+//
+// GORM Gen 命名字段时使用 SingularTable，列名保持单复数不变（views -> Views、data -> Data），
+// 这里需要一致，否则 gen sql 与 gen db 生成的字段名不同，模板引用的字段也不存在
+func columnFieldName(column string) string {
+	return schema.NamingStrategy{SingularTable: true}.SchemaName(column)
+}
@@ -0,0 +1,120 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBuildRelations 验证外键解析以及 belongsTo/hasMany/many2many、ListBy 方法与子资源路由的推导
+func TestBuildRelations(t *testing.T) {
+	ddl := `CREATE TABLE users (id bigserial PRIMARY KEY, name text NOT NULL);
+CREATE TABLE articles (
+  id bigserial PRIMARY KEY,
+  author_id bigint NOT NULL REFERENCES users,
+  title text NOT NULL
+);
+CREATE TABLE comments (
+  id bigserial PRIMARY KEY,
+  article_id bigint NOT NULL,
+  body text,
+  CONSTRAINT fk_comments_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+CREATE TABLE tags (id bigserial PRIMARY KEY, name text NOT NULL);
+CREATE TABLE article_tags (article_id bigint NOT NULL, tag_id bigint NOT NULL, PRIMARY KEY (article_id, tag_id));
+ALTER TABLE article_tags ADD FOREIGN KEY (article_id) REFERENCES articles (id), ADD FOREIGN KEY (tag_id) REFERENCES tags (id);`
+
+	schema := parseSchema(t, ddl, nil)
+
+	wantFK := []ForeignKeyInfo{{Name: "articles_author_id_fkey", Columns: []string{"author_id"}, RefTable: "users"}}
+	if got := schema.Table("articles").ForeignKeys; !reflect.DeepEqual(got, wantFK) {
+		t.Errorf("articles ForeignKeys = %+v, want %+v", got, wantFK)
+	}
+	if got := schema.Table("article_tags").ForeignKeys; len(got) != 2 || got[1].RefTable != "tags" || got[1].RefColumns[0] != "id" {
+		t.Errorf("article_tags ForeignKeys = %+v", got)
+	}
+
+	var tables []string
+	schemas := make(map[string]*DetailedTableInfo)
+	for _, table := range schema.Tables {
+		tables = append(tables, table.Name)
+		schemas[table.Name] = table
+	}
+	rels := buildRelations(tables, schemas, (*GenConfig)(nil).table)
+
+	fields := func(table string) map[string]string {
+		result := make(map[string]string)
+		for _, rel := range rels[table].Relations {
+			result[rel.FieldName] = rel.GoType() + " " + rel.GORMTag
+		}
+		return result
+	}
+
+	wantArticles := map[string]string{
+		"Author":   "*Users foreignKey:AuthorID;references:ID",
+		"Comments": "[]*Comments foreignKey:ArticleID;references:ID",
+		"Tags":     "[]*Tags many2many:article_tags;foreignKey:ID;joinForeignKey:ArticleID;references:ID;joinReferences:TagID",
+	}
+	if got := fields("articles"); !reflect.DeepEqual(got, wantArticles) {
+		t.Errorf("articles relations = %v, want %v", got, wantArticles)
+	}
+	if got := fields("users"); len(got) != 1 || got["Articles"] == "" {
+		t.Errorf("users relations = %v", got)
+	}
+
+	wantFinders := []ForeignKeyFinder{{Column: "article_id", FieldName: "ArticleID", GoType: "int64", Route: "/articless/{id}/comments"}}
+	if got := rels["comments"].Finders; !reflect.DeepEqual(got, wantFinders) {
+		t.Errorf("comments finders = %+v, want %+v", got, wantFinders)
+	}
+
+	wantNested := []NestedRoute{{Path: "/:id/comments", ModelName: "Comments", Handler: "ListByArticleID"}}
+	if got := rels["articles"].Nested; !reflect.DeepEqual(got, wantNested) {
+		t.Errorf("articles nested routes = %+v, want %+v", got, wantNested)
+	}
+}
+
+// TestPluralColumnFieldNames 验证单复数形式的列名与 GORM Gen 的字段名一致：
+// 主键、索引、唯一索引列生成的代码能编译，与关联同名的列不会和关联字段冲突
+func TestPluralColumnFieldNames(t *testing.T) {
+	for column, want := range map[string]string{"views": "Views", "data": "Data", "media_id": "MediaID", "user_id": "UserID"} {
+		if got := columnFieldName(column); got != want {
+			t.Errorf("columnFieldName(%q) = %q, want %q", column, got, want)
+		}
+	}
+
+	ddl := "CREATE TABLE `tags` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `name` varchar(50) NOT NULL, PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `posts` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `views` int NOT NULL, `data` varchar(100) NOT NULL, `tags` varchar(200) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`), KEY `idx_views` (`views`), UNIQUE KEY `uk_data` (`data`));\n" +
+		"CREATE TABLE `post_tags` (`post_id` bigint unsigned NOT NULL, `tag_id` bigint unsigned NOT NULL, PRIMARY KEY (`post_id`, `tag_id`),\n" +
+		"  FOREIGN KEY (`post_id`) REFERENCES `posts` (`id`), FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`));\n" +
+		"CREATE TABLE `media` (`codes` varchar(20) NOT NULL, `title` varchar(100) NOT NULL, PRIMARY KEY (`codes`));"
+	dir := generateSQL(t, ddl, nil)
+
+	got := structFields(t, dir, "internal/dal/model/posts.gen.go", "Posts")
+	want := map[string]string{"ID": "uint64", "Views": "int32", "Data": "string", "Tags": "string", "TagsRef": "[]*Tags"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Posts fields = %v, want %v", got, want)
+	}
+	vetGenerated(t, dir)
+}
+
+// TestRelationsGenerated 验证外键生成的关联字段与按外键查询的方法，且生成的代码能编译
+func TestRelationsGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `name` varchar(50) NOT NULL, PRIMARY KEY (`id`));\n" +
+		"CREATE TABLE `articles` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `author_id` bigint unsigned NOT NULL, `title` varchar(100) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`), FOREIGN KEY (`author_id`) REFERENCES `users` (`id`));\n" +
+		"CREATE TABLE `comments` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `article_id` bigint unsigned NOT NULL, `body` text,\n" +
+		"  PRIMARY KEY (`id`), FOREIGN KEY (`article_id`) REFERENCES `articles` (`id`));"
+	dir := generateSQL(t, ddl, nil)
+
+	got := structFields(t, dir, "internal/dal/model/articles.gen.go", "Articles")
+	want := map[string]string{"ID": "uint64", "AuthorID": "uint64", "Title": "string", "Author": "*Users", "Comments": "[]*Comments"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Articles fields = %v, want %v", got, want)
+	}
+
+	sigs := signatures(funcDecls(t, dir, "internal/repository/comments.go"), "CommentsRepository.ListByArticleID")
+	if want := "func(ctx context.Context, articleID uint64, page, pageSize int, preloads ...string) ([]*model.Comments, int64, error)"; sigs["CommentsRepository.ListByArticleID"] != want {
+		t.Errorf("CommentsRepository.ListByArticleID = %q, want %q", sigs["CommentsRepository.ListByArticleID"], want)
+	}
+	vetGenerated(t, dir)
+}
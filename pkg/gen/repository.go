@@ -4,22 +4,34 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
 // RepositoryConfig Repository 配置
 type RepositoryConfig struct {
-	TableName   string   // 表名
-	ModelName   string   // 模型名称
-	PackageName string   // 包名
-	ModulePath  string   // 模块路径
-	Indexes     []string // 索引字段列表
+	TableName    string             // 表名
+	ModelName    string             // 模型名称
+	PackageName  string             // 包名
+	ModulePath   string             // 模块路径
+	Indexes      []IndexFinder      // 索引查询方法
+	Key          PrimaryKey         // 主键
+	SoftDelete   bool               // 是否使用软删除（表有 deleted_at 列），生成 Restore/ListTrash/HardDelete
+	ListColumns  []ListColumn       // List 接口可过滤、排序、选择的列
+	Cursor       *ListColumn        // 游标分页使用的列，非 nil 时生成 ListAfter
+	PatchColumns []ListColumn       // PATCH 接口可更新的列
+	Version      *ListColumn        // 乐观锁版本列，非 nil 时 Update/Patch/UpdateBatch 按版本号更新
+	Relations    []RelationInfo     // 可预加载的关联
+	Finders      []ForeignKeyFinder // 外键查询方法
+	Imports      []string           // 主键与索引列类型需要的导入（如 time），生成时自动填充
 }
 
 // GenerateRepository 生成 Repository 层代码
 func (g *DatabaseGenerator) GenerateRepository(table TableInfo, config RepositoryConfig) error {
 	fmt.Printf("  📦 生成 %s Repository...\n", config.ModelName)
+	config.Key = config.Key.orDefault()
+	config.Imports = repositoryImports(config)
 
 	// 创建输出目录
 	outputDir := filepath.Join(g.config.Output, "internal/repository")
@@ -33,36 +45,40 @@ func (g *DatabaseGenerator) GenerateRepository(table TableInfo, config Repositor
 		return err
 	}
 
-	// 2. 生成 Repository 实现文件（users.go, posts.go 等）
+	// 2. 生成预加载、列表查询、批量操作、部分更新与乐观锁公共定义（preload.go、query.go、batch.go、patch.go、optimisticlock.go）
+	if err := os.WriteFile(filepath.Join(outputDir, "preload.go"), []byte(preloadErrorsTmpl), 0644); err != nil {
+		return fmt.Errorf("创建预加载定义文件失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "query.go"), []byte(listQueryTmpl), 0644); err != nil {
+		return fmt.Errorf("创建列表查询定义文件失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "batch.go"), []byte(batchTmpl), 0644); err != nil {
+		return fmt.Errorf("创建批量操作定义文件失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "patch.go"), []byte(patchTmpl), 0644); err != nil {
+		return fmt.Errorf("创建部分更新定义文件失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "optimisticlock.go"), []byte(optimisticLockTmpl), 0644); err != nil {
+		return fmt.Errorf("创建乐观锁定义文件失败: %w", err)
+	}
+
+	// 3. 生成 Repository 实现文件（users.go, posts.go 等）
 	outputPath := filepath.Join(outputDir, strings.ToLower(config.ModelName)+".go")
 	if err := g.renderRepositoryImplTemplate(outputPath, config); err != nil {
 		return err
 	}
 
+	// 4. 生成接口的 Mock（internal/repository/mock），供 Service/Controller 单元测试使用
+	if err := g.GenerateMock(config); err != nil {
+		return err
+	}
+
 	fmt.Printf("     ✓ %sRepository 创建成功\n", config.ModelName)
 	return nil
 }
 
-// renderInterfacesTemplate 渲染接口文件模板（生成一次，追加模式）
-func (g *DatabaseGenerator) renderInterfacesTemplate(outputPath string, config RepositoryConfig) error {
-	// 检查文件是否已存在
-	if _, err := os.Stat(outputPath); err == nil {
-		// 文件已存在，追加接口定义
-		return g.appendInterfaceToFile(outputPath, config)
-	}
-
-	// 文件不存在，创建新文件
-	tmpl := `// Code generated by go-start. DO NOT EDIT.
-// Repository Interfaces
-
-package repository
-
-import (
-	"context"
-	"{{.ModulePath}}/internal/dal/model"
-)
-
-// {{.ModelName}}Repo {{.ModelName}} 仓储接口
+// repoInterfaceTmpl 单个仓储接口定义，新建 interfaces.go 与追加/更新接口时共用
+const repoInterfaceTmpl = `// {{.ModelName}}Repo {{.ModelName}} 仓储接口
 //
 // 设计理念：
 //   - 使用接口抽象数据访问层，便于测试和替换实现
@@ -72,32 +88,93 @@ type {{.ModelName}}Repo interface {
 	// Create 创建 {{.ModelName}}
 	Create(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error
 
-	// GetByID 根据 ID 获取 {{.ModelName}}
-	GetByID(ctx context.Context, id uint) (*model.{{.ModelName}}, error)
+	// GetByID 根据主键获取 {{.ModelName}}，preloads 为要预加载的关联
+	GetByID(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{.ModelName}}, error)
 
-	// Update 更新 {{.ModelName}}
+	// Update 更新 {{.ModelName}}{{if .Version}}，{{.Version.Column}} 与数据库不一致时返回 ErrVersionConflict{{end}}
 	Update(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error
 
-	// Delete 根据 ID 删除 {{.ModelName}}
-	Delete(ctx context.Context, id uint) error
+	// Patch 根据主键只更新 columns 中的列（零值也会写入）{{if .Version}}，{{.Version.Column}} 与数据库不一致时返回 ErrVersionConflict{{end}}
+	Patch(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}, columns []string) error
+
+	// Delete 根据主键删除 {{.ModelName}}
+	Delete(ctx context.Context, {{.Key.Params}}) error
+
+	// List 获取 {{.ModelName}} 列表（分页），q 为过滤、排序与字段选择条件，preloads 为要预加载的关联
+	List(ctx context.Context, q ListQuery, page, pageSize int, preloads ...string) ([]*model.{{.ModelName}}, int64, error)
+{{- if .Cursor}}
 
-	// List 获取 {{.ModelName}} 列表（分页）
-	List(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error)
+	// ListAfter 按 {{.Cursor.Column}} 游标分页获取 {{.ModelName}} 列表（不统计总数），返回下一页游标
+	ListAfter(ctx context.Context, q ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.{{.ModelName}}, string, error)
+{{- end}}
 
 	// Count 统计 {{.ModelName}} 总数
 	Count(ctx context.Context) (int64, error)
-{{range $index := .Indexes}}
-{{if ne $index "id"}}
-	// By{{$index}} 根据 {{$index}} 查询 {{$.ModelName}}
-	By{{$index}}(ctx context.Context, {{$index}} interface{}) (*model.{{$.ModelName}}, error)
 
-	// By{{$index}}List 根据 {{$index}} 查询 {{$.ModelName}} 列表
-	By{{$index}}List(ctx context.Context, {{$index}} interface{}) ([]*model.{{$.ModelName}}, error)
-{{end}}
+	// CreateBatch 在一个事务中批量创建 {{.ModelName}}
+	CreateBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error
+
+	// UpdateBatch 在一个事务中批量更新 {{.ModelName}}，失败时返回 BatchError
+	UpdateBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error
+
+	// DeleteBatch 在一个事务中按主键批量删除 {{.ModelName}}，失败时返回 BatchError
+	DeleteBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error
+{{- if .SoftDelete}}
+
+	// Restore 根据主键恢复已软删除的 {{.ModelName}}
+	Restore(ctx context.Context, {{.Key.Params}}) error
+
+	// ListTrash 分页获取已软删除的 {{.ModelName}}（回收站）
+	ListTrash(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error)
+
+	// HardDelete 根据主键永久删除 {{.ModelName}}（包括已软删除的记录）
+	HardDelete(ctx context.Context, {{.Key.Params}}) error
+{{- end}}
+{{range .Indexes}}
+{{- if .Unique}}
+	// {{.Method}} 根据唯一索引 {{.Index}}（{{.Columns}}）查询 {{$.ModelName}}
+	{{.Method}}(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$.ModelName}}, error)
+{{- if .Unscoped}}
+
+	// {{.Method}}Unscoped 同 {{.Method}}，包括已软删除的记录（检查唯一约束冲突时使用）
+	{{.Method}}Unscoped(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$.ModelName}}, error)
+{{- end}}
+{{- else}}
+	// {{.Method}} 根据索引 {{.Index}}（{{.Columns}}）分页查询 {{$.ModelName}} 列表
+	{{.Method}}(ctx context.Context, {{.Params}}, page, pageSize int, preloads ...string) ([]*model.{{$.ModelName}}, int64, error)
+{{- end}}
 {{end}}
+{{- range .Finders}}
+	// ListBy{{.FieldName}} 根据外键 {{.Column}} 分页查询 {{$.ModelName}} 列表
+	ListBy{{.FieldName}}(ctx context.Context, {{ToLowerCamelCase .FieldName}} {{.GoType}}, page, pageSize int, preloads ...string) ([]*model.{{$.ModelName}}, int64, error)
+{{end -}}
 }
 `
 
+// renderInterfacesTemplate 渲染接口文件模板（生成一次，追加模式）
+func (g *DatabaseGenerator) renderInterfacesTemplate(outputPath string, config RepositoryConfig) error {
+	// 检查文件是否已存在
+	if _, err := os.Stat(outputPath); err == nil {
+		// 文件已存在，追加或更新接口定义
+		return g.appendInterfaceToFile(outputPath, config)
+	}
+
+	// 文件不存在，创建新文件
+	tmpl := `// Code generated by go-start. DO NOT EDIT.
+// Repository Interfaces
+
+package repository
+
+import (
+	"context"
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
+	"{{.ModulePath}}/internal/dal/model"
+)
+
+` + repoInterfaceTmpl
+
 	funcMap := template.FuncMap{
 		"ToLowerCamelCase": toLowerCamelCase,
 	}
@@ -118,6 +195,12 @@ type {{.ModelName}}Repo interface {
 		"PackageName": config.PackageName,
 		"ModulePath":  config.ModulePath,
 		"Indexes":     config.Indexes,
+		"Key":         config.Key,
+		"SoftDelete":  config.SoftDelete,
+		"Cursor":      config.Cursor,
+		"Version":     config.Version,
+		"Finders":     config.Finders,
+		"Imports":     config.Imports,
 	}
 
 	if err := t.Execute(f, data); err != nil {
@@ -127,7 +210,34 @@ type {{.ModelName}}Repo interface {
 	return nil
 }
 
-// appendInterfaceToFile 追加接口定义到现有文件
+// renderRepoInterface 渲染单个仓储接口定义（追加到 interfaces.go 与生成 Mock 时共用）
+func renderRepoInterface(config RepositoryConfig) (string, error) {
+	funcMap := template.FuncMap{
+		"ToLowerCamelCase": toLowerCamelCase,
+	}
+	t, err := template.New("interface_append").Funcs(funcMap).Parse(repoInterfaceTmpl)
+	if err != nil {
+		return "", fmt.Errorf("解析追加接口模板失败: %w", err)
+	}
+
+	data := map[string]interface{}{
+		"ModelName":  config.ModelName,
+		"Indexes":    config.Indexes,
+		"Key":        config.Key,
+		"SoftDelete": config.SoftDelete,
+		"Cursor":     config.Cursor,
+		"Version":    config.Version,
+		"Finders":    config.Finders,
+	}
+
+	var block strings.Builder
+	if err := t.Execute(&block, data); err != nil {
+		return "", fmt.Errorf("执行追加接口模板失败: %w", err)
+	}
+	return block.String(), nil
+}
+
+// appendInterfaceToFile 追加接口定义到现有文件，接口已存在时替换为最新定义
 func (g *DatabaseGenerator) appendInterfaceToFile(outputPath string, config RepositoryConfig) error {
 	// 读取现有内容
 	content, err := os.ReadFile(outputPath)
@@ -135,59 +245,81 @@ func (g *DatabaseGenerator) appendInterfaceToFile(outputPath string, config Repo
 		return fmt.Errorf("读取接口文件失败: %w", err)
 	}
 
-	// 检查接口是否已定义
-	interfaceName := config.ModelName + "Repo"
-	if strings.Contains(string(content), "type "+interfaceName+" interface") {
-		// 接口已存在，跳过
-		return nil
+	block, err := renderRepoInterface(config)
+	if err != nil {
+		return err
 	}
 
-	// 打开文件准备追加
-	f, err := os.OpenFile(outputPath, os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("打开接口文件失败: %w", err)
+	// 接口已定义时替换（表结构或外键可能已变化），否则追加到文件末尾
+	updated := replaceInterfaceBlock(string(content), config.ModelName+"Repo", block)
+	if updated == "" {
+		updated = string(content) + "\n" + block
+	}
+	for _, path := range config.Imports {
+		updated = addImport(updated, path)
 	}
-	defer f.Close()
 
-	// 生成接口定义
-	tmpl := `
-// {{.ModelName}}Repo {{.ModelName}} 仓储接口
-type {{.ModelName}}Repo interface {
-	Create(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error
-	GetByID(ctx context.Context, id uint) (*model.{{.ModelName}}, error)
-	Update(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error
-	Delete(ctx context.Context, id uint) error
-	List(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error)
-	Count(ctx context.Context) (int64, error)
-{{range $index := .Indexes}}
-{{if ne $index "id"}}
-	By{{$index}}(ctx context.Context, {{$index}} interface{}) (*model.{{$.ModelName}}, error)
-	By{{$index}}List(ctx context.Context, {{$index}} interface{}) ([]*model.{{$.ModelName}}, error)
-{{end}}
-{{end}}
+	if err := os.WriteFile(outputPath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("写入接口文件失败: %w", err)
+	}
+
+	return nil
 }
-`
 
-	funcMap := template.FuncMap{
-		"ToLowerCamelCase": toLowerCamelCase,
+// replaceInterfaceBlock 用 block 替换 content 中的接口定义（含前导注释），接口不存在时返回空字符串
+func replaceInterfaceBlock(content, name, block string) string {
+	start := strings.Index(content, "type "+name+" interface {")
+	if start < 0 {
+		return ""
 	}
-	t, err := template.New("interface_append").Funcs(funcMap).Parse(tmpl)
-	if err != nil {
-		return fmt.Errorf("解析追加接口模板失败: %w", err)
+	end := strings.Index(content[start:], "\n}\n")
+	if end < 0 {
+		return ""
 	}
-
-	data := map[string]interface{}{
-		"ModelName": config.ModelName,
-		"Indexes":   config.Indexes,
+	end += start + len("\n}\n")
+
+	// 向前包含紧邻的注释行
+	for start > 0 {
+		prev := strings.LastIndex(content[:start-1], "\n") + 1
+		if !strings.HasPrefix(content[prev:start], "//") {
+			break
+		}
+		start = prev
 	}
 
-	if err := t.Execute(f, data); err != nil {
-		return fmt.Errorf("执行追加接口模板失败: %w", err)
+	return content[:start] + block + content[end:]
+}
+
+// repositoryImports 主键与索引列类型需要的导入路径（去重并排序）
+func repositoryImports(config RepositoryConfig) []string {
+	fields := append([]KeyField(nil), config.Key.Fields...)
+	for _, idx := range config.Indexes {
+		fields = append(fields, idx.Fields...)
 	}
+	return fieldImports(fields)
+}
 
-	return nil
+// addImport 在 import 块中补充缺少的标准库导入（如主键类型需要的 time）
+func addImport(content, path string) string {
+	quoted := strconv.Quote(path)
+	if strings.Contains(content, "\t"+quoted+"\n") {
+		return content
+	}
+	return strings.Replace(content, "import (\n", "import (\n\t"+quoted+"\n", 1)
 }
 
+// preloadErrorsTmpl 预加载相关的公共定义，所有仓储共用
+const preloadErrorsTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Repository: 预加载
+
+package repository
+
+import "errors"
+
+// ErrUnknownPreload 请求预加载的关联不存在
+var ErrUnknownPreload = errors.New("未知的预加载关联")
+`
+
 // renderRepositoryImplTemplate 渲染 Repository 实现模板
 func (g *DatabaseGenerator) renderRepositoryImplTemplate(outputPath string, config RepositoryConfig) error {
 	tmpl := `// Code generated by go-start. DO NOT EDIT.
@@ -199,11 +331,23 @@ package repository
 import (
 	"context"
 	"fmt"
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
 
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
+	"{{.ModulePath}}/internal/dal/model"
 	"{{.ModulePath}}/internal/dal/query"
 )
-
+{{if .Relations}}
+// {{.ModelName}} 可预加载的关联，作为 GetByID/List 等方法的 preloads 参数
+const (
+{{- range .Relations}}
+	{{$.ModelName}}Preload{{.FieldName}} = "{{.FieldName}}" // {{.Kind}} {{.ModelName}}
+{{- end}}
+)
+{{end}}
 // {{.ModelName}}Repository {{.ModelName}} 数据访问层实现
 //
 // 职责说明：
@@ -243,34 +387,82 @@ func (r *{{.ModelName}}Repository) Create(ctx context.Context, {{ToLowerCamelCas
 	return r.q.{{.ModelName}}.WithContext(ctx).Create({{ToLowerCamelCase .ModelName}})
 }
 
-// GetByID 根据 ID 获取 {{.ModelName}}
+// GetByID 根据主键获取 {{.ModelName}}
+//
+// 参数：
+//   {{.Key.Args}} - 主键{{if .Key.Composite}}（复合主键 {{range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.Column}}{{end}}）{{end}}
+//   preloads - 要预加载的关联（{{.ModelName}}Preload* 常量），未知关联返回 ErrUnknownPreload
 //
 // 返回：
 //   *model.{{.ModelName}} - {{.ModelName}}数据，不存在时返回 nil
 //   error - 查询失败时返回错误
-func (r *{{.ModelName}}Repository) GetByID(ctx context.Context, id uint) (*model.{{.ModelName}}, error) {
-	return r.q.{{.ModelName}}.WithContext(ctx).Where(r.q.{{.ModelName}}.ID.Eq(id)).First()
+func (r *{{.ModelName}}Repository) GetByID(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{.ModelName}}, error) {
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, err
+	}
+	return do.Where({{template "keyWhere" .}}).First()
 }
 
 // Update 更新 {{.ModelName}}
 //
 // 参数：
 //   ctx - 请求上下文
-//   {{ToLowerCamelCase .ModelName}} - 要更新的数据，必须包含 ID
+//   {{ToLowerCamelCase .ModelName}} - 要更新的数据，必须包含主键{{if .Version}}与期望的 {{.Version.Column}}（更新成功后加 1）{{end}}
 //
 // 返回：
-//   error - 更新失败时返回错误
+//   error - 更新失败时返回错误{{if .Version}}，{{.Version.Column}} 与数据库不一致时返回 ErrVersionConflict{{end}}
 func (r *{{.ModelName}}Repository) Update(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error {
+{{- if .Version}}
+	return r.updateVersioned(r.q.{{.ModelName}}.WithContext(ctx), {{ToLowerCamelCase .ModelName}})
+{{- else}}
 	_, err := r.q.{{.ModelName}}.WithContext(ctx).Updates({{ToLowerCamelCase .ModelName}})
 	return err
+{{- end}}
 }
 
-// Delete 根据 ID 删除 {{.ModelName}}
+// Patch 根据主键只更新 columns 中的列，与 Update 不同，零值也会写入
+//
+// 参数：
+//   ctx - 请求上下文
+//   {{ToLowerCamelCase .ModelName}} - 包含主键与新值的数据{{if .Version}}，{{.Version.Column}} 为期望的版本号（更新成功后加 1）{{end}}
+//   columns - 要更新的列名（{{.ModelName}}PatchColumns 转换得到）
+//
+// 返回：
+//   error - 列名不存在时返回 ErrInvalidPatch，更新失败时返回错误{{if .Version}}，{{.Version.Column}} 与数据库不一致时返回 ErrVersionConflict{{end}}
+func (r *{{.ModelName}}Repository) Patch(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}, columns []string) error {
+	t := r.q.{{.ModelName}}
+	selected := make([]field.Expr, 0, len(columns))
+	for _, name := range columns {
+		col, ok := t.GetFieldByName(name)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidPatch, name)
+		}
+		selected = append(selected, col)
+	}
+
+{{- if .Version}}
+
+	do := t.WithContext(ctx).
+		Where({{range $i, $f := .Key.Fields}}{{if $i}}, {{end}}t.{{$f.FieldName}}.Eq({{$f.Value (ToLowerCamelCase $.ModelName)}}){{end}}).
+		Select(append(selected, t.{{.Version.FieldName}})...)
+	return r.updateVersioned(do, {{ToLowerCamelCase .ModelName}})
+{{- else}}
+
+	_, err := t.WithContext(ctx).
+		Where({{range $i, $f := .Key.Fields}}{{if $i}}, {{end}}t.{{$f.FieldName}}.Eq({{$f.Value (ToLowerCamelCase $.ModelName)}}){{end}}).
+		Select(selected...).
+		Updates({{ToLowerCamelCase .ModelName}})
+	return err
+{{- end}}
+}
+
+// Delete 根据主键删除 {{.ModelName}}{{if .SoftDelete}}（软删除：只设置 deleted_at，可通过 Restore 恢复）{{end}}
 //
 // 返回：
 //   error - 删除失败时返回错误
-func (r *{{.ModelName}}Repository) Delete(ctx context.Context, id uint) error {
-	_, err := r.q.{{.ModelName}}.WithContext(ctx).Where(r.q.{{.ModelName}}.ID.Eq(id)).Delete()
+func (r *{{.ModelName}}Repository) Delete(ctx context.Context, {{.Key.Params}}) error {
+	_, err := r.q.{{.ModelName}}.WithContext(ctx).Where({{template "keyWhere" .}}).Delete()
 	return err
 }
 
@@ -278,47 +470,401 @@ func (r *{{.ModelName}}Repository) Delete(ctx context.Context, id uint) error {
 //
 // 参数：
 //   ctx - 请求上下文
+//   q - 过滤、排序与字段选择条件，列名或运算符不在白名单中时返回 ErrInvalidQuery
 //   page - 页码，从 1 开始
 //   pageSize - 每页数量
+//   preloads - 要预加载的关联
 //
 // 返回：
 //   []*model.{{.ModelName}} - {{.ModelName}}列表
 //   int64 - 总数量
 //   error - 查询失败时返回错误
-func (r *{{.ModelName}}Repository) List(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error) {
-	{{ToLowerCamelCase .ModelName}}s, count, err := r.q.{{.ModelName}}.WithContext(ctx).
-		FindByPage(page, pageSize)
+func (r *{{.ModelName}}Repository) List(ctx context.Context, q ListQuery, page, pageSize int, preloads ...string) ([]*model.{{.ModelName}}, int64, error) {
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, 0, err
+	}
+	if do, err = r.applyListQuery(do, q); err != nil {
+		return nil, 0, err
+	}
+
+	{{ToLowerCamelCase .ModelName}}s, count, err := do.FindByPage((page-1)*pageSize, pageSize)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	return {{ToLowerCamelCase .ModelName}}s, count, nil
 }
+{{- if .Cursor}}
+
+// ListAfter 按 {{.Cursor.Column}} 游标（keyset）分页获取 {{.ModelName}} 列表，不执行 COUNT，适合大表
+//
+// 参数：
+//   ctx - 请求上下文
+//   q - 过滤与字段选择条件；排序只支持 {{.Cursor.Column}}（sort=-{{.Cursor.Column}} 为倒序）
+//   cursor - 上一页返回的游标，为空时从第一条开始
+//   pageSize - 每页数量
+//   preloads - 要预加载的关联
+//
+// 返回：
+//   []*model.{{.ModelName}} - {{.ModelName}}列表
+//   string - 下一页的游标，没有更多数据时为空
+//   error - 游标或查询条件不合法时返回 ErrInvalidQuery
+func (r *{{.ModelName}}Repository) ListAfter(ctx context.Context, q ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.{{.ModelName}}, string, error) {
+	desc, err := cursorOrder(q.Sorts, "{{.Cursor.Column}}")
+	if err != nil {
+		return nil, "", err
+	}
+	q.Sorts = nil
+
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, "", err
+	}
+	if do, err = r.applyListQuery(do, q); err != nil {
+		return nil, "", err
+	}
+
+	t := r.q.{{.ModelName}}
+	if cursor != "" {
+		after, err := decodeCursor(cursor, {{.Cursor.Parser}})
+		if err != nil {
+			return nil, "", err
+		}
+		if desc {
+			do = do.Where(t.{{.Cursor.FieldName}}.Lt(after))
+		} else {
+			do = do.Where(t.{{.Cursor.FieldName}}.Gt(after))
+		}
+	}
+	if desc {
+		do = do.Order(t.{{.Cursor.FieldName}}.Desc())
+	} else {
+		do = do.Order(t.{{.Cursor.FieldName}})
+	}
+
+	// 多查一条判断是否还有下一页，代替 COUNT
+	{{ToLowerCamelCase .ModelName}}s, err := do.Limit(pageSize + 1).Find()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len({{ToLowerCamelCase .ModelName}}s) > pageSize {
+		{{ToLowerCamelCase .ModelName}}s = {{ToLowerCamelCase .ModelName}}s[:pageSize]
+		next = encodeCursor({{ToLowerCamelCase .ModelName}}s[pageSize-1].{{.Cursor.FieldName}})
+	}
+
+	return {{ToLowerCamelCase .ModelName}}s, next, nil
+}
+{{- end}}
 
 // Count 统计 {{.ModelName}} 总数
 func (r *{{.ModelName}}Repository) Count(ctx context.Context) (int64, error) {
 	return r.q.{{.ModelName}}.WithContext(ctx).Count()
 }
 
-{{range $index := .Indexes}}
-{{if ne $index "id"}}
-// By{{$index}} 根据 {{$index}} 查询 {{$.ModelName}}
+// CreateBatch 在一个事务中批量创建 {{.ModelName}}（CreateInBatches 每批 100 条），任一批失败时整体回滚
+func (r *{{.ModelName}}Repository) CreateBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		return tx.{{.ModelName}}.WithContext(ctx).CreateInBatches({{ToLowerCamelCase .ModelName}}s, createBatchSize)
+	})
+}
+
+// UpdateBatch 在一个事务中逐条更新 {{.ModelName}}（只更新非零值字段{{if .Version}}，按 {{.Version.Column}} 乐观锁{{end}}），任一条失败时整体回滚
+//
+// 返回：
+//   error - 更新失败{{if .Version}}或版本冲突（ErrVersionConflict）{{end}}时返回 BatchError，包含出错记录的下标
+func (r *{{.ModelName}}Repository) UpdateBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+{{- if .Version}}
+			if err := r.updateVersioned(tx.{{.ModelName}}.WithContext(ctx), {{ToLowerCamelCase .ModelName}}); err != nil {
+{{- else}}
+			if _, err := tx.{{.ModelName}}.WithContext(ctx).Updates({{ToLowerCamelCase .ModelName}}); err != nil {
+{{- end}}
+				return BatchError{{"{{"}}Index: i, Err: err{{"}}"}}
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteBatch 在一个事务中按主键逐条删除 {{.ModelName}}{{if .SoftDelete}}（软删除）{{end}}，任一条失败时整体回滚
 //
-// 使用索引：{{$index}}
-func (r *{{$.ModelName}}Repository) By{{$index}}(ctx context.Context, {{$index}} interface{}) (*model.{{$.ModelName}}, error) {
-	return r.q.{{$.ModelName}}.WithContext(ctx).
-		Where(r.q.{{$.ModelName}}.{{$index}}.Eq({{$index}})).
-		First()
+// 返回：
+//   error - 删除失败或记录不存在（gorm.ErrRecordNotFound）时返回 BatchError，包含出错记录的下标
+func (r *{{.ModelName}}Repository) DeleteBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		t := tx.{{.ModelName}}
+		for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+			info, err := t.WithContext(ctx).Where({{range $i, $f := .Key.Fields}}{{if $i}}, {{end}}t.{{$f.FieldName}}.Eq({{$f.Value (ToLowerCamelCase $.ModelName)}}){{end}}).Delete()
+			if err == nil && info.RowsAffected == 0 {
+				err = gorm.ErrRecordNotFound
+			}
+			if err != nil {
+				return BatchError{{"{{"}}Index: i, Err: err{{"}}"}}
+			}
+		}
+		return nil
+	})
 }
+{{- if .SoftDelete}}
 
-// By{{$index}}List 根据 {{$index}} 查询 {{$.ModelName}} 列表
-func (r *{{$.ModelName}}Repository) By{{$index}}List(ctx context.Context, {{$index}} interface{}) ([]*model.{{$.ModelName}}, error) {
-	return r.q.{{$.ModelName}}.WithContext(ctx).
-		Where(r.q.{{$.ModelName}}.{{$index}}.Eq({{$index}})).
-		Find()
+// Restore 根据主键恢复已软删除的 {{.ModelName}}
+//
+// 返回：
+//   error - 记录不存在或未被删除时返回 gorm.ErrRecordNotFound
+func (r *{{.ModelName}}Repository) Restore(ctx context.Context, {{.Key.Params}}) error {
+	info, err := r.q.{{.ModelName}}.WithContext(ctx).Unscoped().
+		Where({{template "keyWhere" .}}, r.q.{{.ModelName}}.DeletedAt.IsNotNull()).
+		Update(r.q.{{.ModelName}}.DeletedAt, nil)
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
+
+// ListTrash 分页获取已软删除的 {{.ModelName}}（回收站），按删除时间倒序
+func (r *{{.ModelName}}Repository) ListTrash(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error) {
+	return r.q.{{.ModelName}}.WithContext(ctx).Unscoped().
+		Where(r.q.{{.ModelName}}.DeletedAt.IsNotNull()).
+		Order(r.q.{{.ModelName}}.DeletedAt.Desc()).
+		FindByPage((page-1)*pageSize, pageSize)
+}
+
+// HardDelete 根据主键永久删除 {{.ModelName}}（包括已软删除的记录）
+//
+// 返回：
+//   error - 记录不存在时返回 gorm.ErrRecordNotFound
+func (r *{{.ModelName}}Repository) HardDelete(ctx context.Context, {{.Key.Params}}) error {
+	info, err := r.q.{{.ModelName}}.WithContext(ctx).Unscoped().Where({{template "keyWhere" .}}).Delete()
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+{{- end}}
+
+{{range .Indexes}}
+{{- if .Unique}}
+// {{.Method}} 根据唯一索引查询 {{$.ModelName}}，不存在时返回 gorm.ErrRecordNotFound
+//
+// 使用索引：{{.Index}}（{{.Columns}}）
+func (r *{{$.ModelName}}Repository) {{.Method}}(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$.ModelName}}, error) {
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, err
+	}
+
+	return do.Where({{range $i, $f := .Fields}}{{if $i}}, {{end}}r.q.{{$.ModelName}}.{{$f.FieldName}}.Eq({{$f.Var}}){{end}}).First()
+}
+{{- if .Unscoped}}
+
+// {{.Method}}Unscoped 根据唯一索引查询 {{$.ModelName}}，包括已软删除的记录，不存在时返回 gorm.ErrRecordNotFound
+//
+// 唯一索引不含 deleted_at，已软删除的记录仍占用唯一值，Service 据此检查唯一约束冲突
+func (r *{{$.ModelName}}Repository) {{.Method}}Unscoped(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$.ModelName}}, error) {
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, err
+	}
+
+	return do.Unscoped().Where({{range $i, $f := .Fields}}{{if $i}}, {{end}}r.q.{{$.ModelName}}.{{$f.FieldName}}.Eq({{$f.Var}}){{end}}).First()
+}
+{{- end}}
+{{- else}}
+// {{.Method}} 根据索引分页查询 {{$.ModelName}} 列表
+//
+// 使用索引：{{.Index}}（{{.Columns}}）
+func (r *{{$.ModelName}}Repository) {{.Method}}(ctx context.Context, {{.Params}}, page, pageSize int, preloads ...string) ([]*model.{{$.ModelName}}, int64, error) {
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return do.Where({{range $i, $f := .Fields}}{{if $i}}, {{end}}r.q.{{$.ModelName}}.{{$f.FieldName}}.Eq({{$f.Var}}){{end}}).
+		FindByPage((page-1)*pageSize, pageSize)
+}
+{{- end}}
 {{end}}
+{{- range .Finders}}
+// ListBy{{.FieldName}} 根据外键 {{.Column}} 分页查询 {{$.ModelName}} 列表
+func (r *{{$.ModelName}}Repository) ListBy{{.FieldName}}(ctx context.Context, {{ToLowerCamelCase .FieldName}} {{.GoType}}, page, pageSize int, preloads ...string) ([]*model.{{$.ModelName}}, int64, error) {
+	do, err := r.withPreloads(ctx, preloads)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return do.Where(r.q.{{$.ModelName}}.{{.FieldName}}.Eq({{ToLowerCamelCase .FieldName}})).
+		FindByPage((page-1)*pageSize, pageSize)
+}
 {{end}}
+// applyListQuery 把过滤、排序与字段选择编译为 GORM Gen 查询条件，只允许生成时白名单中的列和运算符
+func (r *{{.ModelName}}Repository) applyListQuery(do query.I{{.ModelName}}Do, q ListQuery) (query.I{{.ModelName}}Do, error) {
+	t := r.q.{{.ModelName}}
+{{- if .Filterable}}
+
+	for _, f := range q.Filters {
+		var cond field.Expr
+		var err error
+		switch f.Column {
+		{{- range .ListColumns}}{{if .Filterable}}
+		case "{{.Column}}":
+			{{- if eq .Kind "bool"}}
+			cond, err = compareBool(t.{{.FieldName}}, f, {{.OpsList}})
+			{{- else}}
+			cond, err = compare(t.{{.FieldName}}, f, {{.OpsList}}, {{.Parser}})
+			{{- end}}
+		{{- end}}{{end}}
+		default:
+			return nil, fmt.Errorf("%w: 不支持按 %s 过滤", ErrInvalidQuery, f.Column)
+		}
+		if err != nil {
+			return nil, err
+		}
+		do = do.Where(cond)
+	}
+{{- else}}
+
+	if len(q.Filters) > 0 {
+		return nil, fmt.Errorf("%w: {{.ModelName}} 不支持过滤", ErrInvalidQuery)
+	}
+{{- end}}
+{{- if .Sortable}}
+
+	for _, s := range q.Sorts {
+		switch s.Column {
+		case {{.Sortable}}:
+		default:
+			return nil, fmt.Errorf("%w: 不支持按 %s 排序", ErrInvalidQuery, s.Column)
+		}
+		col, _ := t.GetFieldByName(s.Column)
+		if s.Desc {
+			do = do.Order(col.Desc())
+		} else {
+			do = do.Order(col)
+		}
+	}
+{{- else}}
+
+	if len(q.Sorts) > 0 {
+		return nil, fmt.Errorf("%w: {{.ModelName}} 不支持排序", ErrInvalidQuery)
+	}
+{{- end}}
+
+	if len(q.Fields) > 0 {
+		// 主键与外键列始终查询，保证返回的记录可定位且预加载可用
+		selected := map[string]bool{ {{- range $i, $f := .SelectAlways}}{{if $i}}, {{end}}"{{$f.Column}}": true{{end -}} }
+		columns := []field.Expr{ {{- range $i, $f := .SelectAlways}}{{if $i}}, {{end}}t.{{$f.FieldName}}{{end -}} }
+		for _, name := range q.Fields {
+			col, ok := t.GetFieldByName(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: 未知的列 %s", ErrInvalidQuery, name)
+			}
+			if !selected[name] {
+				selected[name] = true
+				columns = append(columns, col)
+			}
+		}
+		do = do.Select(columns...)
+	}
+
+	return do, nil
+}
+
+// {{.ModelName}}JSONKeys fields 参数（列名）对应的 JSON 字段名，用于裁剪 List 响应；
+// 始终保留主键，并保留已预加载的关联
+func {{.ModelName}}JSONKeys(fields, preloads []string) []string {
+	keys := []string{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{ToLowerCamelCase $.ModelName}}JSONNames["{{$f.Column}}"]{{end -}} }
+	for _, names := range [][]string{fields, preloads} {
+		for _, name := range names {
+			if key, ok := {{ToLowerCamelCase .ModelName}}JSONNames[name]; ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+{{if .Version -}}
+// updateVersioned 按 {{.Version.Column}} 乐观锁更新：附加 WHERE {{.Version.Column}} = 期望版本，并把 {{.Version.Column}} 加 1 一起写入；
+// 没有记录被更新时返回 ErrVersionConflict，失败时恢复 {{ToLowerCamelCase .ModelName}} 中的版本号
+func (r *{{.ModelName}}Repository) updateVersioned(do query.I{{.ModelName}}Do, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error {
+	version := {{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}
+	{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}++
+
+	info, err := do.Where(r.q.{{.ModelName}}.{{.Version.FieldName}}.Eq(version)).Updates({{ToLowerCamelCase .ModelName}})
+	if err == nil && info.RowsAffected == 0 {
+		err = ErrVersionConflict
+	}
+	if err != nil {
+		{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}} = version
+		return err
+	}
+	return nil
+}
+
+// Parse{{.ModelName}}ETag 解析 If-Match 请求头得到期望的 {{.Version.Column}}，为空或 * 时返回 0（不校验版本），
+// 格式错误时返回 ErrInvalidETag
+func Parse{{.ModelName}}ETag(etag string) ({{.Version.GoType}}, error) {
+	return parseETag(etag, {{.Version.Parser}})
+}
+
+{{end -}}
+// {{.ModelName}}PatchColumns 把 PATCH 请求的 JSON 字段名转换为列名，未知字段或只读字段（主键、
+// created_at/updated_at{{if .Version}}、{{.Version.Column}}{{end}} 及 gen.yaml 中的 readonly 列）返回 ErrInvalidPatch
+func {{.ModelName}}PatchColumns(fields []string) ([]string, error) {
+	columns := make([]string, len(fields))
+	for i, name := range fields {
+		column, ok := {{ToLowerCamelCase .ModelName}}PatchNames[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s 不存在或为只读字段", ErrInvalidPatch, name)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+// {{ToLowerCamelCase .ModelName}}PatchNames 可部分更新的 JSON 字段名对应的列名
+var {{ToLowerCamelCase .ModelName}}PatchNames = map[string]string{
+{{- range .PatchColumns}}
+	"{{.JSON}}": "{{.Column}}",
+{{- end}}
+}
+
+// {{ToLowerCamelCase .ModelName}}JSONNames 列名与可预加载关联对应的 JSON 字段名
+var {{ToLowerCamelCase .ModelName}}JSONNames = map[string]string{
+{{- range .ListColumns}}
+	"{{.Column}}": "{{.JSON}}",
+{{- end}}
+{{- range .Relations}}
+	{{$.ModelName}}Preload{{.FieldName}}: "{{.JSONName}}",
+{{- end}}
+}
+
+// withPreloads 创建查询并预加载指定的关联
+func (r *{{.ModelName}}Repository) withPreloads(ctx context.Context, preloads []string) (query.I{{.ModelName}}Do, error) {
+	do := r.q.{{.ModelName}}.WithContext(ctx)
+	for _, name := range preloads {
+		switch name {
+		{{- if .Relations}}
+		case {{range $i, $rel := .Relations}}{{if $i}}, {{end}}{{$.ModelName}}Preload{{$rel.FieldName}}{{end}}:
+			do = do.Preload(field.NewRelation(name, ""))
+		{{- end}}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPreload, name)
+		}
+	}
+	return do, nil
+}
+{{- define "keyWhere"}}{{range $i, $f := .Key.Fields}}{{if $i}}, {{end}}r.q.{{$.ModelName}}.{{$f.FieldName}}.Eq({{$f.Var}}){{end}}{{end}}
 `
 
 	// 创建模板并添加辅助函数
@@ -339,11 +885,23 @@ func (r *{{$.ModelName}}Repository) By{{$index}}List(ctx context.Context, {{$ind
 
 	// 执行模板
 	data := map[string]interface{}{
-		"TableName":   config.TableName,
-		"ModelName":   config.ModelName,
-		"PackageName": config.PackageName,
-		"ModulePath":  config.ModulePath,
-		"Indexes":     config.Indexes,
+		"TableName":    config.TableName,
+		"ModelName":    config.ModelName,
+		"PackageName":  config.PackageName,
+		"ModulePath":   config.ModulePath,
+		"Indexes":      config.Indexes,
+		"Key":          config.Key,
+		"SoftDelete":   config.SoftDelete,
+		"ListColumns":  config.ListColumns,
+		"Cursor":       config.Cursor,
+		"PatchColumns": config.PatchColumns,
+		"Version":      config.Version,
+		"Filterable":   filterableColumns(config.ListColumns),
+		"Sortable":     sortableColumns(config.ListColumns),
+		"SelectAlways": listSelectAlways(config),
+		"Relations":    config.Relations,
+		"Finders":      config.Finders,
+		"Imports":      config.Imports,
 	}
 
 	if err := t.Execute(f, data); err != nil {
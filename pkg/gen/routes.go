@@ -39,6 +39,9 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	{{- if .OpenAPI}}
+	"{{.ModulePath}}/docs"
+	{{- end}}
 	"{{.ModulePath}}/internal/application"
 	"{{.ModulePath}}/internal/controller"
 )
@@ -54,6 +57,11 @@ func RegisterRoutes(r *gin.Engine) {
 			"status": "ok",
 		})
 	})
+	{{- if .OpenAPI}}
+
+	// 接口文档：/swagger/index.html
+	docs.Register(r)
+	{{- end}}
 
 	// API v1 路由组
 	v1 := r.Group("/api/v1")
@@ -74,9 +82,20 @@ func register{{.Name}}Routes(router gin.IRouter) {
 	{
 		group.POST("", ctrl.Create)
 		group.GET("", ctrl.List)
-		group.GET("/:id", ctrl.GetByID)
-		group.PUT("/:id", ctrl.Update)
-		group.DELETE("/:id", ctrl.Delete)
+		group.GET("{{.KeyRoute}}", ctrl.GetByID)
+		group.PUT("{{.KeyRoute}}", ctrl.Update)
+		group.PATCH("{{.KeyRoute}}", ctrl.Patch)
+		group.DELETE("{{.KeyRoute}}", ctrl.Delete)
+		group.POST("/batch", ctrl.CreateBatch)
+		group.PATCH("/batch", ctrl.UpdateBatch)
+		group.DELETE("/batch", ctrl.DeleteBatch)
+		{{- if .SoftDelete}}
+		group.GET("/trash", ctrl.ListTrash)
+		group.POST("{{.KeyRoute}}/restore", ctrl.Restore)
+		{{- end}}
+		{{- range .Nested}}
+		group.GET("{{.Path}}", controller.New{{.ModelName}}Controller(application.{{ToLowerCamelCase .ModelName}}Service).{{.Handler}})
+		{{- end}}
 	}
 }
 {{end}}
@@ -100,12 +119,18 @@ func register{{.Name}}Routes(router gin.IRouter) {
 
 	// 准备数据
 	type TableName struct {
-		Name string
+		Name       string
+		KeyRoute   string        // 主键路由参数，如 /:id 或 /:user_id/:role_id
+		SoftDelete bool          // 是否注册回收站和恢复路由
+		Nested     []NestedRoute // 子资源路由，如 GET /articles/:id/comments
 	}
 	var tableNames []TableName
 	for _, table := range tables {
 		tableNames = append(tableNames, TableName{
-			Name: toModelName(table.Name),
+			Name:       g.options(table.Name).ModelName,
+			KeyRoute:   g.primaryKey(table.Name).Route(),
+			SoftDelete: g.softDelete(table.Name),
+			Nested:     g.relationsOf(table.Name).Nested,
 		})
 	}
 
@@ -113,6 +138,7 @@ func register{{.Name}}Routes(router gin.IRouter) {
 	data := map[string]interface{}{
 		"ModulePath": modulePath,
 		"TableNames": tableNames,
+		"OpenAPI":    g.openAPI(),
 	}
 
 	if err := t.Execute(f, data); err != nil {
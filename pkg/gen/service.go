@@ -10,16 +10,23 @@ import (
 
 // ServiceConfig Service 配置
 type ServiceConfig struct {
-	TableName   string // 表名
-	ModelName   string // 模型名称
-	PackageName string // 包名
-	ModulePath  string // 模块路径
-	WithCache   bool   // 是否启用缓存
+	TableName   string             // 表名
+	ModelName   string             // 模型名称
+	PackageName string             // 包名
+	ModulePath  string             // 模块路径
+	WithCache   bool               // 是否启用缓存
+	Key         PrimaryKey         // 主键
+	SoftDelete  bool               // 是否使用软删除，生成 Restore/ListTrash/HardDelete
+	Cursor      *ListColumn        // 游标分页使用的列，非 nil 时生成 ListAfter
+	Version     *ListColumn        // 乐观锁版本列，非 nil 时 Update/Patch 校验版本号
+	Uniques     []IndexFinder      // 唯一索引，Create/Update 前检查冲突
+	Finders     []ForeignKeyFinder // 外键查询方法
 }
 
 // GenerateService 生成 Service 层代码
 func (g *DatabaseGenerator) GenerateService(table TableInfo, config ServiceConfig) error {
 	fmt.Printf("  📦 生成 %s Service...\n", config.ModelName)
+	config.Key = config.Key.orDefault()
 
 	// 创建输出目录
 	outputDir := filepath.Join(g.config.Output, "internal/service")
@@ -27,6 +34,11 @@ func (g *DatabaseGenerator) GenerateService(table TableInfo, config ServiceConfi
 		return fmt.Errorf("创建目录失败: %w", err)
 	}
 
+	// 生成公共错误定义（errors.go）
+	if err := os.WriteFile(filepath.Join(outputDir, "errors.go"), []byte(serviceErrorsTmpl), 0644); err != nil {
+		return fmt.Errorf("创建服务错误定义文件失败: %w", err)
+	}
+
 	// 生成 Service 文件
 	outputPath := filepath.Join(outputDir, strings.ToLower(config.ModelName)+".go")
 
@@ -35,6 +47,11 @@ func (g *DatabaseGenerator) GenerateService(table TableInfo, config ServiceConfi
 		return err
 	}
 
+	// 生成基于 Mock 仓储的单元测试
+	if err := g.GenerateServiceTest(config); err != nil {
+		return err
+	}
+
 	fmt.Printf("     ✓ %sService 创建成功\n", config.ModelName)
 	return nil
 }
@@ -49,9 +66,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
 
 	"gorm.io/gorm"
 	"{{.ModulePath}}/internal/dal/model"
@@ -109,7 +129,7 @@ func New{{.ModelName}}Service(
 //
 // 业务逻辑：
 //   1. 参数校验
-//   2. 检查唯一约束（如：email、username）
+//   2. 检查唯一约束（按表的唯一索引，冲突时返回 Err{{.ModelName}}AlreadyExists）
 //   3. 写入数据库
 //   4. 清除相关缓存
 //
@@ -123,12 +143,16 @@ func (s *{{.ModelName}}Service) Create(ctx context.Context, {{ToLowerCamelCase .
 
 	// TODO: 添加业务校验
 	// 例如：检查邮箱格式、用户名长度等
+{{- if .Version}}
+
+	// 新记录的版本号从 1 开始（0 表示请求未指定版本）
+	{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}} = 1
+{{- end}}
 
 	// 2. 检查唯一约束
-	// TODO: 根据表的唯一索引添加检查
-	// if exists, _ := s.repo.GetByEmail(ctx, {{ToLowerCamelCase .ModelName}}.Email); exists != nil {
-	// 	return Err{{.ModelName}}AlreadyExists
-	// }
+	if err := s.checkUnique(ctx, {{ToLowerCamelCase .ModelName}}, false); err != nil {
+		return err
+	}
 
 	// 3. 写入数据库
 	if err := s.repo.Create(ctx, {{ToLowerCamelCase .ModelName}}); err != nil {
@@ -137,36 +161,39 @@ func (s *{{.ModelName}}Service) Create(ctx context.Context, {{ToLowerCamelCase .
 
 	// 4. 清除相关缓存（如有）
 	{{if .WithCache}}
-	_ = s.deleteCache(ctx, {{ToLowerCamelCase .ModelName}}.ID)
+	_ = s.deleteCache(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
 	{{end}}
 
 	return nil
 }
 
-// GetByID 根据 ID 获取 {{.ModelName}}
+// GetByID 根据主键获取 {{.ModelName}}
 //
 // 缓存策略：
 //   1. 先从缓存查询
 //   2. 缓存命中则直接返回
 //   3. 缓存未命中则查询数据库
 //   4. 查询成功后写入缓存，过期时间 10 分钟
+//   5. 预加载关联（preloads 不为空）时不读写缓存
 //
 // 返回：
 //   *model.{{.ModelName}} - {{.ModelName}}数据
 //   error - 查询失败时返回错误
-func (s *{{.ModelName}}Service) GetByID(ctx context.Context, id uint) (*model.{{.ModelName}}, error) {
+func (s *{{.ModelName}}Service) GetByID(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{.ModelName}}, error) {
 	{{if .WithCache}}
 	// 1. 尝试从缓存获取
-	cacheKey := s.getCacheKey(id)
-	if cached, err := s.cache.Get(ctx, cacheKey); err == nil && cached != nil {
-		if {{ToLowerCamelCase .ModelName}}, ok := cached.(*model.{{.ModelName}}); ok {
-			return {{ToLowerCamelCase .ModelName}}, nil
+	cacheKey := s.getCacheKey({{.Key.Args}})
+	if len(preloads) == 0 {
+		if cached, err := s.cache.Get(ctx, cacheKey); err == nil && cached != nil {
+			if {{ToLowerCamelCase .ModelName}}, ok := cached.(*model.{{.ModelName}}); ok {
+				return {{ToLowerCamelCase .ModelName}}, nil
+			}
 		}
 	}
 	{{end}}
 
 	// 2. 从数据库查询
-	{{ToLowerCamelCase .ModelName}}, err := s.repo.GetByID(ctx, id)
+	{{ToLowerCamelCase .ModelName}}, err := s.repo.GetByID(ctx, {{.Key.Args}}, preloads...)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, Err{{.ModelName}}NotFound
@@ -176,7 +203,9 @@ func (s *{{.ModelName}}Service) GetByID(ctx context.Context, id uint) (*model.{{
 
 	{{if .WithCache}}
 	// 3. 写入缓存
-	_ = s.cache.Set(ctx, cacheKey, {{ToLowerCamelCase .ModelName}}, 10*time.Minute)
+	if len(preloads) == 0 {
+		_ = s.cache.Set(ctx, cacheKey, {{ToLowerCamelCase .ModelName}}, 10*time.Minute)
+	}
 	{{end}}
 
 	return {{ToLowerCamelCase .ModelName}}, nil
@@ -186,55 +215,150 @@ func (s *{{.ModelName}}Service) GetByID(ctx context.Context, id uint) (*model.{{
 //
 // 业务逻辑：
 //   1. 检查记录是否存在
-//   2. 执行更新
-//   3. 清除相关缓存
+{{- if .Version}}
+//   2. 校验 {{.Version.Column}}（为 0 时以当前版本为准）
+//   3. 检查唯一约束（排除自身）
+//   4. 按版本号执行更新，成功后 {{.Version.Column}} 加 1
+//   5. 清除相关缓存
+{{- else}}
+//   2. 检查唯一约束（排除自身）
+//   3. 执行更新
+//   4. 清除相关缓存
+{{- end}}
 //
 // 返回：
-//   error - 更新失败时返回错误
+//   error - 更新失败时返回错误，唯一约束冲突时返回 Err{{.ModelName}}AlreadyExists{{if .Version}}，
+//           版本不一致或被并发修改时返回 ErrConcurrentModification{{end}}
 func (s *{{.ModelName}}Service) Update(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) error {
 	// 1. 参数校验
-	if {{ToLowerCamelCase .ModelName}} == nil || {{ToLowerCamelCase .ModelName}}.ID == 0 {
+	if {{ToLowerCamelCase .ModelName}} == nil{{range .Key.Fields}}{{if .Zero}} || {{ToLowerCamelCase $.ModelName}}.{{.FieldName}} == {{.Zero}}{{end}}{{end}} {
 		return fmt.Errorf("无效的{{.ModelName}}数据")
 	}
 
 	// 2. 检查是否存在
-	exists, err := s.repo.GetByID(ctx, {{ToLowerCamelCase .ModelName}}.ID)
+	{{if .Version}}existing{{else}}_{{end}}, err := s.repo.GetByID(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return Err{{.ModelName}}NotFound
 		}
 		return fmt.Errorf("查询{{.ModelName}}失败: %w", err)
 	}
+{{- if .Version}}
+
+	// 校验版本号
+	if err := checkVersion(&{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}, existing.{{.Version.FieldName}}); err != nil {
+		return err
+	}
+{{- end}}
+
+	// 3. 检查唯一约束（排除自身）
+	if err := s.checkUnique(ctx, {{ToLowerCamelCase .ModelName}}, true); err != nil {
+		return err
+	}
 
 	// TODO: 添加业务校验
-	// 例如：检查唯一约束是否冲突
 
-	// 3. 执行更新
+	// 4. 执行更新
 	if err := s.repo.Update(ctx, {{ToLowerCamelCase .ModelName}}); err != nil {
+{{- if .Version}}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return fmt.Errorf("%w: {{.ModelName}}", ErrConcurrentModification)
+		}
+{{- end}}
 		return fmt.Errorf("更新{{.ModelName}}失败: %w", err)
 	}
 
-	// 4. 清除缓存
+	// 5. 清除缓存
 	{{if .WithCache}}
-	_ = s.deleteCache(ctx, exists.ID)
-	_ = s.deleteCache(ctx, {{ToLowerCamelCase .ModelName}}.ID)
+	_ = s.deleteCache(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
 	{{end}}
 
 	return nil
 }
 
+// Patch 部分更新 {{.ModelName}}，只修改 fields 中的字段，其余字段保持不变
+//
+// 业务逻辑：
+//   1. 校验字段（未知字段与主键、created_at 等只读字段返回 repository.ErrInvalidPatch）
+//   2. 检查记录是否存在
+//   3. 把请求中的字段合并到现有记录，检查唯一约束（排除自身）
+//   4. 只更新这些字段（零值也会写入）
+//   5. 清除相关缓存
+//
+// 参数：
+{{- if .Version}}
+//   version - 期望的 {{.Version.Column}}（通常来自 If-Match），为 0 时以当前版本为准
+{{- end}}
+//   body - 请求体（JSON 字段名到值）
+//   fields - 要更新的 JSON 字段名（repository.PatchFields 解析）
+//
+// 返回：
+//   *model.{{.ModelName}} - 更新后的{{.ModelName}}
+//   error - 记录不存在时返回 Err{{.ModelName}}NotFound，唯一约束冲突时返回 Err{{.ModelName}}AlreadyExists{{if .Version}}，
+//           版本不一致或被并发修改时返回 ErrConcurrentModification{{end}}
+func (s *{{.ModelName}}Service) Patch(ctx context.Context, {{.Key.Params}}, {{if .Version}}version {{.Version.GoType}}, {{end}}body map[string]json.RawMessage, fields []string) (*model.{{.ModelName}}, error) {
+	// 1. 校验字段
+	columns, err := repository.{{.ModelName}}PatchColumns(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 检查是否存在
+	{{ToLowerCamelCase .ModelName}}, err := s.repo.GetByID(ctx, {{.Key.Args}})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, Err{{.ModelName}}NotFound
+		}
+		return nil, fmt.Errorf("查询{{.ModelName}}失败: %w", err)
+	}
+{{- if .Version}}
+	if err := checkVersion(&version, {{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}); err != nil {
+		return nil, err
+	}
+{{- end}}
+
+	// 3. 合并字段并检查唯一约束（排除自身）
+	if err := repository.MergePatch({{ToLowerCamelCase .ModelName}}, body, fields); err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, {{ToLowerCamelCase .ModelName}}, true); err != nil {
+		return nil, err
+	}
+
+	// 4. 执行更新
+	if err := s.repo.Patch(ctx, {{ToLowerCamelCase .ModelName}}, columns); err != nil {
+{{- if .Version}}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, fmt.Errorf("%w: {{.ModelName}}", ErrConcurrentModification)
+		}
+{{- end}}
+		return nil, fmt.Errorf("更新{{.ModelName}}失败: %w", err)
+	}
+
+	// 5. 清除缓存
+	{{if .WithCache}}
+	_ = s.deleteCache(ctx, {{.Key.Args}})
+	{{end}}
+
+	return {{ToLowerCamelCase .ModelName}}, nil
+}
+
 // Delete 删除 {{.ModelName}}
 //
 // 业务逻辑：
 //   1. 检查记录是否存在
-//   2. 执行软删除或硬删除
+{{- if .SoftDelete}}
+//   2. 执行软删除（记录进入回收站，可通过 Restore 恢复；永久删除使用 HardDelete）
+{{- else}}
+//   2. 执行删除（表没有 deleted_at 列，删除后不可恢复）
+{{- end}}
 //   3. 清除相关缓存
 //
 // 返回：
 //   error - 删除失败时返回错误
-func (s *{{.ModelName}}Service) Delete(ctx context.Context, id uint) error {
+func (s *{{.ModelName}}Service) Delete(ctx context.Context, {{.Key.Params}}) error {
 	// 1. 检查是否存在
-	_, err := s.repo.GetByID(ctx, id)
+	_, err := s.repo.GetByID(ctx, {{.Key.Args}})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return Err{{.ModelName}}NotFound
@@ -243,18 +367,75 @@ func (s *{{.ModelName}}Service) Delete(ctx context.Context, id uint) error {
 	}
 
 	// 2. 执行删除
-	if err := s.repo.Delete(ctx, id); err != nil {
+	if err := s.repo.Delete(ctx, {{.Key.Args}}); err != nil {
 		return fmt.Errorf("删除{{.ModelName}}失败: %w", err)
 	}
 
 	// 3. 清除缓存
 	{{if .WithCache}}
-	_ = s.deleteCache(ctx, id)
+	_ = s.deleteCache(ctx, {{.Key.Args}})
+	{{end}}
+
+	return nil
+}
+{{- if .SoftDelete}}
+
+// Restore 恢复已软删除的 {{.ModelName}}
+//
+// 返回：
+//   error - 记录不存在或未被删除时返回 Err{{.ModelName}}NotFound
+func (s *{{.ModelName}}Service) Restore(ctx context.Context, {{.Key.Params}}) error {
+	if err := s.repo.Restore(ctx, {{.Key.Args}}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Err{{.ModelName}}NotFound
+		}
+		return fmt.Errorf("恢复{{.ModelName}}失败: %w", err)
+	}
+
+	{{if .WithCache}}
+	_ = s.deleteCache(ctx, {{.Key.Args}})
 	{{end}}
 
 	return nil
 }
 
+// ListTrash 获取回收站中的 {{.ModelName}} 列表（分页，按删除时间倒序）
+func (s *{{.ModelName}}Service) ListTrash(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	{{ToLowerCamelCase .ModelName}}s, total, err := s.repo.ListTrash(ctx, page, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("查询{{.ModelName}}回收站失败: %w", err)
+	}
+
+	return {{ToLowerCamelCase .ModelName}}s, total, nil
+}
+
+// HardDelete 永久删除 {{.ModelName}}（包括回收站中的记录），删除后不可恢复
+//
+// 返回：
+//   error - 记录不存在时返回 Err{{.ModelName}}NotFound
+func (s *{{.ModelName}}Service) HardDelete(ctx context.Context, {{.Key.Params}}) error {
+	if err := s.repo.HardDelete(ctx, {{.Key.Args}}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Err{{.ModelName}}NotFound
+		}
+		return fmt.Errorf("永久删除{{.ModelName}}失败: %w", err)
+	}
+
+	{{if .WithCache}}
+	_ = s.deleteCache(ctx, {{.Key.Args}})
+	{{end}}
+
+	return nil
+}
+{{- end}}
+
 // List 获取 {{.ModelName}} 列表（分页）
 //
 // 业务逻辑：
@@ -264,14 +445,16 @@ func (s *{{.ModelName}}Service) Delete(ctx context.Context, id uint) error {
 //
 // 参数：
 //   ctx - 请求上下文
+//   q - 过滤、排序与字段选择条件（repository.ParseListQuery 解析）
 //   page - 页码，从 1 开始
 //   pageSize - 每页数量
+//   preloads - 要预加载的关联
 //
 // 返回：
 //   []*model.{{.ModelName}} - {{.ModelName}}列表
 //   int64 - 总数量
-//   error - 查询失败时返回错误
-func (s *{{.ModelName}}Service) List(ctx context.Context, page, pageSize int) ([]*model.{{.ModelName}}, int64, error) {
+//   error - 查询失败时返回错误，查询条件不合法时包装 repository.ErrInvalidQuery
+func (s *{{.ModelName}}Service) List(ctx context.Context, q repository.ListQuery, page, pageSize int, preloads ...string) ([]*model.{{.ModelName}}, int64, error) {
 	// 1. 参数校验
 	if page <= 0 {
 		page = 1
@@ -281,30 +464,260 @@ func (s *{{.ModelName}}Service) List(ctx context.Context, page, pageSize int) ([
 	}
 
 	// 2. 查询数据库
-	{{ToLowerCamelCase .ModelName}}s, total, err := s.repo.List(ctx, page, pageSize)
+	{{ToLowerCamelCase .ModelName}}s, total, err := s.repo.List(ctx, q, page, pageSize, preloads...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("查询{{.ModelName}}列表失败: %w", err)
 	}
 
 	return {{ToLowerCamelCase .ModelName}}s, total, nil
 }
+{{- if .Cursor}}
+
+// ListAfter 按 {{.Cursor.Column}} 游标分页获取 {{.ModelName}} 列表，不统计总数
+//
+// 参数：
+//   cursor - 上一页返回的游标，为空时从第一条开始
+//   pageSize - 每页数量
+//
+// 返回：
+//   string - 下一页的游标，没有更多数据时为空
+//   int - 实际使用的每页数量（pageSize 不合法时为默认值）
+//   error - 查询失败时返回错误，游标或查询条件不合法时包装 repository.ErrInvalidQuery
+func (s *{{.ModelName}}Service) ListAfter(ctx context.Context, q repository.ListQuery, cursor string, pageSize int, preloads ...string) ([]*model.{{.ModelName}}, string, int, error) {
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	{{ToLowerCamelCase .ModelName}}s, next, err := s.repo.ListAfter(ctx, q, cursor, pageSize, preloads...)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("查询{{.ModelName}}列表失败: %w", err)
+	}
+
+	return {{ToLowerCamelCase .ModelName}}s, next, pageSize, nil
+}
+{{- end}}
+{{range .Finders}}
+// ListBy{{.FieldName}} 根据 {{.Column}} 分页获取 {{$.ModelName}} 列表
+func (s *{{$.ModelName}}Service) ListBy{{.FieldName}}(ctx context.Context, {{ToLowerCamelCase .FieldName}} {{.GoType}}, page, pageSize int, preloads ...string) ([]*model.{{$.ModelName}}, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	{{ToLowerCamelCase $.ModelName}}s, total, err := s.repo.ListBy{{.FieldName}}(ctx, {{ToLowerCamelCase .FieldName}}, page, pageSize, preloads...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("查询{{$.ModelName}}列表失败: %w", err)
+	}
 
+	return {{ToLowerCamelCase $.ModelName}}s, total, nil
+}
+{{end}}
 // Count 统计 {{.ModelName}} 总数
 func (s *{{.ModelName}}Service) Count(ctx context.Context) (int64, error) {
 	return s.repo.Count(ctx)
 }
 
+// CreateBatch 批量创建 {{.ModelName}}
+//
+// 业务逻辑：
+//   1. 校验记录数（1 ~ repository.MaxBatchSize）
+//   2. 逐条校验并检查唯一约束，收集每条记录的错误
+//   3. 全部通过后在一个事务中分批写入
+//   4. 清除相关缓存
+//
+// 返回：
+//   error - 有记录校验失败时返回 repository.BatchError，此时不写入任何记录
+func (s *{{.ModelName}}Service) CreateBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error {
+	// 1. 校验记录数
+	if err := repository.CheckBatchSize(len({{ToLowerCamelCase .ModelName}}s)); err != nil {
+		return err
+	}
+
+	// 2. 逐条校验
+	var batchErr repository.BatchError
+	for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		if {{ToLowerCamelCase .ModelName}} == nil {
+			batchErr.Add(i, repository.ErrInvalidItem)
+			continue
+		}
+{{- if .Version}}
+		{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}} = 1
+{{- end}}
+		if err := s.checkUnique(ctx, {{ToLowerCamelCase .ModelName}}, false); err != nil {
+			batchErr.Add(i, err)
+		}
+	}
+	if len(batchErr) > 0 {
+		return batchErr
+	}
+
+	// 3. 写入数据库
+	if err := s.repo.CreateBatch(ctx, {{ToLowerCamelCase .ModelName}}s); err != nil {
+		return fmt.Errorf("批量创建{{.ModelName}}失败: %w", err)
+	}
+
+	// 4. 清除相关缓存（如有）
+	{{if .WithCache}}
+	for _, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		_ = s.deleteCache(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
+	}
+	{{end}}
+
+	return nil
+}
+
+// UpdateBatch 批量更新 {{.ModelName}}（只更新非零值字段）
+//
+// 业务逻辑：
+//   1. 校验记录数
+//   2. 逐条检查主键、记录是否存在{{if .Version}}、{{.Version.Column}}{{end}}与唯一约束（排除自身），收集每条记录的错误
+//   3. 全部通过后在一个事务中更新
+//   4. 清除相关缓存
+//
+// 返回：
+//   error - 有记录校验或更新失败时返回 repository.BatchError，此时不更新任何记录
+func (s *{{.ModelName}}Service) UpdateBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error {
+	// 1. 校验记录数
+	if err := repository.CheckBatchSize(len({{ToLowerCamelCase .ModelName}}s)); err != nil {
+		return err
+	}
+
+	// 2. 逐条校验
+	var batchErr repository.BatchError
+	for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		{{if .Version}}existing{{else}}_{{end}}, err := s.checkBatchItem(ctx, {{ToLowerCamelCase .ModelName}})
+		if err != nil {
+			batchErr.Add(i, err)
+			continue
+		}
+{{- if .Version}}
+		if err := checkVersion(&{{ToLowerCamelCase .ModelName}}.{{.Version.FieldName}}, existing.{{.Version.FieldName}}); err != nil {
+			batchErr.Add(i, err)
+			continue
+		}
+{{- end}}
+		if err := s.checkUnique(ctx, {{ToLowerCamelCase .ModelName}}, true); err != nil {
+			batchErr.Add(i, err)
+		}
+	}
+	if len(batchErr) > 0 {
+		return batchErr
+	}
+
+	// 3. 执行更新
+	if err := s.repo.UpdateBatch(ctx, {{ToLowerCamelCase .ModelName}}s); err != nil {
+{{- if .Version}}
+		// 校验之后被并发修改的记录返回版本冲突
+		if errors.As(err, &batchErr) {
+			for _, e := range batchErr {
+				if errors.Is(e.Err, repository.ErrVersionConflict) {
+					e.Err = fmt.Errorf("%w: {{.ModelName}}", ErrConcurrentModification)
+				}
+			}
+			return batchErr
+		}
+{{- end}}
+		return fmt.Errorf("批量更新{{.ModelName}}失败: %w", err)
+	}
+
+	// 4. 清除缓存
+	{{if .WithCache}}
+	for _, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		_ = s.deleteCache(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
+	}
+	{{end}}
+
+	return nil
+}
+
+// DeleteBatch 按主键批量删除 {{.ModelName}}{{if .SoftDelete}}（软删除）{{end}}，只使用每条记录的主键字段
+//
+// 返回：
+//   error - 有记录不存在或删除失败时返回 repository.BatchError，此时不删除任何记录
+func (s *{{.ModelName}}Service) DeleteBatch(ctx context.Context, {{ToLowerCamelCase .ModelName}}s []*model.{{.ModelName}}) error {
+	if err := repository.CheckBatchSize(len({{ToLowerCamelCase .ModelName}}s)); err != nil {
+		return err
+	}
+
+	var batchErr repository.BatchError
+	for i, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		if _, err := s.checkBatchItem(ctx, {{ToLowerCamelCase .ModelName}}); err != nil {
+			batchErr.Add(i, err)
+		}
+	}
+	if len(batchErr) > 0 {
+		return batchErr
+	}
+
+	if err := s.repo.DeleteBatch(ctx, {{ToLowerCamelCase .ModelName}}s); err != nil {
+		// 校验之后被并发删除的记录同样按不存在处理
+		if errors.As(err, &batchErr) {
+			for _, e := range batchErr {
+				if errors.Is(e.Err, gorm.ErrRecordNotFound) {
+					e.Err = Err{{.ModelName}}NotFound
+				}
+			}
+			return batchErr
+		}
+		return fmt.Errorf("批量删除{{.ModelName}}失败: %w", err)
+	}
+
+	{{if .WithCache}}
+	for _, {{ToLowerCamelCase .ModelName}} := range {{ToLowerCamelCase .ModelName}}s {
+		_ = s.deleteCache(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
+	}
+	{{end}}
+
+	return nil
+}
+
+// checkBatchItem 检查批量更新/删除的记录带有主键且记录存在，返回数据库中的现有记录
+func (s *{{.ModelName}}Service) checkBatchItem(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}) (*model.{{.ModelName}}, error) {
+	if {{ToLowerCamelCase .ModelName}} == nil{{range .Key.Fields}}{{if .Zero}} || {{ToLowerCamelCase $.ModelName}}.{{.FieldName}} == {{.Zero}}{{end}}{{end}} {
+		return nil, repository.ErrInvalidItem
+	}
+
+	existing, err := s.repo.GetByID(ctx, {{.Key.ModelArgs (ToLowerCamelCase .ModelName)}})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, Err{{.ModelName}}NotFound
+		}
+		return nil, fmt.Errorf("查询{{.ModelName}}失败: %w", err)
+	}
+	return existing, nil
+}
+
+// checkUnique 按唯一索引检查冲突，冲突时返回包含冲突字段的 Err{{.ModelName}}AlreadyExists
+//
+// updating 为 true 时排除记录自身；零值字段不参与检查{{if .SoftDelete}}；已软删除的记录同样占用唯一值{{end}}
+func (s *{{.ModelName}}Service) checkUnique(ctx context.Context, {{ToLowerCamelCase .ModelName}} *model.{{.ModelName}}, updating bool) error {
+{{- range .Uniques}}
+	{{if .Guard (ToLowerCamelCase $.ModelName)}}if {{.Guard (ToLowerCamelCase $.ModelName)}} {{end}}{
+		exists, err := s.repo.{{.CheckMethod}}(ctx, {{.Args (ToLowerCamelCase $.ModelName)}})
+		if err == nil && (!updating || {{range $i, $f := $.Key.Fields}}{{if $i}} || {{end}}exists.{{$f.FieldName}} != {{ToLowerCamelCase $.ModelName}}.{{$f.FieldName}}{{end}}) {
+			return fmt.Errorf("%w: {{.Columns}}", Err{{$.ModelName}}AlreadyExists)
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("检查{{$.ModelName}}唯一约束失败: %w", err)
+		}
+	}
+{{- end}}
+	return nil
+}
+
 {{if .WithCache}}
 // ============ 缓存辅助方法 ============
 
 // getCacheKey 生成缓存键
-func (s *{{.ModelName}}Service) getCacheKey(id uint) string {
-	return fmt.Sprintf("{{ToLowerCamelCase .ModelName}}:%d", id)
+func (s *{{.ModelName}}Service) getCacheKey({{.Key.Params}}) string {
+	return fmt.Sprintf("{{ToLowerCamelCase .ModelName}}:{{.Key.CacheFormat}}", {{.Key.Args}})
 }
 
 // deleteCache 删除缓存
-func (s *{{.ModelName}}Service) deleteCache(ctx context.Context, id uint) error {
-	cacheKey := s.getCacheKey(id)
+func (s *{{.ModelName}}Service) deleteCache(ctx context.Context, {{.Key.Params}}) error {
+	cacheKey := s.getCacheKey({{.Key.Args}})
 	return s.cache.Delete(ctx, cacheKey)
 }
 
@@ -340,6 +753,13 @@ func (s *{{.ModelName}}Service) deleteListCache(ctx context.Context) error {
 		"PackageName": config.PackageName,
 		"ModulePath":  config.ModulePath,
 		"WithCache":   config.WithCache,
+		"Key":         config.Key,
+		"SoftDelete":  config.SoftDelete,
+		"Cursor":      config.Cursor,
+		"Version":     config.Version,
+		"Uniques":     config.Uniques,
+		"Imports":     serviceImports(config),
+		"Finders":     config.Finders,
 	}
 
 	if err := t.Execute(f, data); err != nil {
@@ -348,3 +768,12 @@ func (s *{{.ModelName}}Service) deleteListCache(ctx context.Context) error {
 
 	return nil
 }
+
+// serviceImports 主键类型需要的导入，启用缓存时额外导入 time（缓存过期时间）
+func serviceImports(config ServiceConfig) []string {
+	imports := config.Key.Imports("time")
+	if config.WithCache || len(config.Key.Imports()) > len(imports) {
+		imports = append([]string{"time"}, imports...)
+	}
+	return imports
+}
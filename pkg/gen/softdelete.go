@@ -0,0 +1,25 @@
+package gen
+
+// hasSoftDelete 表是否使用 GORM 软删除：有未被忽略的 deleted_at 时间列，且模型字段为 gorm.DeletedAt
+// （未覆盖类型时 GORM Gen 会把 time.Time 类型的 deleted_at 转换为 gorm.DeletedAt）
+func hasSoftDelete(table *DetailedTableInfo, opts tableOptions) bool {
+	for _, f := range table.Fields {
+		if f.Name != "deleted_at" || opts.ignored(f.Name) {
+			continue
+		}
+		if override := opts.Columns[f.Name].Type; override != "" {
+			return override == "gorm.DeletedAt"
+		}
+		return opts.Types.BaseType(typeColumn(f)) == "time.Time"
+	}
+	return false
+}
+
+// softDelete 表是否使用软删除，无法获取表结构时返回 false
+func (g *DatabaseGenerator) softDelete(tableName string) bool {
+	schema, err := g.tableSchema(tableName)
+	if err != nil {
+		return false
+	}
+	return hasSoftDelete(schema, g.options(tableName))
+}
@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/internal/buildtest"
+)
+
+// TestSoftDelete 验证 deleted_at 列的软删除识别，以及索引查询方法跳过 deleted_at 列
+func TestSoftDelete(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` bigint unsigned NOT NULL, `email` varchar(100) NOT NULL, `deleted_at` datetime NULL,\n" +
+		"  PRIMARY KEY (`id`), UNIQUE KEY `uk_email_deleted` (`email`, `deleted_at`), KEY `idx_deleted_at` (`deleted_at`));\n" +
+		"CREATE TABLE `logs` (`id` bigint NOT NULL, `deleted_at` varchar(20), PRIMARY KEY (`id`));"
+
+	schema := parseSchema(t, ddl, nil)
+
+	users := schema.Table("users")
+	if !hasSoftDelete(users, (*GenConfig)(nil).table("users")) {
+		t.Errorf("hasSoftDelete(users) = false, want true")
+	}
+
+	if hasSoftDelete(schema.Table("logs"), (*GenConfig)(nil).table("logs")) {
+		t.Errorf("hasSoftDelete(logs) = true, want false (deleted_at 不是时间类型)")
+	}
+
+	var got []string
+	for _, f := range buildIndexFinders(users, (*GenConfig)(nil).table("users"), nil) {
+		got = append(got, f.Method())
+	}
+	if want := []string{"ListByEmail"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("buildIndexFinders() = %v, want %v", got, want)
+	}
+}
+
+// TestSoftDeleteGenerated 验证只有软删除表生成恢复、回收站与永久删除（DELETE ?hard=true）方法，
+// 唯一约束检查包括已软删除的记录，且生成的代码能编译、生成的 Service 测试通过
+func TestSoftDeleteGenerated(t *testing.T) {
+	ddl := "CREATE TABLE `users` (`id` bigint unsigned NOT NULL, `email` varchar(100) NOT NULL, `deleted_at` datetime NULL,\n" +
+		"  PRIMARY KEY (`id`), UNIQUE KEY `uk_email` (`email`));\n" +
+		"CREATE TABLE `logs` (`id` bigint NOT NULL, `message` varchar(100) NOT NULL, PRIMARY KEY (`id`), UNIQUE KEY `uk_message` (`message`));"
+	dir := generateSQL(t, ddl, nil)
+
+	decls := funcDecls(t, dir, "internal/repository/users.go", "internal/service/users.go", "internal/controller/users.go",
+		"internal/repository/logs.go", "internal/controller/logs.go")
+	got := signatures(decls, "UsersRepository.Restore", "UsersRepository.ListTrash", "UsersRepository.HardDelete",
+		"UsersService.HardDelete", "UsersController.Restore", "UsersController.ListTrash",
+		"UsersRepository.ByEmailUnscoped", "LogsRepository.Restore", "LogsController.Restore", "LogsRepository.ByMessageUnscoped")
+	want := map[string]string{
+		"UsersRepository.Restore":          "func(ctx context.Context, id uint64) error",
+		"UsersRepository.ListTrash":        "func(ctx context.Context, page, pageSize int) ([]*model.Users, int64, error)",
+		"UsersRepository.HardDelete":       "func(ctx context.Context, id uint64) error",
+		"UsersService.HardDelete":          "func(ctx context.Context, id uint64) error",
+		"UsersController.Restore":          "func(ctx *gin.Context)",
+		"UsersController.ListTrash":        "func(ctx *gin.Context)",
+		"UsersRepository.ByEmailUnscoped":  "func(ctx context.Context, email string, preloads ...string) (*model.Users, error)",
+		"LogsRepository.Restore":           "",
+		"LogsController.Restore":           "",
+		"LogsRepository.ByMessageUnscoped": "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("signatures =\n%v\nwant\n%v", got, want)
+	}
+
+	// 已软删除的记录仍占用唯一值，检查冲突时不能只查未删除的记录
+	if check := decls["UsersService.checkUnique"]; check == nil {
+		t.Error("UsersService.checkUnique not generated")
+	} else {
+		var calls []string
+		ast.Inspect(check, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if fun := types.ExprString(call.Fun); strings.HasPrefix(fun, "s.repo.") {
+					calls = append(calls, fun)
+				}
+			}
+			return true
+		})
+		if want := []string{"s.repo.ByEmailUnscoped"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("UsersService.checkUnique calls %v, want %v", calls, want)
+		}
+	}
+	vetGenerated(t, dir)
+	buildtest.Go(t, dir, testModule, "test", "./internal/service/", "./internal/controller/")
+}
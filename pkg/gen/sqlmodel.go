@@ -0,0 +1,189 @@
+package gen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gen/field"
+	"gorm.io/gen/helper"
+	"gorm.io/gorm"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// tableObject 把解析出的表结构适配为 GORM Gen 的 helper.Object，
+// 使 GORM Gen 无需连接数据库即可生成 model 与 query 代码
+type tableObject struct {
+	table     *DetailedTableInfo
+	opts      tableOptions
+	relations []RelationInfo
+}
+
+var _ helper.Object = (*tableObject)(nil)
+
+// TableName 表名
+func (o *tableObject) TableName() string { return o.table.Name }
+
+// StructName 模型名，与 Repository/Service/Controller 层保持一致
+func (o *tableObject) StructName() string { return o.opts.ModelName }
+
+// FileName 生成的文件名
+func (o *tableObject) FileName() string { return o.table.Name }
+
+// ImportPkgPaths 字段类型需要导入的包（不含 GORM Gen 模型模板已导入的包）
+func (o *tableObject) ImportPkgPaths() []string {
+	var goTypes []string
+	for _, f := range o.Fields() {
+		goTypes = append(goTypes, f.Type())
+	}
+	// GORM Gen 直接把路径写入 import 块，需要带引号
+	var paths []string
+	for _, path := range typemap.Imports(goTypes...) {
+		if !genModelImports[path] {
+			paths = append(paths, strconv.Quote(path))
+		}
+	}
+	return paths
+}
+
+// Fields 字段列表
+func (o *tableObject) Fields() []helper.Field {
+	fields := make([]helper.Field, 0, len(o.table.Fields))
+	for i := range o.table.Fields {
+		info := &o.table.Fields[i]
+		if o.opts.ignored(info.Name) {
+			continue
+		}
+		fields = append(fields, &columnField{info: info, override: o.opts.Columns[info.Name]})
+	}
+	for i := range o.relations {
+		fields = append(fields, &relationField{rel: &o.relations[i]})
+	}
+	return fields
+}
+
+// columnField 把 FieldInfo 适配为 GORM Gen 的 helper.Field
+type columnField struct {
+	info     *FieldInfo
+	override ColumnConfig
+}
+
+var _ helper.Field = (*columnField)(nil)
+
+// Name 字段名，与 GORM Gen 连接数据库时的命名规则一致（如 user_id -> UserID）
+func (f *columnField) Name() string {
+	return columnFieldName(f.info.Name)
+}
+
+// Type 字段类型，可空的非主键字段按 types.nullable 策略使用指针或 sql.Null*，
+// deleted_at 时间列与连接数据库生成时一致，使用 gorm.DeletedAt
+func (f *columnField) Type() string {
+	if f.override.Type != "" {
+		return f.override.Type
+	}
+	goType := f.info.GoType
+	if goType == "" {
+		goType = mapToGoType(typemap.Options{}, *f.info)
+	}
+	if f.info.Name == "deleted_at" {
+		switch goType {
+		case "time.Time", "*time.Time", "sql.NullTime":
+			return "gorm.DeletedAt"
+		}
+	}
+	return goType
+}
+
+// ColumnName 列名
+func (f *columnField) ColumnName() string { return f.info.Name }
+
+// GORMTag gorm 标签
+func (f *columnField) GORMTag() string {
+	tag := field.GormTag{}
+	tag.Set("column", f.info.Name)
+	if f.info.PrimaryKey {
+		tag.Set("primaryKey")
+	}
+	if f.info.AutoIncrement {
+		tag.Set("autoIncrement", "true")
+	}
+	if !f.info.Nullable && !f.info.PrimaryKey {
+		tag.Set("not null")
+	}
+	if f.info.DefaultValue != "" && !f.info.AutoIncrement {
+		tag.Set("default", f.info.DefaultValue)
+	}
+	if f.info.Comment != "" {
+		tag.Set("comment", strings.ReplaceAll(f.info.Comment, ";", ","))
+	}
+	return tag.Build()
+}
+
+// JSONTag json 标签
+func (f *columnField) JSONTag() string {
+	if f.override.JSON != "" {
+		return f.override.JSON
+	}
+	return f.info.Name
+}
+
+// Tag 其它标签：枚举列带 oneof 校验
+func (f *columnField) Tag() field.Tag {
+	if f.info.Enum == nil || f.override.Type != "" {
+		return nil
+	}
+	if binding := f.info.Enum.BindingTag(); binding != "" {
+		return field.Tag{"binding": binding}
+	}
+	return nil
+}
+
+// Comment 字段注释
+func (f *columnField) Comment() string { return f.info.Comment }
+
+// relationField 把关联字段适配为 GORM Gen 的 helper.Field（没有列名，不生成查询字段）
+type relationField struct {
+	rel *RelationInfo
+}
+
+var _ helper.Field = (*relationField)(nil)
+
+func (f *relationField) Name() string       { return f.rel.FieldName }
+func (f *relationField) Type() string       { return f.rel.GoType() }
+func (f *relationField) ColumnName() string { return "" }
+func (f *relationField) GORMTag() string    { return f.rel.GORMTag }
+func (f *relationField) JSONTag() string    { return f.rel.JSONTag() }
+func (f *relationField) Tag() field.Tag     { return nil }
+func (f *relationField) Comment() string    { return "" }
+
+// openOfflineGORMDB 创建不连接数据库的 GORM 实例
+//
+// GORM Gen 在 GenerateModelFrom 模式下只需要 db 提供命名策略和日志，
+// 因此使用 DryRun 且跳过版本探测即可。
+func openOfflineGORMDB(dialect string) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+
+	switch dialect {
+	case "mysql":
+		dialector = mysql.New(mysql.Config{
+			DSN:                       "offline:offline@tcp(127.0.0.1:3306)/offline",
+			SkipInitializeWithVersion: true,
+		})
+	case "postgres":
+		dialector = postgres.New(postgres.Config{
+			DSN:                  "host=127.0.0.1 user=offline dbname=offline sslmode=disable",
+			PreferSimpleProtocol: true,
+		})
+	default:
+		return nil, fmt.Errorf("不支持的 SQL 方言: %s", dialect)
+	}
+
+	return gorm.Open(dialector, &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+}
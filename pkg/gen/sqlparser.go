@@ -0,0 +1,967 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SQLSchema SQL DDL 文件解析结果
+type SQLSchema struct {
+	Dialect string               // 方言：mysql 或 postgres
+	Tables  []*DetailedTableInfo // 按出现顺序排列的表
+}
+
+// Table 根据表名查找解析结果
+func (s *SQLSchema) Table(name string) *DetailedTableInfo {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// ParseSQL 解析 MySQL / PostgreSQL 的 DDL 语句
+//
+// 支持的语句：
+//   - CREATE TABLE（列定义、PRIMARY KEY、UNIQUE/KEY/INDEX、FOREIGN KEY、列级 REFERENCES、
+//     CONSTRAINT ... UNIQUE/FOREIGN KEY）
+//   - CREATE [UNIQUE] INDEX ... ON table (...)
+//   - CREATE TYPE ... AS ENUM (...)（PostgreSQL 枚举类型）
+//   - ALTER TABLE ... ADD CONSTRAINT / ADD PRIMARY KEY / ADD UNIQUE / ADD INDEX / ADD FOREIGN KEY
+//   - ALTER TABLE ... ALTER COLUMN ... SET DEFAULT（pg_dump 导出的 serial 列默认值）
+//   - COMMENT ON TABLE / COMMENT ON COLUMN（PostgreSQL）
+//
+// 其它语句（INSERT、DROP、SET 等）会被忽略。
+func ParseSQL(content string) (*SQLSchema, error) {
+	dialect := DetectSQLDialect(content)
+	p := &sqlParser{
+		sqlLexer: sqlLexer{mysql: dialect == "mysql"},
+		schema:   &SQLSchema{Dialect: dialect},
+	}
+
+	for _, stmt := range p.splitSQLStatements(p.stripSQLComments(content)) {
+		if err := p.parseStatement(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(p.schema.Tables) == 0 {
+		return nil, fmt.Errorf("未在 SQL 文件中找到 CREATE TABLE 语句")
+	}
+
+	return p.schema, nil
+}
+
+// DetectSQLDialect 根据 DDL 特征推断 SQL 方言，无法判断时默认 mysql
+func DetectSQLDialect(content string) string {
+	upper := strings.ToUpper(content)
+
+	mysqlHints := []string{"`", "AUTO_INCREMENT", "ENGINE=", "ENGINE =", "UNSIGNED"}
+	for _, hint := range mysqlHints {
+		if strings.Contains(upper, hint) {
+			return "mysql"
+		}
+	}
+
+	pgHints := []string{"SERIAL", "GENERATED ALWAYS AS IDENTITY", "GENERATED BY DEFAULT AS IDENTITY",
+		"::", "COMMENT ON ", "AS ENUM", "TIMESTAMPTZ", "JSONB", "BYTEA", "CHARACTER VARYING"}
+	for _, hint := range pgHints {
+		if strings.Contains(upper, hint) {
+			return "postgres"
+		}
+	}
+
+	return "mysql"
+}
+
+// sqlLexer 按方言切分 SQL 文本
+//
+// MySQL 中 # 开始行注释，字符串中的反斜杠是转义符；PostgreSQL 中 # 是运算符，
+// 标准字符串中的反斜杠是普通字符，只有 E'...' 字符串中的反斜杠是转义符
+type sqlLexer struct {
+	mysql bool
+}
+
+var (
+	mysqlLexer = sqlLexer{mysql: true}
+	pgLexer    = sqlLexer{}
+)
+
+type sqlParser struct {
+	sqlLexer
+	schema *SQLSchema
+	enums  map[string][]string // CREATE TYPE ... AS ENUM 定义的枚举类型（PostgreSQL）
+}
+
+var (
+	createTableRe = regexp.MustCompile(`(?is)^CREATE\s+(?:(?:GLOBAL\s+|LOCAL\s+)?(?:TEMPORARY|TEMP|UNLOGGED)\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)\s*\(`)
+	createIndexRe = regexp.MustCompile(`(?is)^CREATE\s+(UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)?\s*ON\s+(?:ONLY\s+)?([^\s(]+)\s*(?:USING\s+\w+\s*)?\(`)
+	alterTableRe  = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?([^\s]+)\s+(.*)$`)
+	createTypeRe  = regexp.MustCompile(`(?is)^CREATE\s+TYPE\s+([^\s(]+)\s+AS\s+ENUM\s*\((.*)\)\s*$`)
+	commentOnRe   = regexp.MustCompile(`(?is)^COMMENT\s+ON\s+(TABLE|COLUMN)\s+([^\s]+)\s+IS\s+(.*)$`)
+	tableOptionRe = regexp.MustCompile(`(?is)COMMENT\s*=?\s*('(?:[^']|'')*')`)
+)
+
+// parseStatement 解析单条语句
+func (p *sqlParser) parseStatement(stmt string) error {
+	switch {
+	case createTableRe.MatchString(stmt):
+		return p.parseCreateTable(stmt)
+	case createIndexRe.MatchString(stmt):
+		return p.parseCreateIndex(stmt)
+	case alterTableRe.MatchString(stmt):
+		return p.parseAlterTable(stmt)
+	case createTypeRe.MatchString(stmt):
+		p.parseCreateType(stmt)
+	case commentOnRe.MatchString(stmt):
+		p.parseCommentOn(stmt)
+	}
+	return nil
+}
+
+// parseCreateType 解析 CREATE TYPE ... AS ENUM 语句，记录枚举取值供后续列定义使用
+func (p *sqlParser) parseCreateType(stmt string) {
+	m := createTypeRe.FindStringSubmatch(stmt)
+	if p.enums == nil {
+		p.enums = make(map[string][]string)
+	}
+	p.enums[strings.ToLower(unquoteIdent(m[1]))] = p.parseEnumValues(m[2])
+}
+
+// parseCreateTable 解析 CREATE TABLE 语句
+func (p *sqlParser) parseCreateTable(stmt string) error {
+	m := createTableRe.FindStringSubmatchIndex(stmt)
+	name := unquoteIdent(stmt[m[2]:m[3]])
+
+	open := m[1] - 1
+	closeIdx := p.matchingParen(stmt, open)
+	if closeIdx < 0 {
+		return fmt.Errorf("表 %s 的定义括号不匹配", name)
+	}
+
+	table := &DetailedTableInfo{Name: name}
+
+	for _, item := range p.splitTopLevel(stmt[open+1:closeIdx], ',') {
+		if err := p.parseTableItem(table, item); err != nil {
+			return fmt.Errorf("解析表 %s 失败: %w", name, err)
+		}
+	}
+
+	// 表选项（MySQL: COMMENT='...'）
+	if om := tableOptionRe.FindStringSubmatch(stmt[closeIdx+1:]); om != nil {
+		table.Comment = p.unquoteString(om[1])
+	}
+
+	if existing := p.schema.Table(name); existing != nil {
+		*existing = *table
+	} else {
+		p.schema.Tables = append(p.schema.Tables, table)
+	}
+
+	return nil
+}
+
+// parseTableItem 解析表定义中的单个列或约束
+func (p *sqlParser) parseTableItem(table *DetailedTableInfo, item string) error {
+	tokens := p.tokenizeSQL(item)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// CONSTRAINT name ...
+	var constraintName string
+	if strings.EqualFold(tokens[0], "CONSTRAINT") && len(tokens) > 2 {
+		constraintName = unquoteIdent(tokens[1])
+		tokens = tokens[2:]
+	}
+
+	switch strings.ToUpper(tokens[0]) {
+	case "PRIMARY":
+		if cols := p.lastParenColumns(tokens); len(cols) > 0 {
+			p.addPrimaryKey(table, constraintName, cols)
+		}
+		return nil
+	case "UNIQUE":
+		name, cols := p.indexNameAndColumns(tokens[1:])
+		if constraintName != "" {
+			name = constraintName
+		}
+		p.addIndex(table, name, cols, true)
+		return nil
+	case "KEY", "INDEX":
+		name, cols := p.indexNameAndColumns(tokens[1:])
+		p.addIndex(table, name, cols, false)
+		return nil
+	case "FOREIGN":
+		p.parseForeignKey(table, constraintName, tokens[1:])
+		return nil
+	case "FULLTEXT", "SPATIAL", "CHECK", "EXCLUDE":
+		return nil
+	}
+
+	if constraintName != "" {
+		// CONSTRAINT name 之后跟随未知约束类型
+		return nil
+	}
+
+	return p.parseColumn(table, tokens)
+}
+
+// parseColumn 解析列定义
+func (p *sqlParser) parseColumn(table *DetailedTableInfo, tokens []string) error {
+	if len(tokens) < 2 {
+		return fmt.Errorf("无效的列定义: %s", strings.Join(tokens, " "))
+	}
+
+	field := FieldInfo{
+		Name:     unquoteIdent(tokens[0]),
+		Nullable: true,
+	}
+
+	dataType, columnType, rest := parseColumnType(tokens[1:])
+	unique := false
+	var references []string
+	field.Type = dataType
+	field.ColumnType = columnType
+
+	if dataType == "enum" {
+		field.EnumValues = p.parseEnumValues(columnType)
+	} else if values, ok := p.enums[dataType]; ok {
+		field.EnumValues = values
+	}
+
+	switch dataType {
+	case "serial", "bigserial", "smallserial", "serial4", "serial8", "serial2":
+		field.AutoIncrement = true
+		field.Nullable = false
+		field.Type = map[string]string{
+			"serial": "integer", "serial4": "integer",
+			"bigserial": "bigint", "serial8": "bigint",
+			"smallserial": "smallint", "serial2": "smallint",
+		}[dataType]
+	}
+
+	for i := 0; i < len(rest); i++ {
+		tok := strings.ToUpper(rest[i])
+		switch tok {
+		case "NOT":
+			if i+1 < len(rest) && strings.EqualFold(rest[i+1], "NULL") {
+				field.Nullable = false
+				i++
+			}
+		case "NULL":
+			field.Nullable = true
+		case "PRIMARY":
+			field.PrimaryKey = true
+			field.Nullable = false
+			if i+1 < len(rest) && strings.EqualFold(rest[i+1], "KEY") {
+				i++
+			}
+		case "AUTO_INCREMENT", "AUTOINCREMENT":
+			field.AutoIncrement = true
+		case "UNSIGNED":
+			field.ColumnType += " unsigned"
+		case "IDENTITY":
+			field.AutoIncrement = true
+			field.Nullable = false
+		case "UNIQUE":
+			if i+1 < len(rest) && strings.EqualFold(rest[i+1], "KEY") {
+				i++
+			}
+			unique = true
+		case "DEFAULT":
+			value, n := p.parseDefault(rest[i+1:])
+			setDefault(&field, value)
+			i += n
+		case "COMMENT":
+			if i+1 < len(rest) {
+				i++
+				field.Comment = p.unquoteString(rest[i])
+			}
+		case "REFERENCES":
+			// 列级外键：REFERENCES table [(column)]，后续的 ON DELETE 等子句不影响结构
+			references = rest[i:]
+			i = len(rest)
+		}
+	}
+
+	table.Fields = append(table.Fields, field)
+
+	if field.PrimaryKey {
+		p.addPrimaryKey(table, "", []string{field.Name})
+	}
+	if unique {
+		p.addIndex(table, p.columnUniqueName(table.Name, field.Name), []string{field.Name}, true)
+	}
+	if references != nil {
+		p.addForeignKey(table, "", []string{field.Name}, references)
+	}
+
+	return nil
+}
+
+// parseDefault 解析 DEFAULT 之后的默认值，返回默认值与消耗的 token 数；
+// 函数调用保留参数（如 now()、nextval('t_id_seq'::regclass)），去掉 PostgreSQL 类型转换
+func (p *sqlParser) parseDefault(tokens []string) (string, int) {
+	if len(tokens) == 0 {
+		return "", 0
+	}
+
+	value, n := tokens[0], 1
+	if n < len(tokens) && strings.HasPrefix(tokens[n], "(") && !strings.HasPrefix(value, "'") {
+		value += tokens[n]
+		n++
+	}
+	// PostgreSQL 类型转换：'x'::character varying
+	for n < len(tokens) && strings.HasPrefix(tokens[n], "::") {
+		n++
+		for n < len(tokens) && isTypeContinuation(tokens[n]) {
+			n++
+		}
+	}
+	return p.unquoteDefault(value), n
+}
+
+// setDefault 设置列默认值，默认值为 nextval(...) 的列（pg_dump 导出的 serial 列）视为自增
+func setDefault(field *FieldInfo, value string) {
+	if strings.HasPrefix(strings.ToLower(value), "nextval(") {
+		field.AutoIncrement = true
+		field.DefaultValue = ""
+		return
+	}
+	field.DefaultValue = value
+}
+
+// parseForeignKey 解析 "KEY [name] (cols) REFERENCES table [(cols)] ..." 形式的表级外键
+func (p *sqlParser) parseForeignKey(table *DetailedTableInfo, name string, tokens []string) {
+	for i, tok := range tokens {
+		switch {
+		case strings.HasPrefix(tok, "("):
+			p.addForeignKey(table, name, p.parseColumnList(tok[1:len(tok)-1]), tokens[i+1:])
+			return
+		case name == "" && !strings.EqualFold(tok, "KEY"):
+			// MySQL: FOREIGN KEY fk_name (cols)
+			name = unquoteIdent(tok)
+		}
+	}
+}
+
+// addForeignKey 登记外键，references 以 REFERENCES 关键字开头
+func (p *sqlParser) addForeignKey(table *DetailedTableInfo, name string, columns []string, references []string) {
+	if len(columns) == 0 || len(references) < 2 || !strings.EqualFold(references[0], "REFERENCES") {
+		return
+	}
+
+	fk := ForeignKeyInfo{
+		Name:     name,
+		Columns:  columns,
+		RefTable: unquoteIdent(references[1]),
+	}
+	if len(references) > 2 && strings.HasPrefix(references[2], "(") {
+		fk.RefColumns = p.parseColumnList(references[2][1 : len(references[2])-1])
+	}
+
+	if fk.Name == "" {
+		if p.schema.Dialect == "postgres" {
+			fk.Name = table.Name + "_" + strings.Join(columns, "_") + "_fkey"
+		} else {
+			fk.Name = fmt.Sprintf("%s_ibfk_%d", table.Name, len(table.ForeignKeys)+1)
+		}
+	}
+
+	table.ForeignKeys = append(table.ForeignKeys, fk)
+}
+
+// parseCreateIndex 解析 CREATE INDEX 语句
+func (p *sqlParser) parseCreateIndex(stmt string) error {
+	m := createIndexRe.FindStringSubmatchIndex(stmt)
+	unique := m[2] >= 0
+	var name string
+	if m[4] >= 0 {
+		name = unquoteIdent(stmt[m[4]:m[5]])
+	}
+	tableName := unquoteIdent(stmt[m[6]:m[7]])
+
+	open := m[1] - 1
+	closeIdx := p.matchingParen(stmt, open)
+	if closeIdx < 0 {
+		return fmt.Errorf("索引 %s 的列定义括号不匹配", name)
+	}
+
+	table := p.schema.Table(tableName)
+	if table == nil {
+		return fmt.Errorf("索引 %s 引用了未定义的表 %s", name, tableName)
+	}
+
+	p.addIndex(table, name, p.parseColumnList(stmt[open+1:closeIdx]), unique)
+	return nil
+}
+
+// parseAlterTable 解析 ALTER TABLE ... ADD ... 语句
+func (p *sqlParser) parseAlterTable(stmt string) error {
+	m := alterTableRe.FindStringSubmatch(stmt)
+	tableName := unquoteIdent(m[1])
+
+	table := p.schema.Table(tableName)
+	if table == nil {
+		return nil
+	}
+
+	for _, action := range p.splitTopLevel(m[2], ',') {
+		tokens := p.tokenizeSQL(action)
+		if len(tokens) >= 2 && strings.EqualFold(tokens[0], "ALTER") {
+			p.parseAlterColumn(table, tokens[1:])
+			continue
+		}
+		if len(tokens) < 2 || !strings.EqualFold(tokens[0], "ADD") {
+			continue
+		}
+		tokens = tokens[1:]
+
+		switch strings.ToUpper(tokens[0]) {
+		case "CONSTRAINT", "PRIMARY", "UNIQUE", "KEY", "INDEX", "FOREIGN":
+			if err := p.parseTableItem(table, strings.Join(tokens, " ")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseAlterColumn 解析 "ALTER [COLUMN] name SET DEFAULT ..." 子句（pg_dump 单独设置 serial 列的默认值）
+func (p *sqlParser) parseAlterColumn(table *DetailedTableInfo, tokens []string) {
+	if strings.EqualFold(tokens[0], "COLUMN") {
+		tokens = tokens[1:]
+	}
+	if len(tokens) < 4 || !strings.EqualFold(tokens[1], "SET") || !strings.EqualFold(tokens[2], "DEFAULT") {
+		return
+	}
+
+	column := unquoteIdent(tokens[0])
+	for i := range table.Fields {
+		if table.Fields[i].Name == column {
+			value, _ := p.parseDefault(tokens[3:])
+			setDefault(&table.Fields[i], value)
+		}
+	}
+}
+
+// parseCommentOn 解析 PostgreSQL 的 COMMENT ON 语句
+func (p *sqlParser) parseCommentOn(stmt string) {
+	m := commentOnRe.FindStringSubmatch(stmt)
+	target := splitQualifiedName(m[2])
+	comment := p.unquoteString(strings.TrimSpace(m[3]))
+
+	if strings.EqualFold(m[1], "TABLE") {
+		if table := p.schema.Table(target[len(target)-1]); table != nil {
+			table.Comment = comment
+		}
+		return
+	}
+
+	if len(target) < 2 {
+		return
+	}
+	table := p.schema.Table(target[len(target)-2])
+	if table == nil {
+		return
+	}
+	column := target[len(target)-1]
+	for i := range table.Fields {
+		if table.Fields[i].Name == column {
+			table.Fields[i].Comment = comment
+		}
+	}
+}
+
+// addPrimaryKey 标记主键列并登记主键索引
+func (p *sqlParser) addPrimaryKey(table *DetailedTableInfo, name string, columns []string) {
+	for i := range table.Fields {
+		for _, col := range columns {
+			if table.Fields[i].Name == col {
+				table.Fields[i].PrimaryKey = true
+				table.Fields[i].Nullable = false
+			}
+		}
+	}
+
+	if name == "" {
+		name = "PRIMARY"
+		if p.schema.Dialect == "postgres" {
+			name = table.Name + "_pkey"
+		}
+	}
+
+	for i, idx := range table.Indexes {
+		if idx.Primary {
+			table.Indexes[i].Columns = columns
+			return
+		}
+	}
+	table.Indexes = append(table.Indexes, IndexInfo{
+		Name:    name,
+		Columns: columns,
+		Unique:  true,
+		Primary: true,
+	})
+}
+
+// addIndex 登记普通索引或唯一索引
+func (p *sqlParser) addIndex(table *DetailedTableInfo, name string, columns []string, unique bool) {
+	if len(columns) == 0 {
+		return
+	}
+	if name == "" {
+		name = p.defaultIndexName(table.Name, columns, unique)
+	}
+	for _, idx := range table.Indexes {
+		if idx.Name == name {
+			return
+		}
+	}
+	table.Indexes = append(table.Indexes, IndexInfo{
+		Name:    name,
+		Columns: columns,
+		Unique:  unique,
+	})
+}
+
+// columnUniqueName 列级 UNIQUE 约束的默认索引名，与数据库的命名规则保持一致
+func (p *sqlParser) columnUniqueName(tableName, column string) string {
+	if p.schema.Dialect == "postgres" {
+		return tableName + "_" + column + "_key"
+	}
+	return column
+}
+
+// defaultIndexName 未命名索引的默认名称
+func (p *sqlParser) defaultIndexName(tableName string, columns []string, unique bool) string {
+	if p.schema.Dialect == "postgres" {
+		suffix := "_idx"
+		if unique {
+			suffix = "_key"
+		}
+		return tableName + "_" + strings.Join(columns, "_") + suffix
+	}
+	return columns[0]
+}
+
+// parseColumnType 解析列类型，返回小写的基础类型名（不含长度/精度）、
+// 完整类型（含长度/精度，如 tinyint(1)、numeric(10,2)）与剩余 token
+func parseColumnType(tokens []string) (string, string, []string) {
+	dataType := strings.ToLower(unquoteIdent(tokens[0]))
+	var params string
+	i := 1
+
+	// 多词类型：character varying、double precision、timestamp with time zone 等
+	for i < len(tokens) && isTypeContinuation(tokens[i]) {
+		dataType += " " + strings.ToLower(tokens[i])
+		i++
+	}
+
+	// 长度/精度/枚举值
+	if i < len(tokens) && strings.HasPrefix(tokens[i], "(") {
+		params = tokens[i]
+		i++
+		// timestamp(6) with time zone
+		for i < len(tokens) && isTypeContinuation(tokens[i]) {
+			dataType += " " + strings.ToLower(tokens[i])
+			i++
+		}
+	}
+
+	// 数组类型：integer[]
+	if idx := strings.Index(dataType, "["); idx > 0 {
+		dataType = dataType[:idx] + "[]"
+	}
+	if i < len(tokens) && strings.HasPrefix(tokens[i], "[") {
+		dataType += "[]"
+		i++
+	}
+
+	if strings.HasPrefix(dataType, "timestamp with") {
+		dataType = "timestamp with time zone"
+	} else if strings.HasPrefix(dataType, "timestamp without") {
+		dataType = "timestamp without time zone"
+	}
+
+	columnType := dataType
+	if params != "" && !strings.HasSuffix(dataType, "[]") && !strings.Contains(dataType, " ") {
+		columnType += params
+	}
+
+	return dataType, columnType, tokens[i:]
+}
+
+// isTypeContinuation 判断 token 是否是多词类型的组成部分
+func isTypeContinuation(tok string) bool {
+	switch strings.ToUpper(tok) {
+	case "VARYING", "PRECISION", "WITH", "WITHOUT", "TIME", "ZONE":
+		return true
+	}
+	return false
+}
+
+// indexNameAndColumns 解析 "[KEY|INDEX] [name] [USING btree] (cols)" 形式
+func (p *sqlParser) indexNameAndColumns(tokens []string) (string, []string) {
+	var name string
+	for _, tok := range tokens {
+		upper := strings.ToUpper(tok)
+		if strings.HasPrefix(tok, "(") {
+			break
+		}
+		if upper == "KEY" || upper == "INDEX" || upper == "USING" || upper == "BTREE" || upper == "HASH" || upper == "NULLS" || upper == "DISTINCT" {
+			continue
+		}
+		name = unquoteIdent(tok)
+	}
+	return name, p.lastParenColumns(tokens)
+}
+
+// lastParenColumns 从 token 列表中取第一个括号组作为列列表
+func (p *sqlParser) lastParenColumns(tokens []string) []string {
+	for _, tok := range tokens {
+		if strings.HasPrefix(tok, "(") {
+			return p.parseColumnList(tok[1 : len(tok)-1])
+		}
+	}
+	return nil
+}
+
+// parseColumnList 解析索引列列表，去掉前缀长度、排序方向与操作符类
+func (p *sqlParser) parseColumnList(s string) []string {
+	var columns []string
+	for _, part := range p.splitTopLevel(s, ',') {
+		tokens := p.tokenizeSQL(part)
+		if len(tokens) == 0 {
+			continue
+		}
+		// 表达式索引（如 lower(email)）无法映射到单列，跳过
+		if strings.HasPrefix(tokens[0], "(") {
+			continue
+		}
+		if len(tokens) > 1 && strings.HasPrefix(tokens[1], "(") && isFunctionName(tokens[0]) {
+			continue
+		}
+		columns = append(columns, unquoteIdent(tokens[0]))
+	}
+	return columns
+}
+
+// isFunctionName 判断索引列中的标识符是否为函数调用（MySQL 前缀索引 name(10) 不算）
+func isFunctionName(tok string) bool {
+	switch strings.ToLower(tok) {
+	case "lower", "upper", "coalesce", "date", "md5", "substr", "substring", "trim":
+		return true
+	}
+	return false
+}
+
+// stripSQLComments 去除 -- / /* */ 注释（MySQL 还有 #），保留字符串中的内容
+func (l sqlLexer) stripSQLComments(content string) string {
+	var b strings.Builder
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := l.skipQuoted(runes, i)
+			b.WriteString(string(runes[i:end]))
+			i = end - 1
+		case c == '-' && i+1 < len(runes) && runes[i+1] == '-', c == '#' && l.mysql:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			b.WriteRune('\n')
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i++
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// splitSQLStatements 以分号切分语句，忽略字符串和 $$ 块中的分号
+func (l sqlLexer) splitSQLStatements(content string) []string {
+	var statements []string
+	var b strings.Builder
+	runes := []rune(content)
+
+	flush := func() {
+		if stmt := strings.TrimSpace(b.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		b.Reset()
+	}
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := l.skipQuoted(runes, i)
+			b.WriteString(string(runes[i:end]))
+			i = end - 1
+		case c == '$':
+			if end := skipDollarQuoted(runes, i); end > i {
+				b.WriteString(string(runes[i:end]))
+				i = end - 1
+			} else {
+				b.WriteRune(c)
+			}
+		case c == ';':
+			flush()
+		default:
+			b.WriteRune(c)
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// skipQuoted 返回引号结束后的位置，支持 ” 转义与反斜杠转义
+func (l sqlLexer) skipQuoted(runes []rune, start int) int {
+	quote := runes[start]
+	backslash := quote == '\'' && (l.mysql || isEscapeStringPrefix(runes[:start]))
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '\\' && backslash {
+			i++
+			continue
+		}
+		if runes[i] == quote {
+			if i+1 < len(runes) && runes[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(runes)
+}
+
+// skipDollarQuoted 跳过 PostgreSQL 的 $tag$ ... $tag$ 块，不是 $ 块时返回 start
+func skipDollarQuoted(runes []rune, start int) int {
+	end := start + 1
+	for end < len(runes) && (runes[end] == '_' || isAlnum(runes[end])) {
+		end++
+	}
+	if end >= len(runes) || runes[end] != '$' {
+		return start
+	}
+	tag := string(runes[start : end+1])
+	rest := string(runes[end+1:])
+	idx := strings.Index(rest, tag)
+	if idx < 0 {
+		return len(runes)
+	}
+	return end + 1 + len([]rune(rest[:idx])) + len([]rune(tag))
+}
+
+// isEscapeStringPrefix 判断字符串前是否为 PostgreSQL 转义字符串的 E 前缀（E'...'）
+func isEscapeStringPrefix(before []rune) bool {
+	n := len(before)
+	if n == 0 || (before[n-1] != 'E' && before[n-1] != 'e') {
+		return false
+	}
+	return n == 1 || !(before[n-2] == '_' || isAlnum(before[n-2]))
+}
+
+func isAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// splitTopLevel 按分隔符切分，忽略括号和字符串内部的分隔符
+func (l sqlLexer) splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	var b strings.Builder
+	depth := 0
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := l.skipQuoted(runes, i)
+			b.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth == 0:
+			if part := strings.TrimSpace(b.String()); part != "" {
+				parts = append(parts, part)
+			}
+			b.Reset()
+			continue
+		}
+		b.WriteRune(c)
+	}
+	if part := strings.TrimSpace(b.String()); part != "" {
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+// tokenizeSQL 把片段切分为 token：单词、字符串、带引号的标识符、完整的括号组
+func (l sqlLexer) tokenizeSQL(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			end := l.skipQuoted(runes, i)
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		case c == '(':
+			end := l.matchingParenRunes(runes, i)
+			if end < 0 {
+				end = len(runes) - 1
+			}
+			tokens = append(tokens, string(runes[i:end+1]))
+			i = end + 1
+		case c == '=' || c == ',':
+			i++
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r('\"`=,", runes[i]) {
+				i++
+			}
+			// PostgreSQL 转义字符串 E'...' 作为一个 token
+			if i < len(runes) && runes[i] == '\'' && !l.mysql && isEscapeStringPrefix(runes[start:i]) {
+				i = l.skipQuoted(runes, i)
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		}
+	}
+
+	return tokens
+}
+
+// matchingParen 返回与 open 位置左括号匹配的右括号位置
+func (l sqlLexer) matchingParen(s string, open int) int {
+	runes := []rune(s[:open])
+	offset := len(runes)
+	all := []rune(s)
+	end := l.matchingParenRunes(all, offset)
+	if end < 0 {
+		return -1
+	}
+	return len(string(all[:end]))
+}
+
+func (l sqlLexer) matchingParenRunes(runes []rune, open int) int {
+	depth := 0
+	for i := open; i < len(runes); i++ {
+		switch runes[i] {
+		case '\'', '"', '`':
+			i = l.skipQuoted(runes, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitQualifiedName 切分 schema.table.column 形式的名称并去掉引号
+func splitQualifiedName(name string) []string {
+	var parts []string
+	for _, part := range pgLexer.splitTopLevel(name, '.') {
+		parts = append(parts, unquoteIdent(part))
+	}
+	return parts
+}
+
+// unquoteIdent 去掉标识符的引号，并只保留最后一段（去掉 schema 前缀）
+//
+// 标识符中没有字符串字面量，切分规则与方言无关
+func unquoteIdent(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ".") {
+		parts := pgLexer.splitTopLevel(s, '.')
+		s = parts[len(parts)-1]
+	}
+	if isQuotedIdent(s) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func isQuotedIdent(s string) bool {
+	return len(s) >= 2 && ((s[0] == '`' && s[len(s)-1] == '`') || (s[0] == '"' && s[len(s)-1] == '"'))
+}
+
+// unquoteString 去掉字符串字面量的单引号并还原转义
+func (l sqlLexer) unquoteString(s string) string {
+	s = strings.TrimSpace(s)
+	backslash := l.mysql
+	if !l.mysql && len(s) >= 3 && (s[0] == 'E' || s[0] == 'e') && s[1] == '\'' {
+		s, backslash = s[1:], true
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		s = strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+		if backslash {
+			s = unescapeBackslashes(s)
+		}
+	}
+	return s
+}
+
+// unescapeBackslashes 还原反斜杠转义：\n、\t、\r、\0 为控制字符，其余为字符本身
+func unescapeBackslashes(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '0':
+			b.WriteByte(0)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// unquoteDefault 规范化默认值，与 information_schema 返回的形式一致
+func (l sqlLexer) unquoteDefault(s string) string {
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if strings.EqualFold(s, "NULL") {
+		return ""
+	}
+	return l.unquoteString(s)
+}
@@ -0,0 +1,264 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseSQLMySQL 验证 MySQL DDL 的列、主键与索引解析
+func TestParseSQLMySQL(t *testing.T) {
+	ddl := "-- 用户表\n" +
+		"CREATE TABLE IF NOT EXISTS `users` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',\n" +
+		"  `username` varchar(64) NOT NULL COMMENT '用户名',\n" +
+		"  `email` varchar(128) DEFAULT NULL,\n" +
+		"  `status` tinyint NOT NULL DEFAULT '1',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_username` (`username`),\n" +
+		"  KEY `idx_email_status` (`email`(20), `status`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='用户表';\n" +
+		"CREATE INDEX idx_status ON users (status);"
+
+	schema, err := ParseSQL(ddl)
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if schema.Dialect != "mysql" {
+		t.Fatalf("Dialect = %q, want mysql", schema.Dialect)
+	}
+
+	users := schema.Table("users")
+	if users == nil {
+		t.Fatalf("table users not found")
+	}
+	if users.Comment != "用户表" {
+		t.Errorf("Comment = %q, want 用户表", users.Comment)
+	}
+
+	id := users.Fields[0]
+	if id.Type != "bigint" || !id.PrimaryKey || !id.AutoIncrement || id.Nullable || id.Comment != "主键" {
+		t.Errorf("id field = %+v", id)
+	}
+	if email := users.Fields[2]; !email.Nullable || email.DefaultValue != "" {
+		t.Errorf("email field = %+v", email)
+	}
+	if status := users.Fields[3]; status.Nullable || status.DefaultValue != "1" {
+		t.Errorf("status field = %+v", status)
+	}
+
+	want := []IndexInfo{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+		{Name: "uk_username", Columns: []string{"username"}, Unique: true},
+		{Name: "idx_email_status", Columns: []string{"email", "status"}},
+		{Name: "idx_status", Columns: []string{"status"}},
+	}
+	if !reflect.DeepEqual(users.Indexes, want) {
+		t.Errorf("Indexes = %+v, want %+v", users.Indexes, want)
+	}
+}
+
+// TestParseSQLPostgres 验证 PostgreSQL 的 SERIAL/IDENTITY、ALTER TABLE 约束与 COMMENT ON
+func TestParseSQLPostgres(t *testing.T) {
+	ddl := `
+CREATE TABLE public.articles (
+    id BIGSERIAL PRIMARY KEY,
+    title character varying(200) NOT NULL,
+    state varchar(20) DEFAULT 'draft'::character varying NOT NULL,
+    published_at timestamp(6) with time zone
+);
+COMMENT ON COLUMN public.articles.title IS '标题';
+CREATE UNIQUE INDEX articles_title_key ON public.articles USING btree (title);
+
+CREATE TABLE tags (
+    id integer GENERATED ALWAYS AS IDENTITY,
+    name text NOT NULL UNIQUE
+);
+ALTER TABLE ONLY public.tags ADD CONSTRAINT tags_pkey PRIMARY KEY (id);
+
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN RETURN NEW; END; $$ LANGUAGE plpgsql;
+`
+
+	schema, err := ParseSQL(ddl)
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if schema.Dialect != "postgres" {
+		t.Fatalf("Dialect = %q, want postgres", schema.Dialect)
+	}
+
+	articles := schema.Table("articles")
+	if articles == nil {
+		t.Fatalf("table articles not found")
+	}
+	if id := articles.Fields[0]; id.Type != "bigint" || !id.PrimaryKey || !id.AutoIncrement {
+		t.Errorf("id field = %+v", id)
+	}
+	if title := articles.Fields[1]; title.Type != "character varying" || title.Comment != "标题" {
+		t.Errorf("title field = %+v", title)
+	}
+	if state := articles.Fields[2]; state.DefaultValue != "draft" || state.Nullable {
+		t.Errorf("state field = %+v", state)
+	}
+	if published := articles.Fields[3]; published.Type != "timestamp with time zone" || !published.Nullable {
+		t.Errorf("published_at field = %+v", published)
+	}
+
+	tags := schema.Table("tags")
+	if tags == nil {
+		t.Fatalf("table tags not found")
+	}
+	want := []IndexInfo{
+		{Name: "tags_name_key", Columns: []string{"name"}, Unique: true},
+		{Name: "tags_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+	}
+	if !reflect.DeepEqual(tags.Indexes, want) {
+		t.Errorf("Indexes = %+v, want %+v", tags.Indexes, want)
+	}
+	if !tags.Fields[0].PrimaryKey || !tags.Fields[0].AutoIncrement {
+		t.Errorf("tags.id field = %+v", tags.Fields[0])
+	}
+}
+
+// TestParseSQLPostgresSequence 验证默认值为 nextval(...) 的列视为自增，包括 pg_dump 单独设置默认值的形式
+func TestParseSQLPostgresSequence(t *testing.T) {
+	ddl := `
+CREATE TABLE public.orders (
+    id integer DEFAULT nextval('orders_id_seq'::regclass) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    name text
+);
+CREATE SEQUENCE public.users_id_seq START WITH 1 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+ALTER TABLE ONLY public.users ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+`
+
+	schema, err := ParseSQL(ddl)
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		table, column string
+		wantAuto      bool
+		wantDefault   string
+	}{
+		{"orders", "id", true, ""},
+		{"orders", "created_at", false, "now()"},
+		{"users", "id", true, ""},
+		{"users", "name", false, ""},
+	}
+	for _, tt := range tests {
+		table := schema.Table(tt.table)
+		if table == nil {
+			t.Fatalf("table %s not found", tt.table)
+		}
+		for _, f := range table.Fields {
+			if f.Name == tt.column && (f.AutoIncrement != tt.wantAuto || f.DefaultValue != tt.wantDefault) {
+				t.Errorf("%s.%s AutoIncrement = %v, DefaultValue = %q, want %v, %q",
+					tt.table, tt.column, f.AutoIncrement, f.DefaultValue, tt.wantAuto, tt.wantDefault)
+			}
+		}
+	}
+}
+
+// TestParseSQLDialectLexing 验证 # 注释与字符串中的反斜杠转义只在 MySQL 中生效：
+// PostgreSQL 中 # 是运算符，标准字符串 'C:\' 在反斜杠后结束，E'...' 字符串支持反斜杠转义
+func TestParseSQLDialectLexing(t *testing.T) {
+	type column struct{ def, comment string }
+	tests := []struct {
+		name    string
+		ddl     string
+		dialect string
+		want    map[string]column
+	}{
+		{
+			name: "postgres",
+			ddl: `
+CREATE TABLE files (
+    id serial PRIMARY KEY,
+    flags integer NOT NULL CHECK (flags # 1 >= 0),
+    path text DEFAULT 'C:\' NOT NULL,
+    sep text DEFAULT E'a\\b\'c',
+    name text
+);
+COMMENT ON COLUMN files.path IS 'Windows 路径，如 C:\';
+`,
+			dialect: "postgres",
+			want: map[string]column{
+				"id": {}, "flags": {}, "name": {},
+				"path": {def: `C:\`, comment: `Windows 路径，如 C:\`},
+				"sep":  {def: `a\b'c`},
+			},
+		},
+		{
+			name: "mysql",
+			ddl: "# 文件表\n" +
+				"CREATE TABLE `files` (\n" +
+				"  `id` int NOT NULL AUTO_INCREMENT, # 主键\n" +
+				"  `path` varchar(255) NOT NULL DEFAULT 'C:\\\\' COMMENT 'it\\'s a path',\n" +
+				"  `name` varchar(64) COMMENT '名称'\n" +
+				");",
+			dialect: "mysql",
+			want: map[string]column{
+				"id":   {},
+				"path": {def: `C:\`, comment: "it's a path"},
+				"name": {comment: "名称"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		schema, err := ParseSQL(tt.ddl)
+		if err != nil {
+			t.Fatalf("%s: ParseSQL() unexpected error: %v", tt.name, err)
+		}
+		if schema.Dialect != tt.dialect {
+			t.Fatalf("%s: Dialect = %q, want %q", tt.name, schema.Dialect, tt.dialect)
+		}
+
+		got := make(map[string]column)
+		for _, f := range schema.Table("files").Fields {
+			got[f.Name] = column{f.DefaultValue, f.Comment}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: columns = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestParseSQLEnum 验证 MySQL ENUM 列与 PostgreSQL CREATE TYPE ... AS ENUM 的取值解析
+func TestParseSQLEnum(t *testing.T) {
+	mysqlDDL := "CREATE TABLE `orders` (`id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY, " +
+		"`state` enum('pending','in progress','it''s done') NOT NULL);"
+	pgDDL := `CREATE TYPE public.order_state AS ENUM ('pending', 'paid');
+CREATE TABLE orders (id bigserial PRIMARY KEY, state order_state NOT NULL DEFAULT 'pending');`
+
+	tests := []struct {
+		ddl  string
+		want []string
+	}{
+		{mysqlDDL, []string{"pending", "in progress", "it's done"}},
+		{pgDDL, []string{"pending", "paid"}},
+	}
+
+	for _, tt := range tests {
+		schema, err := ParseSQL(tt.ddl)
+		if err != nil {
+			t.Fatalf("ParseSQL() unexpected error: %v", err)
+		}
+		state := schema.Table("orders").Fields[1]
+		if !reflect.DeepEqual(state.EnumValues, tt.want) {
+			t.Errorf("%s: EnumValues = %q, want %q", schema.Dialect, state.EnumValues, tt.want)
+		}
+
+		enums := applyEnums(schema.Table("orders"), (*GenConfig)(nil).table("orders"))
+		if len(enums) != 1 || enums[0].TypeName != "OrdersState" || schema.Table("orders").Fields[1].GoType != "OrdersState" {
+			t.Errorf("%s: applyEnums() = %+v", schema.Dialect, enums)
+		}
+	}
+}
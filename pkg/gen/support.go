@@ -6,7 +6,7 @@ import (
 	"path/filepath"
 )
 
-// GenerateSupportPackages 生成支持包（cache, model, response）
+// GenerateSupportPackages 生成支持包（cache, model, response, middleware）
 func (g *DatabaseGenerator) GenerateSupportPackages() error {
 	modulePath := getModulePath(g.config.Module)
 
@@ -32,6 +32,11 @@ func (g *DatabaseGenerator) GenerateSupportPackages() error {
 		return err
 	}
 
+	// 5. pkg/httpx/middleware
+	if err := g.generateMiddlewarePackage(); err != nil {
+		return err
+	}
+
 	fmt.Println("     ✓ 所有支持包创建成功")
 	return nil
 }
@@ -45,48 +50,99 @@ func (g *DatabaseGenerator) generateCachePackage(modulePath string) error {
 
 	outputPath := filepath.Join(outputDir, "cache.go")
 
-	content := fmt.Sprintf(`package cache
+	content := `package cache
 
 import (
 	"context"
+	"errors"
+	"path"
+	"sync"
 	"time"
 )
 
-// Cache 简单的缓存接口
+// ErrNotFound 缓存中没有该键或已过期
+var ErrNotFound = errors.New("cache: key not found")
+
+// Cache 进程内缓存，保存对象本身（不序列化），过期的键在读取时清除
+//
+// 多实例部署时可以替换为 Redis 实现，保持 Get/Set/Delete/DeleteByPattern 方法签名不变即可
 type Cache struct {
-	// TODO: 实现 Redis 缓存
-	// 这里提供一个简单实现，实际使用时可以替换为 Redis
+	mu    sync.RWMutex
+	items map[string]item
+}
+
+// item 缓存项
+type item struct {
+	value    interface{}
+	expireAt time.Time // 零值表示不过期
 }
 
 // New 创建一个新的缓存实例
 func New() *Cache {
-	return &Cache{}
+	return &Cache{items: make(map[string]item)}
 }
 
-// Set 设置缓存
+// Set 设置缓存，expiration 为 0 表示不过期
 func (c *Cache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	// TODO: 实现 Redis SET
+	it := item{value: value}
+	if expiration > 0 {
+		it.expireAt = time.Now().Add(expiration)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = it
 	return nil
 }
 
-// Get 获取缓存
-func (c *Cache) Get(ctx context.Context, key string) (string, error) {
-	// TODO: 实现 Redis GET
-	return "", nil
+// Get 获取缓存，不存在或已过期时返回 ErrNotFound
+func (c *Cache) Get(ctx context.Context, key string) (interface{}, error) {
+	c.mu.RLock()
+	it, ok := c.items[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !it.expireAt.IsZero() && time.Now().After(it.expireAt) {
+		_ = c.Delete(ctx, key)
+		return nil, ErrNotFound
+	}
+	return it.value, nil
+}
+
+// Delete 删除缓存
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	return nil
 }
 
-// Del 删除缓存
+// Del 删除缓存（Delete 的别名）
 func (c *Cache) Del(ctx context.Context, keys ...string) error {
-	// TODO: 实现 Redis DEL
+	return c.Delete(ctx, keys...)
+}
+
+// DeleteByPattern 删除匹配通配符的缓存，如 user:list:*
+func (c *Cache) DeleteByPattern(ctx context.Context, pattern string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.items {
+		if ok, _ := path.Match(pattern, key); ok {
+			delete(c.items, key)
+		}
+	}
 	return nil
 }
 
-// Close 关闭缓存连接
+// Close 关闭缓存
 func (c *Cache) Close() error {
-	// TODO: 关闭 Redis 连接
 	return nil
 }
-`)
+`
 
 	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("写入 cache.go 失败: %w", err)
@@ -203,6 +259,15 @@ func Error(c *gin.Context, httpStatus int, message string) {
 	})
 }
 
+// ErrorWithData 返回带数据的错误响应，如批量操作中每条记录的错误
+func ErrorWithData(c *gin.Context, httpStatus int, message string, data interface{}) {
+	c.JSON(httpStatus, gin.H{
+		"code":    -1,
+		"message": message,
+		"data":    data,
+	})
+}
+
 // ErrorWithCode 返回带错误码的响应
 func ErrorWithCode(c *gin.Context, code int, message string) {
 	c.JSON(http.StatusOK, gin.H{
@@ -224,6 +289,20 @@ func Page(c *gin.Context, total int64, list interface{}, page, size int) {
 		},
 	})
 }
+
+// CursorPaginated 返回游标分页响应（不统计总数），nextCursor 为空表示没有下一页
+func CursorPaginated(c *gin.Context, list interface{}, nextCursor string, size int) {
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "success",
+		"data": gin.H{
+			"list":        list,
+			"next_cursor": nextCursor,
+			"has_more":    nextCursor != "",
+			"page_size":   size,
+		},
+	})
+}
 `)
 
 	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
@@ -232,3 +311,54 @@ func Page(c *gin.Context, total int64, list interface{}, page, size int) {
 
 	return nil
 }
+
+// generateMiddlewarePackage 生成 pkg/httpx/middleware 包（权限校验钩子，供永久删除等敏感操作使用）
+func (g *DatabaseGenerator) generateMiddlewarePackage() error {
+	outputDir := filepath.Join(g.config.Output, "pkg", "httpx", "middleware")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(outputDir, "permission.go")
+
+	content := `package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionChecker 权限校验函数，由项目在启动时设置（如从 JWT 中取用户角色判断）。
+// 未设置时所有权限校验都不通过，避免敏感操作在未接入鉴权时被任意调用。
+var PermissionChecker func(c *gin.Context, permission string) bool
+
+// HasPermission 当前请求是否拥有指定权限码，如 users:hard_delete
+func HasPermission(c *gin.Context, permission string) bool {
+	if PermissionChecker == nil {
+		return false
+	}
+	return PermissionChecker(c, permission)
+}
+
+// RequirePermission 权限校验中间件，没有权限时返回 403
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    -1,
+				"message": "权限不足",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+`
+
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("写入 permission.go 失败: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,502 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// sampleValue 测试中使用的示例值字面量（同时是合法的 JSON 值与路径参数），
+// 无法构造的类型（指针、时间、枚举等）返回空
+func sampleValue(f KeyField) string {
+	switch {
+	case f.Pointer:
+		return ""
+	case f.IsString():
+		return `"a"`
+	case f.Zero() == "0":
+		return "1"
+	case f.GoType == "bool":
+		return "true"
+	}
+	return ""
+}
+
+// sampleKey 主键的每一列都能构造示例值，才能生成 GetByID/Update 等按主键查询的测试
+func sampleKey(key PrimaryKey) bool {
+	for _, f := range key.Fields {
+		if sampleValue(f) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// duplicateFinder 第一个所有列都能构造示例值的唯一索引，用于生成唯一约束冲突的测试；没有时返回 nil
+func duplicateFinder(uniques []IndexFinder) *IndexFinder {
+	for i, u := range uniques {
+		ok := len(u.Fields) > 0
+		for _, f := range u.Fields {
+			if sampleValue(f) == "" {
+				ok = false
+			}
+		}
+		if ok {
+			return &uniques[i]
+		}
+	}
+	return nil
+}
+
+// testFuncMap 测试模板的辅助函数
+var testFuncMap = template.FuncMap{
+	"ToLowerCamelCase": toLowerCamelCase,
+	"Sample":           sampleValue,
+	// SampleArgs 主键示例值的调用参数，如 1 或 1, "a"
+	"SampleArgs": func(fields []KeyField) string {
+		args := make([]string, len(fields))
+		for i, f := range fields {
+			args[i] = sampleValue(f)
+		}
+		return strings.Join(args, ", ")
+	},
+	// SamplePath 主键示例值的路径，如 /1 或 /1/a
+	"SamplePath": func(fields []KeyField) string {
+		var b strings.Builder
+		for _, f := range fields {
+			b.WriteString("/" + strings.Trim(sampleValue(f), `"`))
+		}
+		return b.String()
+	},
+	// InvalidPath 第一个整数主键列取非法值的路径，如 /abc；主键都是字符串时返回空
+	"InvalidPath": func(fields []KeyField) string {
+		var b strings.Builder
+		invalid := false
+		for _, f := range fields {
+			if !invalid && !f.IsString() {
+				b.WriteString("/abc")
+				invalid = true
+				continue
+			}
+			b.WriteString("/" + strings.Trim(sampleValue(f), `"`))
+		}
+		if !invalid {
+			return ""
+		}
+		return b.String()
+	},
+}
+
+// GenerateServiceTest 生成 Service 的表驱动测试（internal/service/<model>_test.go），
+// 使用 internal/repository/mock 中的 Mock 仓储，覆盖记录不存在、缓存命中与未命中、唯一约束冲突等路径
+func (g *DatabaseGenerator) GenerateServiceTest(config ServiceConfig) error {
+	if !sampleKey(config.Key) {
+		fmt.Printf("     ⚠️  %s 的主键无法构造测试数据，跳过生成 Service 测试\n", config.ModelName)
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"ModelName":  config.ModelName,
+		"ModulePath": config.ModulePath,
+		"WithCache":  config.WithCache,
+		"Key":        config.Key,
+		"Version":    config.Version,
+		"Uniques":    config.Uniques,
+		"Duplicate":  duplicateFinder(config.Uniques),
+	}
+	outputPath := filepath.Join(g.config.Output, "internal/service", strings.ToLower(config.ModelName)+"_test.go")
+	return renderTestTemplate(outputPath, serviceTestTmpl, data)
+}
+
+// GenerateControllerTest 生成 Controller 的 httptest 测试（internal/controller/<model>_test.go）
+func (g *DatabaseGenerator) GenerateControllerTest(config ControllerConfig) error {
+	if !sampleKey(config.Key) {
+		fmt.Printf("     ⚠️  %s 的主键无法构造测试数据，跳过生成 Controller 测试\n", config.ModelName)
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"ModelName":  config.ModelName,
+		"ModulePath": config.ModulePath,
+		"WithCache":  config.WithCache,
+		"Key":        config.Key,
+		"Uniques":    config.Uniques,
+		"Duplicate":  duplicateFinder(config.Uniques),
+	}
+	outputPath := filepath.Join(g.config.Output, "internal/controller", strings.ToLower(config.ModelName)+"_test.go")
+	return renderTestTemplate(outputPath, controllerTestTmpl, data)
+}
+
+// renderTestTemplate 渲染测试模板并格式化后写入文件
+func renderTestTemplate(outputPath, tmpl string, data map[string]interface{}) error {
+	t, err := template.New(filepath.Base(outputPath)).Funcs(testFuncMap).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("解析测试模板失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return fmt.Errorf("执行测试模板失败: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("格式化 %s 失败: %w", filepath.Base(outputPath), err)
+	}
+	if err := os.WriteFile(outputPath, src, 0644); err != nil {
+		return fmt.Errorf("写入测试文件失败: %w", err)
+	}
+	return nil
+}
+
+// serviceTestTmpl Service 测试模板
+const serviceTestTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Test: {{.ModelName}}Service
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	"{{.ModulePath}}/internal/dal/model"
+	{{- if .Version}}
+	"{{.ModulePath}}/internal/repository"
+	{{- end}}
+	"{{.ModulePath}}/internal/repository/mock"
+	{{- if .WithCache}}
+	"{{.ModulePath}}/pkg/cache"
+	{{- end}}
+)
+
+{{- $m := .ModelName}}
+{{- $v := ToLowerCamelCase .ModelName}}
+
+// new{{$m}}TestService 使用 Mock 仓储创建 {{$m}}Service
+func new{{$m}}TestService(repo *mock.{{$m}}Repo) *{{$m}}Service {
+	return New{{$m}}Service(repo, nil{{if .WithCache}}, cache.New(){{end}})
+}
+
+// new{{$m}}TestRepo 创建 Mock 仓储：GetByID 返回主键为示例值的记录，唯一索引查询返回记录不存在，写操作成功
+func new{{$m}}TestRepo() *mock.{{$m}}Repo {
+	return &mock.{{$m}}Repo{
+		GetByIDFunc: func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error) {
+			return &model.{{$m}}{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{$f.Var}}{{end -}} }, nil
+		},
+		CreateFunc: func(ctx context.Context, {{$v}} *model.{{$m}}) error {
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, {{$v}} *model.{{$m}}) error {
+			return nil
+		},
+{{- range .Uniques}}
+		{{.CheckMethod}}Func: func(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$m}}, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+{{- end}}
+	}
+}
+
+func Test{{$m}}Service_GetByID(t *testing.T) {
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		err     error // Mock GetByID 返回的错误
+		wantErr error
+	}{
+		{"found", nil, nil},
+		{"not found", gorm.ErrRecordNotFound, Err{{$m}}NotFound},
+		{"repo error", errDB, errDB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new{{$m}}TestRepo()
+			if tt.err != nil {
+				repo.GetByIDFunc = func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error) {
+					return nil, tt.err
+				}
+			}
+
+			got, err := new{{$m}}TestService(repo).GetByID(context.Background(), {{SampleArgs .Key.Fields}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetByID() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got == nil {
+				t.Fatal("GetByID() returned nil {{$m}}")
+			}
+		})
+	}
+}
+{{- if .WithCache}}
+
+func Test{{$m}}Service_GetByIDCache(t *testing.T) {
+	repo := new{{$m}}TestRepo()
+	svc := new{{$m}}TestService(repo)
+	ctx := context.Background()
+
+	// 第一次未命中缓存查询仓储，第二次命中缓存
+	for i := 0; i < 2; i++ {
+		if _, err := svc.GetByID(ctx, {{SampleArgs .Key.Fields}}); err != nil {
+			t.Fatalf("GetByID() unexpected error: %v", err)
+		}
+	}
+	if n := repo.Calls("GetByID"); n != 1 {
+		t.Errorf("GetByID() queried repository %d times, want 1 (cache hit)", n)
+	}
+
+	// 更新后缓存失效，再次查询仓储
+	if err := svc.Update(ctx, &model.{{$m}}{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{Sample $f}}{{end -}} }); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	calls := repo.Calls("GetByID")
+	if _, err := svc.GetByID(ctx, {{SampleArgs .Key.Fields}}); err != nil {
+		t.Fatalf("GetByID() unexpected error: %v", err)
+	}
+	if repo.Calls("GetByID") != calls+1 {
+		t.Error("GetByID() after Update() should miss the cache")
+	}
+}
+{{- end}}
+
+func Test{{$m}}Service_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *model.{{$m}}
+		setup   func(repo *mock.{{$m}}Repo)
+		wantErr bool
+		errIs   error
+	}{
+		{name: "ok", input: &model.{{$m}}{}},
+		{name: "nil input", input: nil, wantErr: true},
+{{- with .Duplicate}}
+		{
+			name:  "duplicate {{.Columns}}",
+			input: &model.{{$m}}{ {{- range $i, $f := .Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{Sample $f}}{{end -}} },
+			setup: func(repo *mock.{{$m}}Repo) {
+				repo.{{.CheckMethod}}Func = func(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$m}}, error) {
+					return &model.{{$m}}{}, nil
+				}
+			},
+			wantErr: true,
+			errIs:   Err{{$m}}AlreadyExists,
+		},
+{{- end}}
+		{
+			name:  "repo error",
+			input: &model.{{$m}}{},
+			setup: func(repo *mock.{{$m}}Repo) {
+				repo.CreateFunc = func(ctx context.Context, {{$v}} *model.{{$m}}) error {
+					return gorm.ErrInvalidData
+				}
+			},
+			wantErr: true,
+			errIs:   gorm.ErrInvalidData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new{{$m}}TestRepo()
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			err := new{{$m}}TestService(repo).Create(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.errIs)
+			}
+{{- if .Version}}
+			if !tt.wantErr && tt.input.{{.Version.FieldName}} != 1 {
+				t.Errorf("Create() {{.Version.Column}} = %d, want 1", tt.input.{{.Version.FieldName}})
+			}
+{{- end}}
+		})
+	}
+}
+
+func Test{{$m}}Service_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *model.{{$m}}
+		setup   func(repo *mock.{{$m}}Repo)
+		wantErr error
+	}{
+		{name: "ok", input: &model.{{$m}}{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{Sample $f}}{{end -}} }},
+		{
+			name:  "not found",
+			input: &model.{{$m}}{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{Sample $f}}{{end -}} },
+			setup: func(repo *mock.{{$m}}Repo) {
+				repo.GetByIDFunc = func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error) {
+					return nil, gorm.ErrRecordNotFound
+				}
+			},
+			wantErr: Err{{$m}}NotFound,
+		},
+{{- if .Version}}
+		{
+			name:  "stale {{.Version.Column}}",
+			input: &model.{{$m}}{ {{- range .Key.Fields}}{{.FieldName}}: {{Sample .}}, {{end}}{{.Version.FieldName}}: 1},
+			setup: func(repo *mock.{{$m}}Repo) {
+				repo.GetByIDFunc = func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error) {
+					return &model.{{$m}}{ {{- range .Key.Fields}}{{.FieldName}}: {{.Var}}, {{end}}{{.Version.FieldName}}: 2}, nil
+				}
+			},
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			name:  "concurrent update",
+			input: &model.{{$m}}{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{Sample $f}}{{end -}} },
+			setup: func(repo *mock.{{$m}}Repo) {
+				repo.UpdateFunc = func(ctx context.Context, {{$v}} *model.{{$m}}) error {
+					return repository.ErrVersionConflict
+				}
+			},
+			wantErr: ErrConcurrentModification,
+		},
+{{- end}}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new{{$m}}TestRepo()
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			err := new{{$m}}TestService(repo).Update(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+`
+
+// controllerTestTmpl Controller 测试模板
+const controllerTestTmpl = `// Code generated by go-start. DO NOT EDIT.
+// Test: {{.ModelName}}Controller
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"{{.ModulePath}}/internal/dal/model"
+	"{{.ModulePath}}/internal/repository/mock"
+	"{{.ModulePath}}/internal/service"
+	{{- if .WithCache}}
+	"{{.ModulePath}}/pkg/cache"
+	{{- end}}
+)
+
+{{- $m := .ModelName}}
+{{- $v := ToLowerCamelCase .ModelName}}
+
+// new{{$m}}TestRouter 使用 Mock 仓储创建挂载了 {{$m}}Controller 的路由
+func new{{$m}}TestRouter(repo *mock.{{$m}}Repo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	c := New{{$m}}Controller(service.New{{$m}}Service(repo, nil{{if .WithCache}}, cache.New(){{end}}))
+
+	r := gin.New()
+	r.POST("/{{$v}}s", c.Create)
+	r.GET("/{{$v}}s{{.Key.Route}}", c.GetByID)
+	return r
+}
+
+func Test{{$m}}Controller_GetByID(t *testing.T) {
+	found := func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error) {
+		return &model.{{$m}}{ {{- range $i, $f := .Key.Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{$f.Var}}{{end -}} }, nil
+	}
+	notFound := func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		getByID    func(ctx context.Context, {{.Key.Params}}, preloads ...string) (*model.{{$m}}, error)
+		wantStatus int
+	}{
+		{"found", "/{{$v}}s{{SamplePath .Key.Fields}}", found, http.StatusOK},
+		{"not found", "/{{$v}}s{{SamplePath .Key.Fields}}", notFound, http.StatusNotFound},
+{{- with InvalidPath .Key.Fields}}
+		{"invalid key", "/{{$v}}s{{.}}", found, http.StatusBadRequest},
+{{- end}}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := new{{$m}}TestRouter(&mock.{{$m}}Repo{GetByIDFunc: tt.getByID})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GET %s status = %d, want %d, body: %s", tt.path, w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test{{$m}}Controller_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(repo *mock.{{$m}}Repo)
+		wantStatus int
+	}{
+		{name: "invalid json", body: "{", wantStatus: http.StatusBadRequest},
+		{name: "ok", body: "{}", wantStatus: http.StatusOK},
+{{- with .Duplicate}}
+		{
+			name: "duplicate {{.Columns}}",
+			body: ` + "`" + `{ {{- range $i, $f := .Fields}}{{if $i}}, {{end}}"{{$f.Column}}": {{Sample $f}}{{end -}} }` + "`" + `,
+			setup: func(repo *mock.{{$m}}Repo) {
+				repo.{{.CheckMethod}}Func = func(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$m}}, error) {
+					return &model.{{$m}}{}, nil
+				}
+			},
+			wantStatus: http.StatusConflict,
+		},
+{{- end}}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mock.{{$m}}Repo{
+				CreateFunc: func(ctx context.Context, {{$v}} *model.{{$m}}) error {
+					return nil
+				},
+{{- range .Uniques}}
+				{{.CheckMethod}}Func: func(ctx context.Context, {{.Params}}, preloads ...string) (*model.{{$m}}, error) {
+					return nil, gorm.ErrRecordNotFound
+				},
+{{- end}}
+			}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			r := new{{$m}}TestRouter(repo)
+
+			req := httptest.NewRequest(http.MethodPost, "/{{$v}}s", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("POST /{{$v}}s status = %d, want %d, body: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+`
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"os"
@@ -12,6 +13,8 @@ import (
 	"gorm.io/gen"
 	"gorm.io/gorm"
 
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 )
@@ -26,14 +29,17 @@ type TableInfo struct {
 
 // FieldInfo 字段信息
 type FieldInfo struct {
-	Name          string // 字段名
-	Type          string // 数据库类型
-	GoType        string // Go 类型
-	Nullable      bool   // 是否可为空
-	PrimaryKey    bool   // 是否主键
-	AutoIncrement bool   // 是否自增
-	DefaultValue  string // 默认值
-	Comment       string // 注释
+	Name          string        // 字段名
+	Type          string        // 数据库类型
+	ColumnType    string        // 完整的列类型（含长度/精度/unsigned，如 tinyint(1)、int unsigned）
+	GoType        string        // Go 类型
+	Nullable      bool          // 是否可为空
+	PrimaryKey    bool          // 是否主键
+	AutoIncrement bool          // 是否自增
+	DefaultValue  string        // 默认值
+	Comment       string        // 注释
+	EnumValues    []string      // 枚举取值（MySQL ENUM 列、PostgreSQL 枚举类型）
+	Enum          *typemap.Enum // 由 EnumValues 生成的 Go 枚举类型
 }
 
 // IndexInfo 索引信息
@@ -44,18 +50,33 @@ type IndexInfo struct {
 	Primary bool     // 是否主键索引
 }
 
+// ForeignKeyInfo 外键信息
+type ForeignKeyInfo struct {
+	Name       string   // 约束名
+	Columns    []string // 本表的外键列
+	RefTable   string   // 引用的表
+	RefColumns []string // 引用表的列，为空表示引用主键
+}
+
 // Config 生成器配置
 type Config struct {
-	DSN     string   // 数据库连接字符串
-	Tables  []string // 要生成的表名
-	Output  string   // 输出目录
-	SQLFile string   // SQL 文件路径（用于 SQL 生成器）
-	Module  string   // Go 模块路径
+	DSN     string     // 数据库连接字符串
+	Tables  []string   // 要生成的表名
+	Output  string     // 输出目录
+	SQLFile string     // SQL 文件路径（用于 SQL 生成器）
+	Module  string     // Go 模块路径
+	OpenAPI bool       // 是否生成 OpenAPI 文档与 Swagger UI
+	Client  bool       // 是否生成 Go 客户端包
+	Options *GenConfig // gen.yaml 中的默认值与表级/列级覆盖（可选）
 }
 
 // DatabaseGenerator 数据库代码生成器
 type DatabaseGenerator struct {
-	config Config
+	config    Config
+	schemas   map[string]*DetailedTableInfo // 已读取的表结构（SQL 文件解析结果或数据库读取结果的缓存）
+	relations map[string]*tableRelations    // 由外键推导的关联
+
+	goModCreated bool // go.mod 由本次生成，代码生成完成后运行 go mod tidy
 }
 
 // NewDatabaseGenerator 创建数据库代码生成器
@@ -80,25 +101,25 @@ func (g *DatabaseGenerator) Generate() error {
 		return fmt.Errorf("连接数据库失败: %w", err)
 	}
 
-	// 2. 创建 GORM Gen 生成器
+	// 2. 创建 GORM Gen 生成器（可空列由类型映射处理，见 dataTypeMap）
 	generator := gen.NewGenerator(gen.Config{
 		OutPath:       filepath.Join(g.config.Output, "internal/dal/query"),
 		Mode:          gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
-		FieldNullable: true,
+		FieldNullable: false,
 		FieldSignable: false,
 		// FieldWithIndexTag: false,
 		// FieldWithTypeTag: true,
 	})
 
 	generator.UseDB(db)
+	generator.WithDataTypeMap(dataTypeMap(g.config.Options.types()))
+	generator.WithImportPkgPath(append([]string{}, typeImportPaths...)...)
 
 	// 3. 为每个表生成模型
 	fmt.Println("📦 正在读取表结构...")
 	var models []interface{}
 
 	for _, tableName := range g.config.Tables {
-		fmt.Printf("  📋 处理表: %s\n", tableName)
-
 		// 🔧 检查表是否存在
 		if !g.tableExists(db, tableName) {
 			return fmt.Errorf("❌ 表 '%s' 不存在\n\n💡 帮助:\n%s\n📋 数据库中的表:\n%s",
@@ -106,67 +127,257 @@ func (g *DatabaseGenerator) Generate() error {
 				g.getHint(),
 				g.listTables(db))
 		}
+	}
 
-		// 使用 GORM Gen 自动生成模型
-		model := generator.GenerateModel(tableName)
+	if err := g.checkModelNames(); err != nil {
+		return err
+	}
+
+	// 根据外键推导表之间的关联（belongsTo/hasMany/many2many）
+	g.loadRelations()
+
+	for _, tableName := range g.config.Tables {
+		fmt.Printf("  📋 处理表: %s\n", tableName)
+
+		// 使用 GORM Gen 自动生成模型，模型名与分层代码保持一致
+		opts := g.options(tableName)
+		modelOptions := append(modelOpts(opts), relationOpts(g.relationsOf(tableName))...)
+		if schema := g.schemas[tableName]; schema != nil {
+			modelOptions = append(modelOptions, enumOpts(schema)...)
+		}
+		model := generator.GenerateModelAs(tableName, opts.ModelName, modelOptions...)
 		models = append(models, model)
 	}
 
 	// 4. 执行 GORM Gen 生成
+	if err := g.executeGen(generator, models); err != nil {
+		return err
+	}
+
+	// 5. 生成分层代码与项目文件
+	return g.generateLayers()
+}
+
+// executeGen 生成 go.mod 后执行 GORM Gen，数据库与 SQL 文件两种来源共用
+//
+// GORM Gen 通过 go.mod 解析 model 包的导入路径，go.mod 不存在时生成的 query 代码缺少 model 包的 import
+func (g *DatabaseGenerator) executeGen(generator *gen.Generator, models []interface{}) error {
+	if err := g.GenerateGoMod(); err != nil {
+		return fmt.Errorf("生成 go.mod 失败: %w", err)
+	}
+
 	fmt.Println("🚀 正在生成 GORM 查询代码...")
 	generator.ApplyBasic(models...)
 	generator.Execute()
 
+	queryDir := filepath.Join(g.config.Output, "internal/dal/query")
+	if err := trimGenHeader(queryDir, filepath.Join(g.config.Output, "internal/dal/model")); err != nil {
+		return err
+	}
+
 	fmt.Println("✅ GORM Gen 代码生成完成！")
-	fmt.Printf("   生成位置: %s\n", filepath.Join(g.config.Output, "internal/dal/query"))
+	fmt.Printf("   生成位置: %s\n", queryDir)
+	return nil
+}
+
+// genHeader GORM Gen 的模板在生成文件开头把这行声明重复写了三遍
+const genHeader = "// Code generated by gorm.io/gen. DO NOT EDIT.\n"
 
-	// 5. 生成 Repository 层
+// trimGenHeader 把 GORM Gen 生成文件开头重复的声明合并为一行
+func trimGenHeader(dirs ...string) error {
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("读取 %s 失败: %w", file, err)
+			}
+			trimmed := content
+			for bytes.HasPrefix(trimmed, []byte(genHeader+genHeader)) {
+				trimmed = trimmed[len(genHeader):]
+			}
+			if len(trimmed) == len(content) {
+				continue
+			}
+			if err := os.WriteFile(file, trimmed, 0644); err != nil {
+				return fmt.Errorf("写入 %s 失败: %w", file, err)
+			}
+		}
+	}
+	return nil
+}
+
+// generateLayers 在 GORM Gen 生成 model/query 之后生成 Repository、Service、Controller、
+// 路由及项目支持文件，数据库与 SQL 文件两种来源共用
+func (g *DatabaseGenerator) generateLayers() error {
+	// 0. 生成枚举类型（与 model 同包）
+	if err := g.generateEnums(); err != nil {
+		return fmt.Errorf("生成枚举类型失败: %w", err)
+	}
+
+	// 1. 生成 Repository 层
 	fmt.Println("\n📦 正在生成 Repository 层...")
 	if err := g.generateRepositoryLayer(); err != nil {
 		return fmt.Errorf("生成 Repository 层失败: %w", err)
 	}
 
-	// 6. 生成 Service 层
+	// 2. 生成 Service 层
 	fmt.Println("\n📦 正在生成 Service 层...")
 	if err := g.generateServiceLayer(); err != nil {
 		return fmt.Errorf("生成 Service 层失败: %w", err)
 	}
 
-	// 7. 生成 Controller 层
+	// 3. 生成 Controller 层
 	fmt.Println("\n📦 正在生成 Controller 层...")
 	if err := g.generateControllerLayer(); err != nil {
 		return fmt.Errorf("生成 Controller 层失败: %w", err)
 	}
 
-	// 8. 生成 go.mod
-	fmt.Println("\n📦 正在生成 go.mod...")
-	if err := g.GenerateGoMod(); err != nil {
-		return fmt.Errorf("生成 go.mod 失败: %w", err)
-	}
-
-	// 9. 生成支持包
+	// 4. 生成支持包
 	fmt.Println("\n📦 正在生成支持包...")
 	if err := g.GenerateSupportPackages(); err != nil {
 		return fmt.Errorf("生成支持包失败: %w", err)
 	}
 
-	// 10. 生成 main.go 和配置文件
+	// 5. 生成 main.go 和配置文件
 	fmt.Println("\n📦 正在生成 main.go...")
 	if err := g.GenerateMainGo(); err != nil {
 		return fmt.Errorf("生成 main.go 失败: %w", err)
 	}
 
-	// 11. 生成路由注册
+	// 6. 生成 OpenAPI 文档（路由注册时引用 docs 包）
+	if g.openAPI() {
+		fmt.Println("\n📦 正在生成 OpenAPI 文档...")
+		if err := g.GenerateOpenAPI(); err != nil {
+			return fmt.Errorf("生成 OpenAPI 文档失败: %w", err)
+		}
+	}
+
+	// 7. 生成路由注册
 	fmt.Println("\n📦 正在生成路由注册...")
-	if err := g.GenerateRoutes(getTablesFromNames(g.config.Tables), getModulePath(g.config.Module)); err != nil {
+	if err := g.GenerateRoutes(getTablesFromNames(g.tablesWithLayer(LayerRoutes)), getModulePath(g.config.Module)); err != nil {
 		return fmt.Errorf("生成路由失败: %w", err)
 	}
 
+	// 8. 生成 Go 客户端包
+	if g.client() {
+		fmt.Println("\n📦 正在生成 Go 客户端...")
+		if err := g.GenerateClient(); err != nil {
+			return fmt.Errorf("生成 Go 客户端失败: %w", err)
+		}
+	}
+
+	g.tidyGoMod()
+
 	fmt.Println("\n✅ 所有代码生成完成！")
 
 	return nil
 }
 
+// options 获取表合并默认值后的生成配置
+//
+// 主键无法从路径参数解析（如 time.Time、[]byte）的表不生成 Controller 与路由
+func (g *DatabaseGenerator) options(tableName string) tableOptions {
+	opts := g.config.Options.table(tableName)
+	if schema, ok := g.schemas[tableName]; ok && !buildPrimaryKey(schema, opts).routable() {
+		delete(opts.Layers, LayerController)
+		delete(opts.Layers, LayerRoutes)
+	}
+	return opts
+}
+
+// checkModelNames 检查各表的模型名互不相同：模型名不含 schema，a.users 与 b.users 会生成同名文件并互相覆盖
+func (g *DatabaseGenerator) checkModelNames() error {
+	tables := make(map[string]string)
+	for _, tableName := range g.config.Tables {
+		name := g.config.Options.table(tableName).ModelName
+		if other, ok := tables[name]; ok {
+			return fmt.Errorf("表 %s 与 %s 的模型名都是 %s，请在 gen.yaml 的 overrides 中为其中一张表指定 model", other, tableName, name)
+		}
+		tables[name] = tableName
+	}
+	return nil
+}
+
+// loadRelations 读取所有表的外键并推导关联，无法读取结构的表不参与关联
+func (g *DatabaseGenerator) loadRelations() {
+	var tables []string
+	for _, tableName := range g.config.Tables {
+		schema, err := g.tableSchema(tableName)
+		if err != nil {
+			fmt.Printf("  ⚠️  无法获取 %s 的外键信息，跳过关联生成: %v\n", tableName, err)
+			continue
+		}
+		tables = append(tables, tableName)
+
+		if key := buildPrimaryKey(schema, g.config.Options.table(tableName)); len(key.Fields) == 0 {
+			fmt.Printf("  ⚠️  表 %s 没有主键，按 id uint 生成 GetByID/Update/Delete\n", tableName)
+		} else if !key.routable() {
+			fmt.Printf("  ⚠️  表 %s 的主键无法作为路径参数，跳过 Controller 与路由生成\n", tableName)
+		}
+		if opts := g.config.Options.table(tableName); opts.Pagination == PaginationCursor && cursorColumn(schema, opts) == nil {
+			fmt.Printf("  ⚠️  表 %s 的主键不是单列整数或字符串，无法游标分页，List 按 offset 分页生成\n", tableName)
+		}
+		if opts := g.config.Options.table(tableName); opts.VersionColumn != defaultVersionColumn && opts.VersionColumn != "-" && versionColumn(schema, opts) == nil {
+			fmt.Printf("  ⚠️  表 %s 没有整数列 %s，不启用乐观锁\n", tableName, opts.VersionColumn)
+		}
+	}
+
+	g.relations = buildRelations(tables, g.schemas, g.options)
+
+	for _, tableName := range tables {
+		for _, rel := range g.relations[tableName].Relations {
+			fmt.Printf("  🔗 %s.%s -> %s (%s)\n", g.options(tableName).ModelName, rel.FieldName, rel.ModelName, rel.Kind)
+		}
+	}
+}
+
+// relationsOf 获取表的关联信息，没有关联时返回空值
+func (g *DatabaseGenerator) relationsOf(tableName string) *tableRelations {
+	if rels, ok := g.relations[tableName]; ok {
+		return rels
+	}
+	return &tableRelations{}
+}
+
+// tablesWithLayer 返回需要生成指定代码层的表
+func (g *DatabaseGenerator) tablesWithLayer(layer string) []string {
+	var tables []string
+	for _, tableName := range g.config.Tables {
+		if g.options(tableName).has(layer) {
+			tables = append(tables, tableName)
+		}
+	}
+	return tables
+}
+
+// modelOpts 把列级覆盖转换为 GORM Gen 的模型选项
+func modelOpts(opts tableOptions) []gen.ModelOpt {
+	var result []gen.ModelOpt
+
+	if len(opts.IgnoreColumns) > 0 {
+		result = append(result, gen.FieldIgnore(opts.IgnoreColumns...))
+	}
+
+	for column, col := range opts.Columns {
+		if col.Ignore {
+			result = append(result, gen.FieldIgnore(column))
+			continue
+		}
+		if col.JSON != "" {
+			result = append(result, gen.FieldJSONTag(column, col.JSON))
+		}
+		if col.Type != "" {
+			result = append(result, gen.FieldType(column, col.Type))
+		}
+	}
+
+	return result
+}
+
 // getTablesFromNames 从表名列表创建 TableInfo 列表
 func getTablesFromNames(names []string) []TableInfo {
 	var tables []TableInfo
@@ -211,6 +422,11 @@ func connectGORMDB(dsn string) (*gorm.DB, error) {
 
 // tableExists 检查表是否存在
 func (g *DatabaseGenerator) tableExists(db *gorm.DB, tableName string) bool {
+	if db.Dialector.Name() == "postgres" {
+		sqlDB, err := db.DB()
+		return err == nil && pgTableExists(sqlDB, tableName)
+	}
+
 	var count int64
 	// 查询当前数据库中的表
 	db.Raw("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", tableName).Scan(&count)
@@ -228,7 +444,16 @@ func (g *DatabaseGenerator) getHint() string {
 // listTables 列出数据库中的所有表
 func (g *DatabaseGenerator) listTables(db *gorm.DB) string {
 	var tables []string
-	db.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() ORDER BY table_name").Scan(&tables)
+	if db.Dialector.Name() == "postgres" {
+		if sqlDB, err := db.DB(); err == nil {
+			infos, _ := getTables(sqlDB, "postgres", "")
+			for _, info := range infos {
+				tables = append(tables, info.Name)
+			}
+		}
+	} else {
+		db.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() ORDER BY table_name").Scan(&tables)
+	}
 
 	if len(tables) == 0 {
 		return "  (空)"
@@ -246,38 +471,33 @@ func (g *DatabaseGenerator) generateRepositoryLayer() error {
 	// TODO: 获取模块路径和索引信息
 	// 这里简化处理，实际应该从配置或读取生成的代码获取
 
-	for _, tableName := range g.config.Tables {
-		// 简单的表名转模型名
-		modelName := toModelName(tableName)
+	for _, tableName := range g.tablesWithLayer(LayerRepository) {
+		opts := g.options(tableName)
+		modelName := opts.ModelName
 
 		// 获取表的索引信息
-		schema, err := GetTableSchema(g.config.DSN, tableName)
+		schema, err := g.tableSchema(tableName)
 		if err != nil {
 			fmt.Printf("  ⚠️  无法获取 %s 的索引信息，跳过\n", tableName)
 			continue
 		}
 
-		// 提取索引字段（去重）
-		indexFieldSet := make(map[string]bool)
-		var indexFields []string
-		for _, idx := range schema.Indexes {
-			if !idx.Primary && len(idx.Columns) == 1 {
-				// 只处理单列非主键索引
-				field := toCamelCase(idx.Columns[0])
-				if !indexFieldSet[field] {
-					indexFieldSet[field] = true
-					indexFields = append(indexFields, field)
-				}
-			}
-		}
-
-		// 配置 Repository 生成
+		// 配置 Repository 生成（索引的完整列组合与最左前缀生成查询方法）
+		rels := g.relationsOf(tableName)
 		config := RepositoryConfig{
-			TableName:   tableName,
-			ModelName:   modelName,
-			PackageName: "repository",
-			ModulePath:  getModulePath(g.config.Module),
-			Indexes:     indexFields,
+			TableName:    tableName,
+			ModelName:    modelName,
+			PackageName:  "repository",
+			ModulePath:   getModulePath(g.config.Module),
+			Indexes:      buildIndexFinders(schema, opts, rels.Finders),
+			Key:          buildPrimaryKey(schema, opts),
+			SoftDelete:   hasSoftDelete(schema, opts),
+			ListColumns:  buildListColumns(schema, opts),
+			Cursor:       cursorColumn(schema, opts),
+			PatchColumns: buildPatchColumns(schema, opts),
+			Version:      versionColumn(schema, opts),
+			Relations:    rels.Relations,
+			Finders:      rels.Finders,
 		}
 
 		if err := g.GenerateRepository(TableInfo{Name: tableName}, config); err != nil {
@@ -288,22 +508,45 @@ func (g *DatabaseGenerator) generateRepositoryLayer() error {
 	return nil
 }
 
+// tableSchema 获取表结构，优先使用已解析或已读取的结果
+func (g *DatabaseGenerator) tableSchema(tableName string) (*DetailedTableInfo, error) {
+	if schema, ok := g.schemas[tableName]; ok {
+		return schema, nil
+	}
+
+	schema, err := GetTableSchema(g.config.DSN, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.mapTypes(g.config.Options.types())
+	applyEnums(schema, g.options(tableName))
+
+	if g.schemas == nil {
+		g.schemas = make(map[string]*DetailedTableInfo)
+	}
+	g.schemas[tableName] = schema
+	return schema, nil
+}
+
 // generateServiceLayer 生成 Service 层
 func (g *DatabaseGenerator) generateServiceLayer() error {
-	// TODO: 从配置读取是否启用缓存
-	withCache := true // 默认启用缓存
+	for _, tableName := range g.tablesWithLayer(LayerService) {
+		opts := g.options(tableName)
 
-	for _, tableName := range g.config.Tables {
-		// 简单的表名转模型名
-		modelName := toModelName(tableName)
-
-		// 配置 Service 生成
+		// 配置 Service 生成（缓存默认启用，可在 gen.yaml 中关闭）
 		config := ServiceConfig{
 			TableName:   tableName,
-			ModelName:   modelName,
+			ModelName:   opts.ModelName,
 			PackageName: "service",
 			ModulePath:  getModulePath(g.config.Module),
-			WithCache:   withCache,
+			WithCache:   opts.Cache,
+			Key:         g.primaryKey(tableName),
+			SoftDelete:  g.softDelete(tableName),
+			Cursor:      g.cursor(tableName),
+			Version:     g.version(tableName),
+			Uniques:     g.uniques(tableName),
+			Finders:     g.relationsOf(tableName).Finders,
 		}
 
 		if err := g.GenerateService(TableInfo{Name: tableName}, config); err != nil {
@@ -314,18 +557,44 @@ func (g *DatabaseGenerator) generateServiceLayer() error {
 	return nil
 }
 
+// uniques 表的唯一索引查询方法，Service 在 Create/Update 前据此检查冲突（需要 Repository 生成对应的 By 方法）
+func (g *DatabaseGenerator) uniques(tableName string) []IndexFinder {
+	opts := g.options(tableName)
+	schema, err := g.tableSchema(tableName)
+	if err != nil || !opts.has(LayerRepository) {
+		return nil
+	}
+
+	var uniques []IndexFinder
+	for _, finder := range buildIndexFinders(schema, opts, g.relationsOf(tableName).Finders) {
+		if finder.Unique {
+			uniques = append(uniques, finder)
+		}
+	}
+	return uniques
+}
+
 // generateControllerLayer 生成 Controller 层
 func (g *DatabaseGenerator) generateControllerLayer() error {
-	for _, tableName := range g.config.Tables {
-		// 简单的表名转模型名
-		modelName := toModelName(tableName)
-
-		// 配置 Controller 生成
+	for _, tableName := range g.tablesWithLayer(LayerController) {
+		// 配置 Controller 生成（List 接口文档列出可过滤的列）
+		var listColumns []ListColumn
+		if schema, err := g.tableSchema(tableName); err == nil {
+			listColumns = buildListColumns(schema, g.options(tableName))
+		}
 		config := ControllerConfig{
 			TableName:   tableName,
-			ModelName:   modelName,
+			ModelName:   g.options(tableName).ModelName,
 			PackageName: "controller",
 			ModulePath:  getModulePath(g.config.Module),
+			Key:         g.primaryKey(tableName),
+			SoftDelete:  g.softDelete(tableName),
+			ListColumns: listColumns,
+			Cursor:      g.cursor(tableName),
+			Version:     g.version(tableName),
+			Finders:     g.relationsOf(tableName).Finders,
+			WithCache:   g.options(tableName).Cache,
+			Uniques:     g.uniques(tableName),
 		}
 
 		if err := g.GenerateController(TableInfo{Name: tableName}, config); err != nil {
@@ -385,18 +654,9 @@ func extractModulePath(goModPath string) string {
 func toModelName(tableName string) string {
 	// users -> Users
 	// user_profiles -> UserProfile
+	// billing.invoices -> Invoices（PostgreSQL schema 前缀不参与命名）
+	_, tableName = splitSchemaTable(tableName)
 	parts := strings.Split(tableName, "_")
-	for i, part := range parts {
-		if i > 0 || len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + part[1:]
-		}
-	}
-	return strings.Join(parts, "")
-}
-
-// toCamelCase 转换为驼峰命名
-func toCamelCase(s string) string {
-	parts := strings.Split(s, "_")
 	for i, part := range parts {
 		if len(part) > 0 {
 			parts[i] = strings.ToUpper(part[:1]) + part[1:]
@@ -418,38 +678,115 @@ func NewSQLGenerator(config Config) *SQLGenerator {
 }
 
 // Generate 从 SQL 文件生成代码
+//
+// 解析 DDL 得到表结构后，通过 GORM Gen 的 GenerateModelFrom 离线生成 model/query，
+// 再复用数据库生成器的分层代码生成流程，无需连接数据库。
 func (g *SQLGenerator) Generate() error {
 	fmt.Println("📄 正在解析 SQL 文件...")
 
-	// 1. 读取 SQL 文件
-	_, err := os.ReadFile(g.config.SQLFile)
+	// 0. 检查 Go 版本
+	if err := checkGoVersion(); err != nil {
+		return err
+	}
+
+	// 1. 读取并解析 SQL 文件
+	content, err := os.ReadFile(g.config.SQLFile)
 	if err != nil {
 		return fmt.Errorf("读取 SQL 文件失败: %w", err)
 	}
 
-	// 2. 创建临时数据库并导入 SQL
-	// 注意：这需要一个临时数据库
-	// 简化起见，我们提示用户使用 DatabaseGenerator
+	parsed, err := ParseSQL(string(content))
+	if err != nil {
+		return fmt.Errorf("解析 SQL 文件失败: %w", err)
+	}
+
+	tables, err := g.selectTables(parsed)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🗄️  SQL 方言: %s\n", parsed.Dialect)
+
+	// 2. 创建 GORM Gen 生成器（离线模式）
+	db, err := openOfflineGORMDB(parsed.Dialect)
+	if err != nil {
+		return fmt.Errorf("初始化 GORM 失败: %w", err)
+	}
+
+	generator := gen.NewGenerator(gen.Config{
+		OutPath:       filepath.Join(g.config.Output, "internal/dal/query"),
+		Mode:          gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
+		FieldNullable: false,
+		FieldSignable: false,
+	})
+
+	generator.UseDB(db)
+
+	// 3. 根据外键推导表之间的关联
+	schemas := make(map[string]*DetailedTableInfo)
+	var tableNames []string
+	for _, table := range tables {
+		table.mapTypes(g.config.Options.types())
+		applyEnums(table, g.config.Options.table(table.Name))
+		schemas[table.Name] = table
+		tableNames = append(tableNames, table.Name)
+	}
+
+	config := g.config
+	config.Tables = tableNames
+
+	layers := &DatabaseGenerator{
+		config:  config,
+		schemas: schemas,
+	}
+	if err := layers.checkModelNames(); err != nil {
+		return err
+	}
+	layers.loadRelations()
+
+	// 4. 为每个表生成模型
+	var models []interface{}
+	for _, table := range tables {
+		fmt.Printf("  📋 处理表: %s (%d 个字段, %d 个索引)\n", table.Name, len(table.Fields), len(table.Indexes))
+
+		object := &tableObject{
+			table:     table,
+			opts:      layers.options(table.Name),
+			relations: layers.relationsOf(table.Name).Relations,
+		}
+		models = append(models, generator.GenerateModelFrom(object))
+	}
+
+	// 5. 执行 GORM Gen 生成
+	if err := layers.executeGen(generator, models); err != nil {
+		return err
+	}
+
+	// 6. 生成分层代码与项目文件
+	return layers.generateLayers()
+}
+
+// selectTables 按 --tables 与 gen.yaml 的 tables/exclude 规则筛选解析出的表，未指定时返回全部
+func (g *SQLGenerator) selectTables(parsed *SQLSchema) ([]*DetailedTableInfo, error) {
+	var names []string
+	for _, table := range parsed.Tables {
+		names = append(names, table.Name)
+	}
+
+	selected, err := g.config.Options.ResolveTables(names, g.config.Tables)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("没有需要生成的表，请检查 tables/exclude 配置")
+	}
 
-	fmt.Println("⚠️  SQL 文件生成功能建议使用以下方式：")
-	fmt.Println()
-	fmt.Println("方式一：直接使用数据库生成（推荐）")
-	fmt.Println("  1. 创建数据库并导入 SQL 文件：")
-	fmt.Println("     mysql -u root -p < schema.sql")
-	fmt.Println()
-	fmt.Println("  2. 使用数据库生成命令：")
-	fmt.Printf("     go-start gen db --dsn=\"root:pass@tcp(localhost:3306)/dbname\" --tables=your_tables\n")
-	fmt.Println()
-	fmt.Println("方式二：使用交互式选择")
-	fmt.Println("  go-start gen db --dsn=\"...\" --interactive")
-	fmt.Println()
-	fmt.Println("为什么推荐使用数据库连接？")
-	fmt.Println("  ✅ 可以准确读取表结构")
-	fmt.Println("  ✅ 可以获取索引信息")
-	fmt.Println("  ✅ 可以生成基于索引的查询方法")
-	fmt.Println("  ✅ 支持交互式表选择")
+	var result []*DetailedTableInfo
+	for _, name := range selected {
+		result = append(result, parsed.Table(name))
+	}
 
-	return fmt.Errorf("请使用 DatabaseGenerator 从数据库生成代码")
+	return result, nil
 }
 
 // ListTables 列出数据库中的所有表
@@ -519,18 +856,27 @@ func GetTableSchema(dsn, tableName string) (*DetailedTableInfo, error) {
 		return nil, err
 	}
 
+	// 获取外键信息
+	foreignKeys, err := getForeignKeys(db, dbType, tableName)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DetailedTableInfo{
-		Name:    tableName,
-		Fields:  fields,
-		Indexes: indexes,
+		Name:        tableName,
+		Fields:      fields,
+		Indexes:     indexes,
+		ForeignKeys: foreignKeys,
 	}, nil
 }
 
 // DetailedTableInfo 详细的表信息
 type DetailedTableInfo struct {
-	Name    string      // 表名
-	Fields  []FieldInfo // 字段列表
-	Indexes []IndexInfo // 索引列表
+	Name        string           // 表名
+	Comment     string           // 表注释
+	Fields      []FieldInfo      // 字段列表
+	Indexes     []IndexInfo      // 索引列表
+	ForeignKeys []ForeignKeyInfo // 外键列表
 }
 
 // parseDSN, getTables, getFields, getIndexes, mapToGoType 等函数...
@@ -579,10 +925,7 @@ func getTables(db *sql.DB, dbType, dbName string) ([]TableInfo, error) {
 			ORDER BY TABLE_NAME`
 		args = []interface{}{dbName}
 	} else if dbType == "postgres" {
-		query = `SELECT table_name, obj_description((table_schema||'.'||table_name)::regclass, 'oid') as comment
-			FROM information_schema.tables
-			WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
-			ORDER BY table_name`
+		query = pgTablesQuery
 	}
 
 	rows, err := db.Query(query, args...)
@@ -609,22 +952,16 @@ func getTables(db *sql.DB, dbType, dbName string) ([]TableInfo, error) {
 
 // getFields 获取字段列表
 func getFields(db *sql.DB, dbType, tableName string) ([]FieldInfo, error) {
-	var query string
-
-	if dbType == "mysql" {
-		query = `SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_KEY,
-			COLUMN_DEFAULT, EXTRA, COLUMN_COMMENT
-			FROM INFORMATION_SCHEMA.COLUMNS
-			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
-			ORDER BY ORDINAL_POSITION`
-	} else if dbType == "postgres" {
-		query = `SELECT column_name, data_type, is_nullable,
-			column_default, column_comment
-			FROM information_schema.columns
-			WHERE table_name = $1
-			ORDER BY ordinal_position`
+	if dbType == "postgres" {
+		return getPGFields(db, tableName)
 	}
 
+	query := `SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, IS_NULLABLE, COLUMN_KEY,
+		COLUMN_DEFAULT, EXTRA, COLUMN_COMMENT
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`
+
 	rows, err := db.Query(query, tableName)
 	if err != nil {
 		return nil, err
@@ -637,26 +974,20 @@ func getFields(db *sql.DB, dbType, tableName string) ([]FieldInfo, error) {
 		var nullable sql.NullString
 		var columnKey, extra, defaultValue, comment sql.NullString
 
-		if dbType == "mysql" {
-			if err := rows.Scan(&field.Name, &field.Type, &nullable,
-				&columnKey, &defaultValue, &extra, &comment); err != nil {
-				return nil, err
-			}
-			field.PrimaryKey = columnKey.String == "PRI"
-			field.AutoIncrement = strings.Contains(extra.String, "auto_increment")
-			field.Comment = comment.String
-			field.DefaultValue = defaultValue.String
-		} else {
-			if err := rows.Scan(&field.Name, &field.Type, &nullable,
-				&defaultValue, &comment); err != nil {
-				return nil, err
-			}
-			field.Comment = comment.String
-			field.DefaultValue = defaultValue.String
+		if err := rows.Scan(&field.Name, &field.Type, &field.ColumnType, &nullable,
+			&columnKey, &defaultValue, &extra, &comment); err != nil {
+			return nil, err
 		}
+		field.PrimaryKey = columnKey.String == "PRI"
+		field.AutoIncrement = strings.Contains(extra.String, "auto_increment")
+		field.Comment = comment.String
+		field.DefaultValue = defaultValue.String
 
 		field.Nullable = nullable.String == "YES"
-		field.GoType = mapToGoType(field.Type)
+		if strings.EqualFold(field.Type, "enum") {
+			field.EnumValues = mysqlLexer.parseEnumValues(field.ColumnType)
+		}
+		field.GoType = mapToGoType(typemap.Options{}, field)
 
 		fields = append(fields, field)
 	}
@@ -666,18 +997,15 @@ func getFields(db *sql.DB, dbType, tableName string) ([]FieldInfo, error) {
 
 // getIndexes 获取索引列表
 func getIndexes(db *sql.DB, dbType, tableName string) ([]IndexInfo, error) {
-	var query string
-
-	if dbType == "mysql" {
-		query = `SELECT INDEX_NAME, GROUP_CONCAT(COLUMN_NAME ORDER BY SEQ_IN_INDEX) as COLUMNS, NON_UNIQUE
-			FROM INFORMATION_SCHEMA.STATISTICS
-			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
-			GROUP BY INDEX_NAME, NON_UNIQUE ORDER BY INDEX_NAME`
-	} else if dbType == "postgres" {
-		// PostgreSQL 索引查询较复杂，这里简化处理
-		return []IndexInfo{}, nil
+	if dbType == "postgres" {
+		return getPGIndexes(db, tableName)
 	}
 
+	query := `SELECT INDEX_NAME, GROUP_CONCAT(COLUMN_NAME ORDER BY SEQ_IN_INDEX) as COLUMNS, NON_UNIQUE
+		FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+		GROUP BY INDEX_NAME, NON_UNIQUE ORDER BY INDEX_NAME`
+
 	rows, err := db.Query(query, tableName)
 	if err != nil {
 		return nil, err
@@ -706,18 +1034,97 @@ func getIndexes(db *sql.DB, dbType, tableName string) ([]IndexInfo, error) {
 	return indexes, nil
 }
 
-// mapToGoType 数据库类型映射到 Go 类型
-func mapToGoType(dbType string) string {
-	typeMap := map[string]string{
-		"varchar": "string", "char": "string", "text": "string",
-		"int": "int", "tinyint": "int", "smallint": "int",
-		"bigint": "int64", "float": "float64", "double": "float64",
-		"datetime": "time.Time", "timestamp": "time.Time",
-		"date": "time.Time", "bool": "bool", "boolean": "bool",
+// getForeignKeys 获取外键列表
+func getForeignKeys(db *sql.DB, dbType, tableName string) ([]ForeignKeyInfo, error) {
+	if dbType == "postgres" {
+		return getPGForeignKeys(db, tableName)
 	}
 
-	if goType, ok := typeMap[strings.ToLower(dbType)]; ok {
-		return goType
+	query := `SELECT CONSTRAINT_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY CONSTRAINT_NAME, ORDINAL_POSITION`
+
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKeyInfo
+	for rows.Next() {
+		var name, column, refTable, refColumn string
+		if err := rows.Scan(&name, &column, &refTable, &refColumn); err != nil {
+			return nil, err
+		}
+		foreignKeys = appendForeignKeyColumn(foreignKeys, name, column, refTable, refColumn)
+	}
+
+	return foreignKeys, rows.Err()
+}
+
+// appendForeignKeyColumn 把按约束名、列顺序排列的查询结果合并为 ForeignKeyInfo
+func appendForeignKeyColumn(foreignKeys []ForeignKeyInfo, name, column, refTable, refColumn string) []ForeignKeyInfo {
+	if n := len(foreignKeys); n > 0 && foreignKeys[n-1].Name == name {
+		foreignKeys[n-1].Columns = append(foreignKeys[n-1].Columns, column)
+		foreignKeys[n-1].RefColumns = append(foreignKeys[n-1].RefColumns, refColumn)
+		return foreignKeys
+	}
+	return append(foreignKeys, ForeignKeyInfo{
+		Name:       name,
+		Columns:    []string{column},
+		RefTable:   refTable,
+		RefColumns: []string{refColumn},
+	})
+}
+
+// mapToGoType 字段映射到 Go 类型，可空列按 types 的可空策略处理
+func mapToGoType(types typemap.Options, field FieldInfo) string {
+	return types.GoType(typeColumn(field))
+}
+
+// typeColumn 把 FieldInfo 转换为类型映射需要的列信息
+func typeColumn(field FieldInfo) typemap.Column {
+	return typemap.Column{
+		Type:       field.Type,
+		ColumnType: field.ColumnType,
+		Nullable:   field.Nullable,
+		PrimaryKey: field.PrimaryKey,
+	}
+}
+
+// mapTypes 按类型映射配置重新计算表中各字段的 Go 类型
+func (t *DetailedTableInfo) mapTypes(types typemap.Options) {
+	for i := range t.Fields {
+		t.Fields[i].GoType = mapToGoType(types, t.Fields[i])
 	}
-	return "string"
 }
+
+// dataTypeMap 让 GORM Gen 连接数据库生成模型时使用与 typemap 一致的类型映射
+//
+// 可空列由映射函数处理（指针或 sql.Null*），因此生成器需关闭 FieldNullable；
+// deleted_at 保持 time.Time，使 GORM Gen 生成 gorm.DeletedAt。
+func dataTypeMap(types typemap.Options) map[string]func(gorm.ColumnType) string {
+	mapping := func(col gorm.ColumnType) string {
+		column := typemap.Column{Type: col.DatabaseTypeName()}
+		column.ColumnType, _ = col.ColumnType()
+		column.Nullable, _ = col.Nullable()
+		column.PrimaryKey, _ = col.PrimaryKey()
+		if col.Name() == "deleted_at" {
+			return types.BaseType(column)
+		}
+		return types.GoType(column)
+	}
+
+	result := make(map[string]func(gorm.ColumnType) string)
+	for _, name := range typemap.KnownTypes() {
+		result[name] = mapping
+	}
+	return result
+}
+
+// typeImportPaths 生成的模型可能用到、但 GORM Gen 模型模板未导入的包（未使用的导入会被自动移除）
+var typeImportPaths = []string{"database/sql", "github.com/lib/pq", "github.com/shopspring/decimal"}
+
+// genModelImports GORM Gen 模型模板已固定导入的包，重复导入会导致格式化失败
+var genModelImports = map[string]bool{"time": true, "gorm.io/datatypes": true, "gorm.io/gorm": true}
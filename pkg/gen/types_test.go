@@ -0,0 +1,151 @@
+package gen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/internal/buildtest"
+)
+
+// testModule 测试生成项目的模块路径
+const testModule = "example.com/app"
+
+// generatedPackages 生成项目中由分层生成器产出的包；routes 与 application 互相引用，不在检查范围内
+var generatedPackages = []string{
+	"./internal/dal/...", "./internal/repository/...", "./internal/service/...",
+	"./internal/controller/...", "./pkg/...", "./docs/...", "./client/...",
+}
+
+// parseSchema 解析 ddl，并按 cfg 的类型映射推导每张表各列的 Go 类型
+func parseSchema(t *testing.T, ddl string, cfg *GenConfig) *SQLSchema {
+	t.Helper()
+	schema, err := ParseSQL(ddl)
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	for _, table := range schema.Tables {
+		table.mapTypes(cfg.types())
+	}
+	return schema
+}
+
+// generateSQL 用 SQL 生成器把 ddl 生成到临时目录（同时生成 OpenAPI 文档与客户端），返回项目目录
+func generateSQL(t *testing.T, ddl string, cfg *GenConfig) string {
+	t.Helper()
+	// 生成结束时会在后台运行 go mod tidy，测试中不访问网络
+	t.Setenv("GOPROXY", "off")
+
+	dir := t.TempDir()
+	sqlFile := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(sqlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "app")
+	err := NewSQLGenerator(Config{
+		SQLFile: sqlFile,
+		Output:  out,
+		Module:  testModule,
+		OpenAPI: true,
+		Client:  true,
+		Options: cfg,
+	}).Generate()
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	return out
+}
+
+// vetGenerated 检查生成的项目能否编译
+func vetGenerated(t *testing.T, dir string) {
+	t.Helper()
+	buildtest.Vet(t, dir, testModule, generatedPackages...)
+}
+
+// readGenerated 读取生成的文件
+func readGenerated(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// structFields 解析生成的文件，返回结构体 typeName 的字段名到类型的映射
+func structFields(t *testing.T, dir, name, typeName string) map[string]string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, 0)
+	if err != nil {
+		t.Fatalf("parse %s: %v", name, err)
+	}
+	obj := file.Scope.Lookup(typeName)
+	if obj == nil || obj.Kind != ast.Typ {
+		t.Fatalf("%s: type %s not found", name, typeName)
+	}
+	fields := make(map[string]string)
+	for _, f := range obj.Decl.(*ast.TypeSpec).Type.(*ast.StructType).Fields.List {
+		for _, n := range f.Names {
+			fields[n.Name] = types.ExprString(f.Type)
+		}
+	}
+	return fields
+}
+
+// funcDecls 解析生成的文件，返回其中的函数声明；方法以 "类型.方法名" 为键
+func funcDecls(t *testing.T, dir string, names ...string) map[string]*ast.FuncDecl {
+	t.Helper()
+	decls := make(map[string]*ast.FuncDecl)
+	for _, name := range names {
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			key := fn.Name.Name
+			if fn.Recv != nil {
+				key = strings.TrimPrefix(types.ExprString(fn.Recv.List[0].Type), "*") + "." + key
+			}
+			decls[key] = fn
+		}
+	}
+	return decls
+}
+
+// signatures 返回 funcDecls 中 names 对应的函数签名，不存在的函数为空字符串
+func signatures(decls map[string]*ast.FuncDecl, names ...string) map[string]string {
+	result := make(map[string]string)
+	for _, name := range names {
+		if fn := decls[name]; fn != nil {
+			result[name] = types.ExprString(fn.Type)
+		} else {
+			result[name] = ""
+		}
+	}
+	return result
+}
+
+// TestSQLGeneratorQueryImportsModel 验证在空目录中生成时 query 包导入 model 包，且 GORM Gen 的声明只出现一次
+func TestSQLGeneratorQueryImportsModel(t *testing.T) {
+	dir := generateSQL(t, "CREATE TABLE `users` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `name` varchar(50) NOT NULL, PRIMARY KEY (`id`));", nil)
+
+	query := readGenerated(t, dir, "internal/dal/query/users.gen.go")
+	if !strings.Contains(query, `"`+testModule+`/internal/dal/model"`) {
+		t.Errorf("users.gen.go does not import the model package:\n%s", query)
+	}
+	for _, name := range []string{"internal/dal/query/users.gen.go", "internal/dal/query/gen.go", "internal/dal/model/users.gen.go"} {
+		if n := strings.Count(readGenerated(t, dir, name), genHeader); n != 1 {
+			t.Errorf("%s has %d generated-code headers, want 1", name, n)
+		}
+	}
+	vetGenerated(t, dir)
+}
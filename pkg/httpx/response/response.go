@@ -32,6 +32,14 @@ type PaginatedData struct {
 	TotalPages int         `json:"total_pages"`
 }
 
+// CursorPaginatedData represents keyset (cursor) paginated response data
+type CursorPaginatedData struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
+	PageSize   int         `json:"page_size"`
+}
+
 // Success sends a successful response with data
 func Success(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusOK, Response{
@@ -133,3 +141,14 @@ func Paginated(c *gin.Context, items interface{}, total int64, page, pageSize in
 		TotalPages: totalPages,
 	})
 }
+
+// CursorPaginated sends a keyset paginated response without a total count.
+// nextCursor is opaque to clients and empty on the last page.
+func CursorPaginated(c *gin.Context, items interface{}, nextCursor string, pageSize int) {
+	Success(c, CursorPaginatedData{
+		Items:      items,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+		PageSize:   pageSize,
+	})
+}
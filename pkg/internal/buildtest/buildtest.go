@@ -0,0 +1,72 @@
+// Package buildtest 供生成器的测试检查生成的代码能否编译
+package buildtest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// goStartModule go-start 的模块路径，生成的代码会引用其中的包（如 commonadapter）
+const goStartModule = "github.com/Martindeeepdark/go-start"
+
+// Vet 在生成的模块根目录 dir 中对 patterns 指定的包运行 go vet，见 Go
+func Vet(t testing.TB, dir, module string, patterns ...string) {
+	t.Helper()
+	Go(t, dir, module, append([]string{"vet"}, patterns...)...)
+}
+
+// Go 在生成的模块根目录 dir 中运行 go 命令（如 go test ./...），失败时输出命令的结果
+//
+// 依赖版本取 go-start 自身的 go.mod，go-start 指向当前源码；检查使用单独的 modfile，
+// 不修改生成的 go.mod（dir 中没有 go.mod 时按 module 创建）。依赖需要已在模块缓存中，
+// 不访问网络；-short 或找不到 go 命令时跳过
+func Go(t testing.TB, dir, module string, args ...string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping build of generated code in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	root := filepath.Join(filepath.Dir(file), "..", "..", "..")
+	goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		t.Fatalf("read go-start go.mod: %v", err)
+	}
+	goSum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatalf("read go-start go.sum: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); os.IsNotExist(err) {
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+module+"\n"), 0644); err != nil {
+			t.Fatalf("write go.mod: %v", err)
+		}
+	}
+
+	// go-start 的 go.mod 去掉 module 行，加上对 go-start 本身的依赖
+	_, requires, _ := strings.Cut(string(goMod), "\n")
+	modFile := filepath.Join(t.TempDir(), "check.mod")
+	content := "module " + module + "\n" + requires +
+		"\nrequire " + goStartModule + " v0.0.0\n\nreplace " + goStartModule + " => " + root + "\n"
+	if err := os.WriteFile(modFile, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", modFile, err)
+	}
+	if err := os.WriteFile(strings.TrimSuffix(modFile, ".mod")+".sum", goSum, 0644); err != nil {
+		t.Fatalf("write go.sum: %v", err)
+	}
+
+	cmd := exec.Command(goBin, append([]string{args[0], "-modfile=" + modFile}, args[1:]...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off", "GOTOOLCHAIN=local")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
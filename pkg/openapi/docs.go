@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteDocs 在 dir 下生成 openapi.yaml、openapi.json 与内嵌两份文档的 docs 包
+//
+// 生成的项目调用 docs.Register(r) 后即可通过 /swagger/index.html 浏览接口文档。
+func WriteDocs(dir string, doc *Document) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+
+	for _, name := range []string{"openapi.yaml", "openapi.json"} {
+		if err := WriteFile(filepath.Join(dir, name), doc); err != nil {
+			return err
+		}
+	}
+
+	title := html.EscapeString(strings.ReplaceAll(doc.Info.Title, "`", "'"))
+	source := strings.ReplaceAll(docsTmpl, "{{Title}}", title)
+	if err := os.WriteFile(filepath.Join(dir, "docs.go"), []byte(source), 0644); err != nil {
+		return fmt.Errorf("写入 docs.go 失败: %w", err)
+	}
+	return nil
+}
+
+// docsTmpl docs 包源码：内嵌 OpenAPI 文档并提供 Swagger UI 页面
+const docsTmpl = `// Code generated by go-start. DO NOT EDIT.
+
+// Package docs 内嵌 OpenAPI 文档并提供 Swagger UI
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.yaml
+var openapiYAML []byte
+
+//go:embed openapi.json
+var openapiJSON []byte
+
+// Register 注册文档路由：
+//
+//	GET /swagger/index.html    Swagger UI
+//	GET /swagger/openapi.yaml  OpenAPI 文档（YAML）
+//	GET /swagger/openapi.json  OpenAPI 文档（JSON）
+func Register(r gin.IRouter) {
+	r.GET("/swagger", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
+	})
+	r.GET("/swagger/index.html", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(indexHTML))
+	})
+	r.GET("/swagger/openapi.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml; charset=utf-8", openapiYAML)
+	})
+	r.GET("/swagger/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", openapiJSON)
+	})
+}
+
+// indexHTML Swagger UI 页面，静态资源从 CDN 加载
+const indexHTML = ` + "`" + `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{Title}} - Swagger UI</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({
+        url: "/swagger/openapi.json",
+        dom_id: "#swagger-ui",
+        deepLinking: true,
+        persistAuthorization: true
+      });
+    };
+  </script>
+</body>
+</html>
+` + "`" + `
+`
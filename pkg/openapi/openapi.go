@@ -0,0 +1,709 @@
+// Package openapi 构建并输出 OpenAPI 3.1 文档
+//
+// gen db、gen sql 与 spec 生成器共用同一套文档结构，
+// 并生成内嵌 Swagger UI 的 docs 包供生成的项目注册文档路由。
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// Version 生成的文档遵循的 OpenAPI 版本
+const Version = "3.1.0"
+
+// BearerAuth 需要登录的接口使用的安全方案名称
+const BearerAuth = "bearerAuth"
+
+// ResponseSchema 统一响应结构 {code, message, data} 在 components 中的名称
+const ResponseSchema = "Response"
+
+// Document OpenAPI 文档
+type Document struct {
+	OpenAPI    string               `json:"openapi" yaml:"openapi"`
+	Info       Info                 `json:"info" yaml:"info"`
+	Servers    []Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths" yaml:"paths"`
+	Components Components           `json:"components" yaml:"components"`
+
+	Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"` // 全局安全要求，接口可覆盖
+}
+
+// Info 文档基本信息
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// Server 服务地址
+type Server struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Tag 接口分组
+type Tag struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// PathItem 同一路径下各 HTTP 方法的操作
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+}
+
+// Methods PathItem 支持的 HTTP 方法
+var Methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// Operation 指定 HTTP 方法的操作，未定义时返回 nil
+func (p *PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return p.Get
+	case "PUT":
+		return p.Put
+	case "POST":
+		return p.Post
+	case "DELETE":
+		return p.Delete
+	case "PATCH":
+		return p.Patch
+	}
+	return nil
+}
+
+// Operation 一个接口
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses" yaml:"responses"`
+	Security    []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+	Permission  string                `json:"x-permission,omitempty" yaml:"x-permission,omitempty"` // 需要的权限标识
+}
+
+// Parameter 路径、查询或请求头参数
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name        string  `json:"name" yaml:"name"`
+	In          string  `json:"in" yaml:"in"` // path、query 或 header
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Ref         string               `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Header 响应头
+type Header struct {
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// MediaType 某种内容类型的结构
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Components 可复用的结构与安全方案
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBodies   map[string]*RequestBody    `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme 安全方案
+type SecurityScheme struct {
+	Type         string `json:"type" yaml:"type"`
+	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Schema JSON Schema（OpenAPI 3.1 与 JSON Schema 2020-12 一致，可空用 type: [string, "null"] 表示）
+type Schema struct {
+	Ref              string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type             Types              `json:"type,omitempty" yaml:"type,omitempty"`
+	Format           string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description      string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Enum             []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default          interface{}        `json:"default,omitempty" yaml:"default,omitempty"`
+	Minimum          *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum          *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMinimum *float64           `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64           `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	MinLength        *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength        *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Pattern          string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Items            *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	MinItems         *int               `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems         *int               `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	AllOf            []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	OneOf            []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AnyOf            []*Schema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	ReadOnly         bool               `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+
+	order []string // 读取文档时 properties 的书写顺序
+}
+
+// UnmarshalYAML 实现 yaml.Unmarshaler，记录属性顺序并兼容 OpenAPI 3.0 的写法：
+// nullable: true 转换为 type 中的 null，布尔值的 exclusiveMinimum/exclusiveMaximum 修饰 minimum/maximum
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	type plain Schema
+	if node.Kind != yaml.MappingNode {
+		return node.Decode((*plain)(s))
+	}
+
+	var nullable, exclusiveMin, exclusiveMax bool
+	mapping := *node
+	mapping.Content = nil
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Tag == "!!bool" {
+			switch key.Value {
+			case "nullable":
+				nullable = value.Value == "true"
+				continue
+			case "exclusiveMinimum":
+				exclusiveMin = value.Value == "true"
+				continue
+			case "exclusiveMaximum":
+				exclusiveMax = value.Value == "true"
+				continue
+			}
+		}
+		if key.Value == "properties" && value.Kind == yaml.MappingNode {
+			for j := 0; j < len(value.Content); j += 2 {
+				s.order = append(s.order, value.Content[j].Value)
+			}
+		}
+		mapping.Content = append(mapping.Content, key, value)
+	}
+
+	order := s.order
+	if err := mapping.Decode((*plain)(s)); err != nil {
+		return err
+	}
+	s.order = order
+
+	if nullable {
+		s.Nullable()
+	}
+	if exclusiveMin {
+		s.ExclusiveMinimum, s.Minimum = s.Minimum, nil
+	}
+	if exclusiveMax {
+		s.ExclusiveMaximum, s.Maximum = s.Maximum, nil
+	}
+	return nil
+}
+
+// AddProperty 按顺序添加属性，已存在时替换
+func (s *Schema) AddProperty(name string, prop *Schema) {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*Schema)
+	}
+	if _, ok := s.Properties[name]; !ok {
+		s.order = append(s.order, name)
+	}
+	s.Properties[name] = prop
+}
+
+// PropertyNames 属性名：读取的文档按书写顺序，其余按名称排序
+func (s *Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	seen := make(map[string]bool, len(s.Properties))
+	for _, name := range s.order {
+		if _, ok := s.Properties[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range s.Properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// Types schema 的 type，只有一个类型时输出为字符串，否则输出为数组
+type Types []string
+
+// MarshalJSON 实现 json.Marshaler
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// MarshalYAML 实现 yaml.Marshaler
+func (t Types) MarshalYAML() (interface{}, error) {
+	if len(t) == 1 {
+		return t[0], nil
+	}
+	return []string(t), nil
+}
+
+// UnmarshalYAML 实现 yaml.Unmarshaler，type 可以是字符串或数组
+func (t *Types) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = Types{node.Value}
+		return nil
+	}
+	return node.Decode((*[]string)(t))
+}
+
+// New 创建空文档，预置统一响应结构
+func New(title, version, description string) *Document {
+	if version == "" {
+		version = "1.0.0"
+	}
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Description: description, Version: version},
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: map[string]*Schema{
+				ResponseSchema: {
+					Type:        Types{"object"},
+					Description: "统一响应结构，code 为 0 表示成功",
+					Properties: map[string]*Schema{
+						"code":    {Type: Types{"integer"}},
+						"message": {Type: Types{"string"}},
+						"data":    {},
+					},
+					Required: []string{"code", "message"},
+				},
+			},
+		},
+	}
+}
+
+// routeParam gin 风格的路由参数，如 :id、*path
+var routeParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Path 把 gin 风格的路由（/users/:id）转换为 OpenAPI 路径（/users/{id}）
+func Path(route string) string {
+	return routeParam.ReplaceAllString(route, "{$1}")
+}
+
+// pathParams OpenAPI 路径中的参数名
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, segment[1:len(segment)-1])
+		}
+	}
+	return names
+}
+
+// AddOperation 添加接口，route 可以是 gin 风格的路由
+//
+// 路径中未在 op.Parameters 声明的参数按必填字符串补齐；同一路径同一方法重复添加时后者覆盖前者。
+func (d *Document) AddOperation(method, route string, op *Operation) error {
+	path := Path(route)
+	for _, name := range pathParams(path) {
+		if op.param(name, "path") == nil {
+			op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: String()})
+		}
+	}
+	if op.Responses == nil {
+		op.Responses = make(map[string]*Response)
+	}
+
+	item := d.Paths[path]
+	if item == nil {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	switch strings.ToUpper(method) {
+	case "GET":
+		item.Get = op
+	case "PUT":
+		item.Put = op
+	case "POST":
+		item.Post = op
+	case "DELETE":
+		item.Delete = op
+	case "PATCH":
+		item.Patch = op
+	default:
+		return fmt.Errorf("不支持的 HTTP 方法: %s", method)
+	}
+
+	for _, tag := range op.Tags {
+		d.AddTag(tag, "")
+	}
+	return nil
+}
+
+// AddTag 添加接口分组，已存在时只补充描述
+func (d *Document) AddTag(name, description string) {
+	for i := range d.Tags {
+		if d.Tags[i].Name == name {
+			if d.Tags[i].Description == "" {
+				d.Tags[i].Description = description
+			}
+			return
+		}
+	}
+	d.Tags = append(d.Tags, Tag{Name: name, Description: description})
+}
+
+// RequireAuth 标记接口需要 Bearer Token 认证，并补充 401 响应
+func (d *Document) RequireAuth(op *Operation) {
+	if d.Components.SecuritySchemes == nil {
+		d.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	d.Components.SecuritySchemes[BearerAuth] = &SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+	}
+	op.Security = []map[string][]string{{BearerAuth: {}}}
+	op.Error(401, "未登录或令牌无效")
+}
+
+// param 按名称和位置查找参数
+func (op *Operation) param(name, in string) *Parameter {
+	for _, p := range op.Parameters {
+		if p.Name == name && p.In == in {
+			return p
+		}
+	}
+	return nil
+}
+
+// Success 设置 200 响应，data 为统一响应结构中 data 字段的结构，为 nil 时不约束
+func (op *Operation) Success(description string, data *Schema) *Response {
+	return op.respond(200, description, Envelope(data))
+}
+
+// Error 添加错误响应（统一响应结构，不带 data）
+func (op *Operation) Error(status int, description string) *Response {
+	return op.respond(status, description, Ref(ResponseSchema))
+}
+
+// respond 设置指定状态码的 JSON 响应
+func (op *Operation) respond(status int, description string, schema *Schema) *Response {
+	if op.Responses == nil {
+		op.Responses = make(map[string]*Response)
+	}
+	resp := &Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+	op.Responses[fmt.Sprint(status)] = resp
+	return resp
+}
+
+// JSONBody 设置 JSON 请求体
+func (op *Operation) JSONBody(description string, schema *Schema) {
+	op.RequestBody = &RequestBody{
+		Description: description,
+		Required:    true,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// Query 添加查询参数
+func (op *Operation) Query(name, description string, schema *Schema) *Parameter {
+	p := &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+	op.Parameters = append(op.Parameters, p)
+	return p
+}
+
+// Ref 引用 components.schemas 中的结构
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// Envelope 统一响应结构，data 字段为指定结构
+func Envelope(data *Schema) *Schema {
+	if data == nil {
+		return Ref(ResponseSchema)
+	}
+	return &Schema{AllOf: []*Schema{
+		Ref(ResponseSchema),
+		{Type: Types{"object"}, Properties: map[string]*Schema{"data": data}},
+	}}
+}
+
+// Object 对象结构
+func Object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: Types{"object"}, Properties: properties, Required: required}
+}
+
+// ArrayOf 数组结构
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: Types{"array"}, Items: items}
+}
+
+// String 字符串结构
+func String() *Schema {
+	return &Schema{Type: Types{"string"}}
+}
+
+// Integer 整数结构
+func Integer() *Schema {
+	return &Schema{Type: Types{"integer"}}
+}
+
+// Boolean 布尔结构
+func Boolean() *Schema {
+	return &Schema{Type: Types{"boolean"}}
+}
+
+// Float 返回 v 的指针，用于 minimum/maximum 等约束
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Int 返回 v 的指针，用于 minLength/maxLength 等约束
+func Int(v int) *int {
+	return &v
+}
+
+// Nullable 允许值为 null；没有 type 的结构（任意值）与引用保持不变
+func (s *Schema) Nullable() *Schema {
+	if len(s.Type) == 0 {
+		return s
+	}
+	for _, t := range s.Type {
+		if t == "null" {
+			return s
+		}
+	}
+	s.Type = append(s.Type, "null")
+	return s
+}
+
+// Describe 在描述前补充说明，text 为空时不变
+func (s *Schema) Describe(text string) *Schema {
+	switch {
+	case text == "":
+	case s.Description == "":
+		s.Description = text
+	default:
+		s.Description = text + "；" + s.Description
+	}
+	return s
+}
+
+// Is 是否为指定类型（忽略 null）
+func (s *Schema) Is(typ string) bool {
+	return slices.Contains(s.Type, typ)
+}
+
+// sqlNullFields database/sql 的 Null 类型序列化为 JSON 时的值字段及其类型
+var sqlNullFields = map[string][2]string{
+	"sql.NullString":  {"String", "string"},
+	"sql.NullInt64":   {"Int64", "int64"},
+	"sql.NullInt32":   {"Int32", "int32"},
+	"sql.NullInt16":   {"Int16", "int16"},
+	"sql.NullByte":    {"Byte", "uint8"},
+	"sql.NullFloat64": {"Float64", "float64"},
+	"sql.NullBool":    {"Bool", "bool"},
+	"sql.NullTime":    {"Time", "time.Time"},
+}
+
+// pqArrays lib/pq 的数组类型对应的元素类型
+var pqArrays = map[string]string{
+	"pq.StringArray":  "string",
+	"pq.Int64Array":   "int64",
+	"pq.Float64Array": "float64",
+	"pq.BoolArray":    "bool",
+}
+
+// SchemaFor Go 类型对应的 JSON 结构，指针类型可为 null，无法识别的类型不约束
+func SchemaFor(goType string) *Schema {
+	if strings.HasPrefix(goType, "*") {
+		return SchemaFor(goType[1:]).Nullable()
+	}
+	if field, ok := sqlNullFields[goType]; ok {
+		// sql.Null* 没有实现 json.Marshaler，按结构体序列化
+		return Object(map[string]*Schema{field[0]: SchemaFor(field[1]), "Valid": Boolean()})
+	}
+	if elem, ok := pqArrays[goType]; ok {
+		return ArrayOf(SchemaFor(elem)).Nullable()
+	}
+
+	switch goType {
+	case "string":
+		return String()
+	case "bool":
+		return Boolean()
+	case "int", "int64", "uint64", "uint":
+		s := &Schema{Type: Types{"integer"}, Format: "int64"}
+		if strings.HasPrefix(goType, "u") {
+			s.Minimum = Float(0)
+		}
+		return s
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32":
+		s := &Schema{Type: Types{"integer"}, Format: "int32"}
+		if strings.HasPrefix(goType, "u") {
+			s.Minimum = Float(0)
+		}
+		return s
+	case "float32":
+		return &Schema{Type: Types{"number"}, Format: "float"}
+	case "float64":
+		return &Schema{Type: Types{"number"}, Format: "double"}
+	case "time.Time":
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case "decimal.Decimal":
+		return &Schema{Type: Types{"string"}, Format: "decimal"}
+	case "decimal.NullDecimal":
+		return SchemaFor("decimal.Decimal").Nullable()
+	case "[]byte":
+		return &Schema{Type: Types{"string"}, Format: "byte"}
+	case "uuid.UUID":
+		return &Schema{Type: Types{"string"}, Format: "uuid"}
+	}
+	if strings.HasPrefix(goType, "[]") {
+		return ArrayOf(SchemaFor(goType[2:]))
+	}
+	// datatypes.JSON、json.RawMessage、interface{} 等任意 JSON 值
+	return &Schema{}
+}
+
+// EnumSchema 枚举类型的结构：取值为底层值（整数枚举为数字），描述中列出各取值的含义
+func EnumSchema(e typemap.Enum) *Schema {
+	s := SchemaFor(e.BaseType)
+	var labels []string
+	for _, v := range e.Values {
+		if n, err := strconv.ParseInt(v.Value, 10, 64); err == nil && e.Numeric() {
+			s.Enum = append(s.Enum, n)
+		} else {
+			s.Enum = append(s.Enum, v.Value)
+		}
+		labels = append(labels, v.Value+"="+v.DisplayName())
+	}
+	s.Description = "可选值：" + strings.Join(labels, ", ")
+	return s
+}
+
+// 输出格式
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// FormatOf 按文件扩展名判断输出格式，.json 为 JSON，其他为 YAML
+func FormatOf(filename string) string {
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// Marshal 按格式序列化文档
+func Marshal(doc *Document, format string) ([]byte, error) {
+	doc.sortTags()
+
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatYAML, "":
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("不支持的文档格式: %s (支持: %s, %s)", format, FormatYAML, FormatJSON)
+}
+
+// WriteFile 把文档写入文件，格式由扩展名决定
+func WriteFile(filename string, doc *Document) error {
+	data, err := Marshal(doc, FormatOf(filename))
+	if err != nil {
+		return fmt.Errorf("序列化 OpenAPI 文档失败: %w", err)
+	}
+	if dir := filepath.Dir(filename); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建目录失败: %w", err)
+		}
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filename, err)
+	}
+	return nil
+}
+
+// ReadFile 读取 YAML 或 JSON 格式的 OpenAPI 3.0/3.1 文档（JSON 是 YAML 的子集，统一按 YAML 解析）
+func ReadFile(filename string) (*Document, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取 %s 失败: %w", filename, err)
+	}
+
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("解析 OpenAPI 文档失败: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("不支持的 OpenAPI 版本 %q (支持 3.0、3.1)", doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// sortTags 接口分组按名称排序，保证重复生成的结果一致
+func (d *Document) sortTags() {
+	sort.SliceStable(d.Tags, func(i, j int) bool {
+		return d.Tags[i].Name < d.Tags[j].Name
+	})
+}
@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSchemaFor 验证 Go 类型到 JSON Schema 的映射，指针类型输出 ["x", "null"]，单一类型输出字符串
+func TestSchemaFor(t *testing.T) {
+	tests := []struct {
+		goType string
+		want   string
+	}{
+		{"string", `{"type":"string"}`},
+		{"int64", `{"type":"integer","format":"int64"}`},
+		{"uint32", `{"type":"integer","format":"int32","minimum":0}`},
+		{"*time.Time", `{"type":["string","null"],"format":"date-time"}`},
+		{"[]int", `{"type":"array","items":{"type":"integer","format":"int64"}}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(SchemaFor(tt.goType))
+		if err != nil {
+			t.Fatalf("SchemaFor(%q) marshal error: %v", tt.goType, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("SchemaFor(%q) = %s, want %s", tt.goType, got, tt.want)
+		}
+	}
+}
+
+// TestAddOperation 验证 gin 路由转换为 OpenAPI 路径，缺失的路径参数自动补齐
+func TestAddOperation(t *testing.T) {
+	doc := New("Test API", "1.0.0", "")
+	op := &Operation{Tags: []string{"User"}, OperationID: "GetUser"}
+	op.Success("成功", Ref("User"))
+	if err := doc.AddOperation("GET", "/users/:id", op); err != nil {
+		t.Fatalf("AddOperation() unexpected error: %v", err)
+	}
+
+	item, ok := doc.Paths["/users/{id}"]
+	if !ok || item.Get != op {
+		t.Fatalf("AddOperation() did not register GET /users/{id}, paths = %v", doc.Paths)
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || op.Parameters[0].In != "path" || !op.Parameters[0].Required {
+		t.Errorf("AddOperation() parameters = %+v, want required path param id", op.Parameters)
+	}
+
+	if err := doc.AddOperation("TRACE", "/users/:id", &Operation{}); err == nil {
+		t.Errorf("AddOperation() unsupported method expected error")
+	}
+}
+
+// TestReadFile 验证读取 OpenAPI 3.0 文档：nullable 与布尔值的 exclusiveMaximum 转换为 3.1 写法，属性保持书写顺序
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	content := `openapi: 3.0.3
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        tag: {type: string, nullable: true}
+        age: {type: integer, maximum: 30, exclusiveMaximum: true}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() unexpected error: %v", err)
+	}
+	pet := doc.Components.Schemas["Pet"]
+	if got := strings.Join(pet.PropertyNames(), ","); got != "name,tag,age" {
+		t.Errorf("PropertyNames() = %s, want name,tag,age", got)
+	}
+	if tag := pet.Properties["tag"]; !tag.Is("string") || !tag.Is("null") {
+		t.Errorf("tag type = %v, want [string null]", tag.Type)
+	}
+	if age := pet.Properties["age"]; age.Maximum != nil || age.ExclusiveMaximum == nil || *age.ExclusiveMaximum != 30 {
+		t.Errorf("age maximum = %v, exclusiveMaximum = %v, want exclusiveMaximum 30", age.Maximum, age.ExclusiveMaximum)
+	}
+}
@@ -0,0 +1,710 @@
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// goStruct 生成的结构体
+type goStruct struct {
+	Name    string
+	Comment string
+	Fields  []goField
+}
+
+// goField 结构体字段
+type goField struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+}
+
+// goMethod Client 的方法
+type goMethod struct {
+	Name    string
+	Comment []string
+	Params  string // ctx 之后的参数
+	Result  string // data 解码的类型，为空表示没有数据
+	Pointer bool   // 返回 *Result
+	Method  string // http.MethodGet 等
+	Path    string // 拼接路径的 Go 表达式
+	Query   []goQuery
+	Body    bool
+	Auth    bool
+}
+
+// goQuery 查询参数，来自 <Method>Params 结构体的字段
+type goQuery struct {
+	Name  string // 参数名
+	Field string // 结构体字段名
+	Cond  string // 参数已设置的条件
+	Value string // 参数值表达式
+	Multi bool   // 数组参数，逐个添加
+}
+
+// goGenerator 生成 Go 客户端时收集的结构体与导入
+type goGenerator struct {
+	doc     *openapi.Document
+	structs []goStruct
+	names   map[string]bool
+}
+
+// Go 生成 Go 客户端包，返回文件名到内容的映射（client.go 与 types.go）：
+// components.schemas 与内联对象生成结构体，每个接口生成 Client 的一个方法，
+// 支持 context、超时、幂等请求重试，解包统一响应结构，失败时返回 *Error
+func Go(doc *openapi.Document, pkg string) (map[string][]byte, error) {
+	g := &goGenerator{doc: doc, names: make(map[string]bool)}
+	names := schemaNames(doc)
+	for _, name := range names {
+		g.names[refName(name)] = true
+	}
+	for _, name := range names {
+		schema := doc.Components.Schemas[name]
+		if schema.Is("object") && len(schema.Properties) > 0 {
+			g.addStruct(refName(name), schema)
+		}
+	}
+
+	var methods []goMethod
+	for _, ep := range endpoints(doc) {
+		methods = append(methods, g.method(ep))
+	}
+
+	baseURL := ""
+	if len(doc.Servers) > 0 {
+		baseURL = doc.Servers[0].URL
+	}
+	usesTime := false
+	for _, st := range g.structs {
+		for _, f := range st.Fields {
+			usesTime = usesTime || strings.Contains(f.Type, "time.Time")
+		}
+	}
+	data := map[string]interface{}{
+		"Package": pkg,
+		"Info":    doc.Info,
+		"BaseURL": baseURL,
+		"Structs": sortedStructs(g.structs),
+		"Methods": methods,
+		"Time":    usesTime,
+	}
+
+	files := make(map[string][]byte)
+	for name, text := range map[string]string{"client.go": goClientTemplate, "types.go": goTypesTemplate} {
+		tmpl, err := template.New(name).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("解析模板失败: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("执行模板失败: %w", err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("格式化 %s 失败: %w", name, err)
+		}
+		files[name] = src
+	}
+	return files, nil
+}
+
+// WriteGo 在 dir 目录生成 Go 客户端包，包名取目录名
+func WriteGo(dir string, doc *openapi.Document) error {
+	files, err := Go(doc, packageName(dir))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return fmt.Errorf("写入 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// packageName 目录对应的包名，如 pkg/user-client → userclient，无法推导时为 client
+func packageName(dir string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(filepath.Base(filepath.Clean(dir))) {
+		if 'a' <= r && r <= 'z' || b.Len() > 0 && '0' <= r && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "client"
+	}
+	return b.String()
+}
+
+// addStruct 添加对象结构对应的结构体，返回结构体名（重名时追加序号）
+func (g *goGenerator) addStruct(name string, schema *openapi.Schema) string {
+	st := goStruct{Name: name, Comment: schema.Description}
+	g.names[name] = true
+	index := len(g.structs)
+	g.structs = append(g.structs, st)
+
+	for _, prop := range schema.PropertyNames() {
+		s := g.resolve(schema.Properties[prop])
+		nullable := s.Is("null")
+		typ := g.goType(s, name+goName(prop))
+		if nullable && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") && typ != "interface{}" {
+			typ = "*" + typ
+		}
+
+		tag := prop
+		if !slices.Contains(schema.Required, prop) {
+			tag += ",omitempty"
+		}
+		st.Fields = append(st.Fields, goField{
+			Name:    goName(prop),
+			Type:    typ,
+			Tag:     "`json:\"" + tag + "\"`",
+			Comment: schema.Properties[prop].Description,
+		})
+	}
+	g.structs[index] = st
+	return name
+}
+
+// uniqueName 未被占用的类型名
+func (g *goGenerator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	return unique
+}
+
+// resolve 解析指向 components 中属性的引用（如 #/components/schemas/User/properties/name），其他结构不变
+func (g *goGenerator) resolve(s *openapi.Schema) *openapi.Schema {
+	if s == nil || !strings.Contains(s.Ref, "/properties/") {
+		return s
+	}
+	ref := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	name, prop, _ := strings.Cut(ref, "/properties/")
+	if schema := g.doc.Components.Schemas[name]; schema != nil && schema.Properties[prop] != nil {
+		return schema.Properties[prop]
+	}
+	return &openapi.Schema{}
+}
+
+// goType 结构对应的 Go 类型（不含可空的指针），内联对象生成名为 hint 的结构体
+func (g *goGenerator) goType(s *openapi.Schema, hint string) string {
+	s = g.resolve(s)
+	switch {
+	case s == nil:
+		return "interface{}"
+	case s.Ref != "":
+		name := refName(s.Ref)
+		if schema := g.doc.Components.Schemas[name]; schema != nil && (!schema.Is("object") || len(schema.Properties) == 0) {
+			return g.goType(schema, name)
+		}
+		return name
+	case len(s.AllOf) == 1:
+		return g.goType(s.AllOf[0], hint)
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		return "interface{}"
+	}
+
+	switch {
+	case s.Is("integer"):
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case s.Is("number"):
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case s.Is("boolean"):
+		return "bool"
+	case s.Is("string"):
+		switch s.Format {
+		case "date-time":
+			return "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		}
+		return "string"
+	case s.Is("array"):
+		return "[]" + g.goType(s.Items, hint+"Item")
+	case s.Is("object"):
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		return g.addStruct(g.uniqueName(hint), s)
+	}
+	return "interface{}"
+}
+
+// method 接口对应的 Client 方法：路径参数在前，然后是请求体与查询参数
+func (g *goGenerator) method(ep endpoint) goMethod {
+	m := goMethod{
+		Name:   goName(ep.Name),
+		Method: "http.Method" + ep.Method[:1] + strings.ToLower(ep.Method[1:]),
+		Body:   ep.Body != nil,
+		Auth:   ep.Auth,
+	}
+	if ep.Summary != "" {
+		m.Comment = append(m.Comment, ep.Summary)
+	}
+	if ep.Permission != "" {
+		m.Comment = append(m.Comment, "需要权限 "+ep.Permission)
+	}
+
+	var params []string
+	path := ep.Path
+	for _, p := range ep.PathParams {
+		name := goParamName(p.Name)
+		typ := g.goType(p.Schema, "")
+		params = append(params, name+" "+typ)
+		value := "url.PathEscape(" + name + ")"
+		if typ != "string" {
+			value = "url.PathEscape(fmt.Sprint(" + name + "))"
+		}
+		path = strings.ReplaceAll(path, "{"+p.Name+"}", "\" + "+value+" + \"")
+	}
+	m.Path = strings.TrimSuffix("\""+path+"\"", " + \"\"")
+
+	if ep.Body != nil {
+		typ := g.goType(ep.Body, g.uniqueName(m.Name+"Body"))
+		if g.structType(typ) {
+			g.describe(typ, m.Name+" 的请求体")
+			typ = "*" + typ
+		}
+		params = append(params, "body "+typ)
+	}
+
+	if len(ep.Query) > 0 {
+		st := goStruct{Name: g.uniqueName(m.Name + "Params"), Comment: m.Name + " 的查询参数，零值表示不传"}
+		g.names[st.Name] = true
+		for _, p := range ep.Query {
+			field := goName(p.Name)
+			typ := g.goType(p.Schema, st.Name+field)
+			q := goQuery{Name: p.Name, Field: field, Value: "params." + field}
+			switch {
+			case typ == "bool":
+				typ, q.Cond, q.Value = "*bool", "params."+field+" != nil", "fmt.Sprint(*params."+field+")"
+			case typ == "string":
+				q.Cond = "params." + field + " != \"\""
+			case typ == "time.Time":
+				q.Cond, q.Value = "!params."+field+".IsZero()", "params."+field+".Format(time.RFC3339)"
+			case strings.HasPrefix(typ, "[]"):
+				q.Multi, q.Value = true, "fmt.Sprint(v)"
+			default:
+				q.Cond, q.Value = "params."+field+" != 0", "fmt.Sprint(params."+field+")"
+			}
+			st.Fields = append(st.Fields, goField{Name: field, Type: typ, Comment: p.Description})
+			m.Query = append(m.Query, q)
+		}
+		g.structs = append(g.structs, st)
+		params = append(params, "params *"+st.Name)
+	}
+
+	if ep.Data != nil {
+		m.Result = g.goType(ep.Data, g.uniqueName(m.Name+"Result"))
+		m.Pointer = g.structType(m.Result)
+		if m.Pointer {
+			g.describe(m.Result, m.Name+" 返回的数据")
+		}
+	}
+	if len(params) > 0 {
+		m.Params = ", " + strings.Join(params, ", ")
+	}
+	return m
+}
+
+// describe 为没有描述的结构体补充注释
+func (g *goGenerator) describe(name, comment string) {
+	for i := range g.structs {
+		if g.structs[i].Name == name && g.structs[i].Comment == "" {
+			g.structs[i].Comment = comment
+		}
+	}
+}
+
+// structType 类型是否为生成的结构体
+func (g *goGenerator) structType(typ string) bool {
+	for _, st := range g.structs {
+		if st.Name == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// goInitialisms 按 Go 命名习惯全部大写的缩写
+var goInitialisms = map[string]bool{
+	"id": true, "ids": true, "url": true, "uri": true, "api": true, "http": true, "json": true,
+	"ip": true, "uuid": true, "sql": true, "html": true, "ttl": true,
+}
+
+// goName Go 风格的导出名，如 category_id、categoryId → CategoryID，tag_ids → TagIDs
+func goName(s string) string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words, word = append(words, string(word)), nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && (unicode.IsLower(word[len(word)-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			words, word = append(words, string(word)), nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	var b strings.Builder
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		switch {
+		case lower == "ids":
+			b.WriteString("IDs")
+		case goInitialisms[lower]:
+			b.WriteString(strings.ToUpper(w))
+		default:
+			b.WriteString(exportedName(w))
+		}
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// goParamName Go 风格的参数名，如 article_id → articleID，id → id
+func goParamName(s string) string {
+	name := []rune(goName(s))
+	upper := 0
+	for upper < len(name) && unicode.IsUpper(name[upper]) {
+		upper++
+	}
+	switch {
+	case upper == len(name):
+		upper = len(name)
+	case upper > 1:
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		name[i] = unicode.ToLower(name[i])
+	}
+	return string(name)
+}
+
+// sortedStructs 按名称排序的结构体，生成的 types.go 顺序稳定
+func sortedStructs(structs []goStruct) []goStruct {
+	sorted := append([]goStruct(nil), structs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+const goTypesTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Time}}
+import "time"
+{{end}}
+{{- range .Structs}}
+{{if .Comment}}
+// {{.Name}} {{.Comment}}
+{{- else}}
+// {{.Name}} {{.Name}} 结构
+{{- end}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{end}}`
+
+const goClientTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+// Package {{.Package}} {{.Info.Title}} {{.Info.Version}} 的 Go 客户端
+//
+//	c := {{.Package}}.New("http://localhost:8080{{.BaseURL}}", {{.Package}}.WithTimeout(5*time.Second), {{.Package}}.WithRetry(2, 200*time.Millisecond))
+//	_, err := c.XXX(ctx, ...)
+//	if errors.Is(err, {{.Package}}.ErrNotFound) { ... }
+package {{.Package}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 错误码，与服务端 response.Code* 一致；响应没有业务错误码（如 code 为 -1）时取 HTTP 状态码
+const (
+	CodeSuccess            = 0
+	CodeInvalidParams      = 400
+	CodeUnauthorized       = 401
+	CodeForbidden          = 403
+	CodeNotFound           = 404
+	CodeConflict           = 409 // 唯一约束冲突或乐观锁版本过期
+	CodePreconditionFailed = 412 // If-Match 与当前版本不一致
+	CodeUnprocessable      = 422 // 批量操作校验失败，所有记录均未写入
+	CodeInternalError      = 500
+	CodeServiceUnavailable = 503
+)
+
+// 按错误码匹配的错误，如 errors.Is(err, ErrNotFound)
+var (
+	ErrInvalidParams      = &Error{Code: CodeInvalidParams, Message: "参数错误"}
+	ErrUnauthorized       = &Error{Code: CodeUnauthorized, Message: "未授权"}
+	ErrForbidden          = &Error{Code: CodeForbidden, Message: "权限不足"}
+	ErrNotFound           = &Error{Code: CodeNotFound, Message: "记录不存在"}
+	ErrConflict           = &Error{Code: CodeConflict, Message: "数据冲突"}
+	ErrPreconditionFailed = &Error{Code: CodePreconditionFailed, Message: "版本不一致"}
+	ErrUnprocessable      = &Error{Code: CodeUnprocessable, Message: "批量操作失败"}
+	ErrInternalError      = &Error{Code: CodeInternalError, Message: "服务器内部错误"}
+	ErrServiceUnavailable = &Error{Code: CodeServiceUnavailable, Message: "服务不可用"}
+)
+
+// Error 接口返回的错误：HTTP 状态码不是 2xx，或统一响应结构的 code 不为 0
+type Error struct {
+	StatusCode int    // HTTP 状态码
+	Code       int    // 统一响应结构中的 code
+	Message    string // 统一响应结构中的 message
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("{{.Package}}: %s (code=%d, status=%d)", e.Message, e.Code, e.StatusCode)
+}
+
+// Is 按错误码匹配
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// response 统一响应结构
+type response struct {
+	Code    int             ` + "`json:\"code\"`" + `
+	Message string          ` + "`json:\"message\"`" + `
+	Data    json.RawMessage ` + "`json:\"data\"`" + `
+}
+
+// Client 接口客户端，可以并发使用
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+	token      func(ctx context.Context) (string, error)
+	retries    int
+	retryWait  time.Duration
+}
+
+// Option 客户端配置
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的 http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithTimeout 设置单次请求的超时时间（默认 10 秒）
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = timeout
+		c.httpClient = &hc
+	}
+}
+
+// WithRetry 幂等请求（GET、PUT、DELETE）遇到网络错误、429 或 5xx 时最多重试 retries 次，等待时间从 wait 开始指数增长
+func WithRetry(retries int, wait time.Duration) Option {
+	return func(c *Client) { c.retries, c.retryWait = retries, wait }
+}
+
+// WithToken 需要认证的接口携带固定的 Bearer Token
+func WithToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) { return token, nil })
+}
+
+// WithTokenSource 需要认证的接口在每次请求时获取 Bearer Token
+func WithTokenSource(token func(ctx context.Context) (string, error)) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHeader 每个请求附加的请求头
+func WithHeader(key, value string) Option {
+	return func(c *Client) { c.header.Add(key, value) }
+}
+
+// New 创建客户端，baseURL 包含接口前缀，如 http://localhost:8080{{.BaseURL}}
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		header:     make(http.Header),
+		retryWait:  100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+{{range .Methods}}
+// {{.Name}}{{range $i, $c := .Comment}}{{if $i}}
+//{{end}} {{$c}}{{end}}
+func (c *Client) {{.Name}}(ctx context.Context{{.Params}}) {{if .Result}}({{if .Pointer}}*{{end}}{{.Result}}, error){{else}}error{{end}} {
+	{{- if .Query}}
+	query := url.Values{}
+	if params != nil {
+		{{- range .Query}}
+		{{- if .Multi}}
+		for _, v := range params.{{.Field}} {
+			query.Add("{{.Name}}", {{.Value}})
+		}
+		{{- else}}
+		if {{.Cond}} {
+			query.Set("{{.Name}}", {{.Value}})
+		}
+		{{- end}}
+		{{- end}}
+	}
+	{{- end}}
+	{{- if .Result}}
+	var out {{.Result}}
+	{{- if .Pointer}}
+	if err := c.do(ctx, {{.Method}}, {{.Path}}, {{if .Query}}query{{else}}nil{{end}}, {{if .Body}}body{{else}}nil{{end}}, {{.Auth}}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+	{{- else}}
+	err := c.do(ctx, {{.Method}}, {{.Path}}, {{if .Query}}query{{else}}nil{{end}}, {{if .Body}}body{{else}}nil{{end}}, {{.Auth}}, &out)
+	return out, err
+	{{- end}}
+	{{- else}}
+	return c.do(ctx, {{.Method}}, {{.Path}}, {{if .Query}}query{{else}}nil{{end}}, {{if .Body}}body{{else}}nil{{end}}, {{.Auth}}, nil)
+	{{- end}}
+}
+{{end}}
+// do 发送请求并把 data 解码到 out，幂等请求按 WithRetry 的配置重试
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, auth bool, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("{{.Package}}: 编码请求体失败: %w", err)
+		}
+	}
+
+	attempts := 1
+	if method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete {
+		attempts += c.retries
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.retryWait << (i - 1)):
+			}
+		}
+
+		var retry bool
+		retry, err = c.send(ctx, method, path, query, payload, auth, out)
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// send 发送一次请求，返回的 retry 表示错误是否可以重试
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte, auth bool, out interface{}) (retry bool, err error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return false, fmt.Errorf("{{.Package}}: 创建请求失败: %w", err)
+	}
+	for key, values := range c.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if auth && c.token != nil {
+		token, err := c.token(ctx)
+		if err != nil {
+			return false, fmt.Errorf("{{.Package}}: 获取令牌失败: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("{{.Package}}: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return retry, fmt.Errorf("{{.Package}}: 读取响应失败: %w", err)
+	}
+
+	var r response
+	if err := json.Unmarshal(data, &r); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return retry, &Error{StatusCode: resp.StatusCode, Code: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		}
+		return retry, fmt.Errorf("{{.Package}}: 解析响应失败: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest || r.Code != CodeSuccess {
+		code := r.Code
+		if code <= 0 {
+			code = resp.StatusCode
+		}
+		return retry, &Error{StatusCode: resp.StatusCode, Code: code, Message: r.Message}
+	}
+
+	if out != nil && len(r.Data) > 0 && string(r.Data) != "null" {
+		if err := json.Unmarshal(r.Data, out); err != nil {
+			return false, fmt.Errorf("{{.Package}}: 解析 data 失败: %w", err)
+		}
+	}
+	return false, nil
+}
+`
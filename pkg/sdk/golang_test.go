@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// TestGo 验证结构体字段的类型与 JSON tag，分页接口生成查询参数结构体与返回结构体
+func TestGo(t *testing.T) {
+	doc := openapi.New("Blog", "v1", "")
+	article := openapi.Object(nil, "title")
+	article.AddProperty("id", &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64", ReadOnly: true})
+	article.AddProperty("title", openapi.String())
+	article.AddProperty("category_id", (&openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64"}).Nullable())
+	doc.Components.Schemas["Article"] = article
+
+	op := &openapi.Operation{OperationID: "ListArticles"}
+	op.Query("page", "页码", openapi.Integer())
+	op.Success("成功", openapi.Object(map[string]*openapi.Schema{
+		"list":  openapi.ArrayOf(openapi.Ref("Article")),
+		"total": {Type: openapi.Types{"integer"}, Format: "int64"},
+	}, "list", "total"))
+	if err := doc.AddOperation("GET", "/articles", op); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Go(doc, "blog")
+	if err != nil {
+		t.Fatalf("Go() unexpected error: %v", err)
+	}
+	types, client := string(files["types.go"]), string(files["client.go"])
+	for _, want := range []string{
+		"ID         int64  `json:\"id,omitempty\"`",
+		"CategoryID *int64 `json:\"category_id,omitempty\"`",
+		"List  []Article `json:\"list\"`",
+		"type ListArticlesParams struct",
+	} {
+		if !strings.Contains(types, want) {
+			t.Errorf("types.go missing %q\n%s", want, types)
+		}
+	}
+	want := "func (c *Client) ListArticles(ctx context.Context, params *ListArticlesParams) (*ListArticlesResult, error) {"
+	if !strings.Contains(client, want) {
+		t.Errorf("client.go missing %q\n%s", want, client)
+	}
+}
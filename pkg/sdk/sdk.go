@@ -0,0 +1,173 @@
+// Package sdk 根据 OpenAPI 文档生成调用方 SDK
+//
+// spec 与 gen db 都先构建 OpenAPI 文档，再由文档生成客户端，
+// 因此客户端的类型、分页参数与认证要求始终与接口文档一致。
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// endpoint 客户端的一个方法
+type endpoint struct {
+	Name       string // 方法名，来自 operationId
+	Method     string
+	Path       string // OpenAPI 路径，如 /articles/{id}
+	Summary    string
+	Permission string
+	Auth       bool // 需要 Bearer Token
+	PathParams []param
+	Query      []param
+	Body       *openapi.Schema // 请求体，nil 表示没有
+	Data       *openapi.Schema // 统一响应结构中 data 的结构，nil 表示没有数据
+}
+
+// param 路径或查询参数
+type param struct {
+	Name        string
+	Description string
+	Required    bool
+	Schema      *openapi.Schema
+}
+
+// endpoints 文档中的接口，按路径与方法排序；operationId 重复或缺失时按方法与路径补齐
+func endpoints(doc *openapi.Document) []endpoint {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var list []endpoint
+	seen := make(map[string]int)
+	for _, path := range paths {
+		for _, method := range openapi.Methods {
+			op := doc.Paths[path].Operation(method)
+			if op == nil {
+				continue
+			}
+
+			ep := endpoint{
+				Name:       exportedName(op.OperationID),
+				Method:     method,
+				Path:       path,
+				Summary:    op.Summary,
+				Permission: op.Permission,
+				Auth:       requiresAuth(doc, op),
+				Data:       responseData(op),
+			}
+			if ep.Name == "" {
+				ep.Name = exportedName(strings.ToLower(method) + "_" + path)
+			}
+			if seen[ep.Name]++; seen[ep.Name] > 1 {
+				ep.Name = fmt.Sprintf("%s%d", ep.Name, seen[ep.Name])
+			}
+
+			for _, p := range op.Parameters {
+				if p.Ref != "" {
+					p = doc.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+				}
+				if p == nil {
+					continue
+				}
+				switch p.In {
+				case "path":
+					ep.PathParams = append(ep.PathParams, param{p.Name, p.Description, true, p.Schema})
+				case "query":
+					ep.Query = append(ep.Query, param{p.Name, p.Description, p.Required, p.Schema})
+				}
+			}
+			if op.RequestBody != nil {
+				ep.Body = op.RequestBody.Content["application/json"].Schema
+			}
+
+			list = append(list, ep)
+		}
+	}
+	return list
+}
+
+// requiresAuth 接口是否需要 Bearer Token：接口未声明 security 时使用全局要求，空列表表示无需认证
+func requiresAuth(doc *openapi.Document, op *openapi.Operation) bool {
+	security := op.Security
+	if security == nil {
+		security = doc.Security
+	}
+	for _, req := range security {
+		if _, ok := req[openapi.BearerAuth]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// responseData 200 响应中统一响应结构的 data 结构（见 openapi.Envelope），没有数据时返回 nil
+func responseData(op *openapi.Operation) *openapi.Schema {
+	resp := op.Responses["200"]
+	if resp == nil {
+		return nil
+	}
+	schema := resp.Content["application/json"].Schema
+	if schema == nil {
+		return nil
+	}
+	for _, part := range schema.AllOf {
+		if data := part.Properties["data"]; data != nil {
+			return data
+		}
+	}
+	return nil
+}
+
+// schemaNames components.schemas 中除统一响应结构外的结构名，按名称排序
+func schemaNames(doc *openapi.Document) []string {
+	var names []string
+	for name := range doc.Components.Schemas {
+		if name != openapi.ResponseSchema {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// refName 引用的结构名，如 #/components/schemas/Article → Article
+func refName(ref string) string {
+	return exportedName(ref[strings.LastIndex(ref, "/")+1:])
+}
+
+// exportedName 转换为大驼峰标识符，如 get_article、/articles/{id} → GetArticle、ArticlesId
+func exportedName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteByte('X')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lowerName 转换为小驼峰标识符，如 category_id → categoryId，GetArticle → getArticle
+func lowerName(s string) string {
+	name := []rune(exportedName(s))
+	if len(name) == 0 {
+		return ""
+	}
+	name[0] = unicode.ToLower(name[0])
+	return string(name)
+}
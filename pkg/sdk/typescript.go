@@ -0,0 +1,383 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// tsInterface components.schemas 中的一个结构
+type tsInterface struct {
+	Name    string
+	Comment string
+	Fields  []tsField
+	Alias   string // 不是对象时生成 type 别名
+}
+
+// tsField 接口字段
+type tsField struct {
+	Name     string
+	Type     string
+	Comment  string
+	Optional bool
+	ReadOnly bool
+}
+
+// tsMethod 客户端方法
+type tsMethod struct {
+	Name    string
+	Comment []string
+	Params  string // 方法参数列表
+	Return  string // data 的类型，没有数据时为 void
+	Method  string
+	Path    string // 模板字符串，路径参数已替换为 ${...}
+	Options string // request 的第三个参数
+}
+
+// TypeScript 生成 TypeScript 客户端：
+// components.schemas 生成为 interface，每个接口生成 Client 的一个方法，
+// 基于 fetch 发送请求，解包统一响应结构 {code, message, data}，失败时抛出 ApiError
+func TypeScript(doc *openapi.Document) ([]byte, error) {
+	var interfaces []tsInterface
+	for _, name := range schemaNames(doc) {
+		interfaces = append(interfaces, newTSInterface(refName(name), doc.Components.Schemas[name]))
+	}
+
+	var methods []tsMethod
+	for _, ep := range endpoints(doc) {
+		methods = append(methods, newTSMethod(ep))
+	}
+
+	baseURL := ""
+	if len(doc.Servers) > 0 {
+		baseURL = doc.Servers[0].URL
+	}
+
+	tmpl, err := template.New("client.ts").Parse(tsTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("解析模板失败: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{
+		"Info":       doc.Info,
+		"BaseURL":    baseURL,
+		"Interfaces": interfaces,
+		"Methods":    methods,
+	}); err != nil {
+		return nil, fmt.Errorf("执行模板失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// newTSInterface 结构对应的 interface，非对象结构生成 type 别名
+func newTSInterface(name string, schema *openapi.Schema) tsInterface {
+	it := tsInterface{Name: name, Comment: schema.Description}
+	if !schema.Is("object") || len(schema.Properties) == 0 {
+		it.Alias = tsType(schema)
+		return it
+	}
+	for _, prop := range schema.PropertyNames() {
+		it.Fields = append(it.Fields, tsProperty(schema, prop))
+	}
+	return it
+}
+
+// tsProperty 对象的一个属性：不在 required 中的属性可省略，只读属性（自增主键、时间戳）为 readonly
+func tsProperty(schema *openapi.Schema, name string) tsField {
+	prop := schema.Properties[name]
+	return tsField{
+		Name:     tsKey(name),
+		Type:     tsType(prop),
+		Comment:  prop.Description,
+		Optional: !slices.Contains(schema.Required, name),
+		ReadOnly: prop.ReadOnly,
+	}
+}
+
+// tsType 结构对应的 TypeScript 类型
+func tsType(s *openapi.Schema) string {
+	if s == nil {
+		return "unknown"
+	}
+	switch {
+	case s.Ref != "":
+		return refName(s.Ref)
+	case len(s.AllOf) > 0:
+		return joinTypes(s.AllOf, " & ")
+	case len(s.OneOf) > 0:
+		return joinTypes(s.OneOf, " | ")
+	case len(s.AnyOf) > 0:
+		return joinTypes(s.AnyOf, " | ")
+	}
+
+	var types []string
+	if len(s.Enum) > 0 {
+		for _, v := range s.Enum {
+			literal, _ := json.Marshal(v)
+			types = append(types, string(literal))
+		}
+	}
+	for _, t := range s.Type {
+		switch t {
+		case "string", "boolean":
+			if len(s.Enum) == 0 {
+				types = append(types, t)
+			}
+		case "integer", "number":
+			if len(s.Enum) == 0 {
+				types = append(types, "number")
+			}
+		case "array":
+			item := tsType(s.Items)
+			if strings.ContainsAny(item, "|&") {
+				item = "(" + item + ")"
+			}
+			types = append(types, item+"[]")
+		case "object":
+			types = append(types, tsObject(s))
+		case "null":
+			types = append(types, "null")
+		}
+	}
+	if len(types) == 0 {
+		return "unknown"
+	}
+	return strings.Join(types, " | ")
+}
+
+// tsObject 内联对象类型，没有属性时为 Record<string, unknown>
+func tsObject(s *openapi.Schema) string {
+	if len(s.Properties) == 0 {
+		return "Record<string, unknown>"
+	}
+	var fields []string
+	for _, name := range s.PropertyNames() {
+		f := tsProperty(s, name)
+		optional := ""
+		if f.Optional {
+			optional = "?"
+		}
+		fields = append(fields, f.Name+optional+": "+f.Type)
+	}
+	return "{ " + strings.Join(fields, "; ") + " }"
+}
+
+// joinTypes 组合多个结构的类型
+func joinTypes(schemas []*openapi.Schema, sep string) string {
+	types := make([]string, len(schemas))
+	for i, s := range schemas {
+		types[i] = tsType(s)
+	}
+	return strings.Join(types, sep)
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsKey 属性名，不是合法标识符时加引号
+func tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	quoted, _ := json.Marshal(name)
+	return string(quoted)
+}
+
+// newTSMethod 接口对应的客户端方法：路径参数按顺序在前，然后是请求体，最后是可选的查询参数
+func newTSMethod(ep endpoint) tsMethod {
+	m := tsMethod{Name: lowerName(ep.Name), Method: ep.Method, Path: ep.Path, Return: "void"}
+	if ep.Summary != "" {
+		m.Comment = append(m.Comment, ep.Summary)
+	}
+	if ep.Permission != "" {
+		m.Comment = append(m.Comment, "需要权限 `"+ep.Permission+"`")
+	}
+	if ep.Data != nil {
+		m.Return = tsType(ep.Data)
+	}
+
+	var params, options []string
+	for _, p := range ep.PathParams {
+		name := lowerName(p.Name)
+		params = append(params, name+": "+tsType(p.Schema))
+		m.Path = strings.ReplaceAll(m.Path, "{"+p.Name+"}", "${encodeURIComponent(String("+name+"))}")
+	}
+	if ep.Body != nil {
+		params = append(params, "body: "+tsType(ep.Body))
+		options = append(options, "body")
+	}
+	if len(ep.Query) > 0 {
+		var fields []string
+		required := false
+		for _, p := range ep.Query {
+			optional := "?"
+			if p.Required {
+				optional, required = "", true
+			}
+			fields = append(fields, tsKey(p.Name)+optional+": "+strings.TrimSuffix(tsType(p.Schema), " | null"))
+		}
+		query := "query: { " + strings.Join(fields, "; ") + " }"
+		if !required {
+			query += " = {}"
+		}
+		params = append(params, query)
+		options = append(options, "query")
+	}
+	if ep.Auth {
+		options = append(options, "auth: true")
+	}
+
+	m.Params = strings.Join(params, ", ")
+	if len(options) > 0 {
+		m.Options = ", { " + strings.Join(options, ", ") + " }"
+	}
+	return m
+}
+
+const tsTemplate = `// Code generated by go-start. DO NOT EDIT.
+// {{.Info.Title}} {{.Info.Version}}
+
+/** 统一响应结构 */
+export interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data?: T;
+}
+
+/** 业务错误码，与服务端 response.Code* 一致；响应没有业务错误码（如 code 为 -1）时取 HTTP 状态码 */
+export const ErrorCode = {
+  Success: 0,
+  InvalidParams: 400,
+  Unauthorized: 401,
+  Forbidden: 403,
+  NotFound: 404,
+  Conflict: 409,
+  PreconditionFailed: 412,
+  Unprocessable: 422,
+  InternalError: 500,
+  ServiceUnavailable: 503,
+} as const;
+
+/** 请求失败：HTTP 状态码不是 2xx，或统一响应结构的 code 不为 0 */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly code: number,
+    message: string,
+  ) {
+    super(message);
+    this.name = 'ApiError';
+  }
+
+  /** 是否为指定错误码，如 err.is(ErrorCode.NotFound) */
+  is(code: number): boolean {
+    return this.code === code;
+  }
+}
+{{range .Interfaces}}
+{{- if .Comment}}
+/** {{.Comment}} */
+{{- else}}
+{{end}}
+{{- if .Alias}}
+export type {{.Name}} = {{.Alias}};
+{{- else}}
+export interface {{.Name}} {
+{{- range .Fields}}
+{{- if .Comment}}
+  /** {{.Comment}} */
+{{- end}}
+  {{if .ReadOnly}}readonly {{end}}{{.Name}}{{if .Optional}}?{{end}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+{{end}}
+export interface ClientOptions {
+  /** 接口地址，默认 {{if .BaseURL}}{{.BaseURL}}{{else}}当前域名{{end}} */
+  baseURL?: string;
+  /** 返回 Bearer Token，需要认证的接口会在 Authorization 请求头中携带 */
+  token?: () => string | null | undefined | Promise<string | null | undefined>;
+  /** 附加的请求头 */
+  headers?: Record<string, string>;
+  /** 自定义 fetch，默认使用全局 fetch */
+  fetch?: typeof fetch;
+}
+
+type Query = Record<string, string | number | boolean | null | undefined>;
+
+interface RequestOptions {
+  query?: Query;
+  body?: unknown;
+  auth?: boolean;
+}
+
+export class Client {
+  private readonly baseURL: string;
+
+  constructor(private readonly options: ClientOptions = {}) {
+    this.baseURL = (options.baseURL ?? '{{.BaseURL}}').replace(/\/+$/, '');
+  }
+{{range .Methods}}
+{{- if .Comment}}
+  /**
+{{- range .Comment}}
+   * {{.}}
+{{- end}}
+   */
+{{- else}}
+{{end}}
+  {{.Name}}({{.Params}}): Promise<{{.Return}}> {
+    return this.request('{{.Method}}', ` + "`{{.Path}}`" + `{{.Options}});
+  }
+{{end}}
+  private async request<T>(method: string, path: string, opts: RequestOptions = {}): Promise<T> {
+    let url = this.baseURL + path;
+    if (opts.query) {
+      const params = new URLSearchParams();
+      for (const [key, value] of Object.entries(opts.query)) {
+        if (value !== undefined && value !== null) {
+          params.append(key, String(value));
+        }
+      }
+      const search = params.toString();
+      if (search) {
+        url += '?' + search;
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: 'application/json', ...this.options.headers };
+    if (opts.body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+    }
+    if (opts.auth && this.options.token) {
+      const token = await this.options.token();
+      if (token) {
+        headers['Authorization'] = ` + "`Bearer ${token}`" + `;
+      }
+    }
+
+    const res = await (this.options.fetch ?? fetch)(url, {
+      method,
+      headers,
+      body: opts.body === undefined ? undefined : JSON.stringify(opts.body),
+    });
+
+    let payload: ApiResponse<T> | undefined;
+    try {
+      payload = (await res.json()) as ApiResponse<T>;
+    } catch {
+      payload = undefined;
+    }
+    if (!res.ok || !payload || payload.code !== ErrorCode.Success) {
+      const code = payload && payload.code > 0 ? payload.code : res.status;
+      throw new ApiError(res.status, code, payload?.message || res.statusText);
+    }
+    return payload.data as T;
+  }
+}
+`
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// TestTypeScript 验证结构生成 interface（可选、只读、可空与枚举），接口生成带路径参数、请求体与认证的方法
+func TestTypeScript(t *testing.T) {
+	doc := openapi.New("Blog", "v1", "")
+	doc.Servers = []openapi.Server{{URL: "/api/v1"}}
+
+	article := openapi.Object(nil, "title")
+	article.AddProperty("id", &openapi.Schema{Type: openapi.Types{"integer"}, ReadOnly: true})
+	article.AddProperty("title", openapi.String())
+	article.AddProperty("status", (&openapi.Schema{Type: openapi.Types{"integer"}, Enum: []interface{}{1, 2}}).Nullable())
+	doc.Components.Schemas["Article"] = article
+
+	op := &openapi.Operation{OperationID: "UpdateArticle"}
+	op.JSONBody("Article", openapi.Ref("Article"))
+	op.Success("成功", nil)
+	doc.RequireAuth(op)
+	if err := doc.AddOperation("PUT", "/articles/:article_id", op); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := TypeScript(doc)
+	if err != nil {
+		t.Fatalf("TypeScript() unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"export interface Article {\n  readonly id?: number;\n  title: string;\n  status?: 1 | 2 | null;\n}",
+		"updateArticle(articleId: string, body: Article): Promise<void> {",
+		"this.request('PUT', `/articles/${encodeURIComponent(String(articleId))}`, { body, auth: true });",
+		"options.baseURL ?? '/api/v1'",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("TypeScript() output missing %q\n%s", want, out)
+		}
+	}
+}
@@ -1,17 +1,22 @@
 package spec
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
 )
 
 // Generator represents the code generator
 type Generator struct {
 	spec      *Spec
 	outputDir string
+	targets   map[string]bool // 生成目标（http、grpc），为空时只生成 http
 }
 
 // NewGenerator creates a new code generator
@@ -36,26 +41,40 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("生成仓储失败: %w", err)
 	}
 
-	// 3. Generate services
+	// 3. Generate request validators (if any), also used by the handler stubs of services
+	if len(g.spec.Requests) > 0 {
+		if err := g.generateValidators(); err != nil {
+			fmt.Printf("⚠️  生成验证器跳过（模板未实现）\n")
+		}
+	}
+
+	// 4. Generate services
 	if err := g.generateServices(); err != nil {
 		return fmt.Errorf("生成服务失败: %w", err)
 	}
 
-	// 4. Generate controllers
-	if err := g.generateControllers(); err != nil {
-		return fmt.Errorf("生成控制器失败: %w", err)
-	}
+	if g.target(TargetHTTP) {
+		// 5. Generate controllers
+		if err := g.generateControllers(); err != nil {
+			return fmt.Errorf("生成控制器失败: %w", err)
+		}
 
-	// 5. Generate request validators (if any)
-	if len(g.spec.Requests) > 0 {
-		if err := g.generateValidators(); err != nil {
-			fmt.Printf("⚠️  生成验证器跳过（模板未实现）\n")
+		// 6. Generate OpenAPI document and Swagger UI
+		if err := g.generateDocs(); err != nil {
+			return fmt.Errorf("生成接口文档失败: %w", err)
+		}
+
+		// 7. Generate routes
+		if err := g.generateRoutes(); err != nil {
+			fmt.Printf("⚠️  生成路由跳过（模板未实现）\n")
 		}
 	}
 
-	// 6. Generate routes
-	if err := g.generateRoutes(); err != nil {
-		fmt.Printf("⚠️  生成路由跳过（模板未实现）\n")
+	// 8. Generate .proto files and gRPC servers
+	if g.target(TargetGRPC) {
+		if err := g.generateGRPC(); err != nil {
+			return fmt.Errorf("生成 gRPC 服务失败: %w", err)
+		}
 	}
 
 	fmt.Printf("\n✅ 代码生成完成！\n")
@@ -70,12 +89,25 @@ func (g *Generator) generateModels() error {
 		outputPath := filepath.Join(g.outputDir, "internal/model", strings.ToLower(model.Name)+".go")
 
 		if err := g.generateFile("model.go.tmpl", outputPath, map[string]interface{}{
-			"Spec":  g.spec,
-			"Model": model,
+			"Spec":      g.spec,
+			"Model":     model,
+			"Imports":   modelImports(g.spec.Project.Types, model),
+			"Relations": modelRelations(g.spec, model),
 		}); err != nil {
 			return err
 		}
 
+		if enums := modelEnums(g.spec.Project.Types, model); len(enums) > 0 {
+			src, err := typemap.RenderEnums("model", enums)
+			if err != nil {
+				return fmt.Errorf("模型 %s: %w", model.Name, err)
+			}
+			enumPath := filepath.Join(g.outputDir, "internal/model", strings.ToLower(model.Name)+"_enums.go")
+			if err := os.WriteFile(enumPath, src, 0644); err != nil {
+				return fmt.Errorf("写入枚举文件失败: %w", err)
+			}
+		}
+
 		fmt.Printf("  ✓ %s\n", model.Name)
 	}
 
@@ -86,12 +118,39 @@ func (g *Generator) generateModels() error {
 func (g *Generator) generateRepositories() error {
 	fmt.Println("\n📦 生成数据访问层...")
 
+	if err := g.generateFile("mock_common.go.tmpl", filepath.Join(g.outputDir, "internal/repository/mock", "mock.go"), nil); err != nil {
+		return err
+	}
+
+	// Shared preload helper, only needed when some model declares relations
+	for _, model := range g.spec.Models {
+		if len(model.Relations) > 0 {
+			if err := g.generateFile("preload.go.tmpl", filepath.Join(g.outputDir, "internal/repository", "preload.go"), nil); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
 	for _, model := range g.spec.Models {
 		outputPath := filepath.Join(g.outputDir, "internal/repository", strings.ToLower(model.Name)+".go")
 
 		if err := g.generateFile("repository.go.tmpl", outputPath, map[string]interface{}{
-			"Spec":  g.spec,
-			"Model": model,
+			"Spec":      g.spec,
+			"Model":     model,
+			"Paging":    listPaging(g.spec.GetEndpointsByModel(model.Name)),
+			"Relations": modelRelations(g.spec, model),
+		}); err != nil {
+			return err
+		}
+
+		// Mock implementation of the repository interface, used by the generated tests
+		mockPath := filepath.Join(g.outputDir, "internal/repository/mock", strings.ToLower(model.Name)+".go")
+		if err := g.generateFile("mock.go.tmpl", mockPath, map[string]interface{}{
+			"Spec":      g.spec,
+			"Model":     model,
+			"Paging":    listPaging(g.spec.GetEndpointsByModel(model.Name)),
+			"Relations": modelRelations(g.spec, model),
 		}); err != nil {
 			return err
 		}
@@ -106,6 +165,16 @@ func (g *Generator) generateRepositories() error {
 func (g *Generator) generateServices() error {
 	fmt.Println("\n📦 生成业务逻辑层...")
 
+	// Shared cursor errors, only needed when some model lists by cursor
+	for _, model := range g.spec.Models {
+		if listPaging(g.spec.GetEndpointsByModel(model.Name)).Mode == PaginationCursor {
+			if err := g.generateFile("cursor.go.tmpl", filepath.Join(g.outputDir, "internal/service", "cursor.go"), nil); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
 	for _, model := range g.spec.Models {
 		outputPath := filepath.Join(g.outputDir, "internal/service", strings.ToLower(model.Name)+".go")
 
@@ -138,13 +207,77 @@ func (g *Generator) generateServices() error {
 			"ListCacheEnabled": listCacheEnabled,
 			"GetCacheTTL":      getCacheTTL,
 			"ListCacheTTL":     listCacheTTL,
+			"Paging":           listPaging(endpoints),
+			"Relations":        modelRelations(g.spec, model),
+		}); err != nil {
+			return err
+		}
+
+		testPath := filepath.Join(g.outputDir, "internal/service", strings.ToLower(model.Name)+"_test.go")
+		if err := g.generateFile("service_test.go.tmpl", testPath, map[string]interface{}{
+			"Spec":      g.spec,
+			"Model":     model,
+			"Relations": modelRelations(g.spec, model),
 		}); err != nil {
 			return err
 		}
 
+		// Lifecycle hooks, with the spec rules registered as rule<Name> stubs kept across regeneration
+		hooks := modelHooks(g.spec, model)
+		hooksData := map[string]interface{}{
+			"Spec":  g.spec,
+			"Model": model,
+			"Hooks": hooks,
+		}
+		src, err := g.renderTemplate("hooks.go.tmpl", hooksData)
+		if err != nil {
+			return err
+		}
+		// Formatted so the hook lists and rule registrations stay aligned
+		if src, err = mergeStubs(nil, src); err != nil {
+			return fmt.Errorf("%s 钩子: %w", model.Name, err)
+		}
+		hooksPath := filepath.Join(g.outputDir, "internal/service", strings.ToLower(model.Name)+"_hooks.go")
+		if err := os.WriteFile(hooksPath, src, 0644); err != nil {
+			return fmt.Errorf("写入输出文件失败: %w", err)
+		}
+		if hasRules(hooks) {
+			rulesPath := filepath.Join(g.outputDir, "internal/service", strings.ToLower(model.Name)+"_rules.go")
+			if err := g.generateStubs("rules.go.tmpl", rulesPath, hooksData); err != nil {
+				return err
+			}
+		}
+
 		fmt.Printf("  ✓ %sService\n", model.Name)
 	}
 
+	return g.generateHandlerServices()
+}
+
+// generateHandlerServices generates the service stubs of custom endpoints
+// (internal/service/<owner>_handlers.go); hand-written code in existing files is kept
+func (g *Generator) generateHandlerServices() error {
+	owners, err := customHandlers(g.spec)
+	if err != nil {
+		return err
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+
+	if err := g.generateFile("handler_service_common.go.tmpl", filepath.Join(g.outputDir, "internal/service", "handlers.go"), nil); err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		outputPath := filepath.Join(g.outputDir, "internal/service", strings.ToLower(owner.Name)+"_handlers.go")
+		if err := g.generateStubs("handler_service.go.tmpl", outputPath, map[string]interface{}{
+			"Spec":  g.spec,
+			"Owner": owner,
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("  ✓ %sService 自定义端点（%d 个）\n", owner.Name, len(owner.Handlers))
+	}
 	return nil
 }
 
@@ -228,6 +361,17 @@ func (g *Generator) generateControllers() error {
 			"GetPerm":         getPerm,
 			"DeletePerm":      deletePerm,
 			"ListPerm":        listPerm,
+			"Paging":          listPaging(endpoints),
+			"Relations":       modelRelations(g.spec, model),
+		}); err != nil {
+			return err
+		}
+
+		testPath := filepath.Join(g.outputDir, "internal/controller", strings.ToLower(model.Name)+"_test.go")
+		if err := g.generateFile("controller_test.go.tmpl", testPath, map[string]interface{}{
+			"Spec":      g.spec,
+			"Model":     model,
+			"Relations": modelRelations(g.spec, model),
 		}); err != nil {
 			return err
 		}
@@ -235,9 +379,72 @@ func (g *Generator) generateControllers() error {
 		fmt.Printf("  ✓ %sController\n", model.Name)
 	}
 
+	return g.generateHandlerControllers()
+}
+
+// generateHandlerControllers generates the controller methods of custom endpoints
+// (internal/controller/<owner>_handlers.go), binding path params and the validate request
+func (g *Generator) generateHandlerControllers() error {
+	owners, err := customHandlers(g.spec)
+	if err != nil {
+		return err
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+
+	if err := g.generateFile("handler_controller_common.go.tmpl", filepath.Join(g.outputDir, "internal/controller", "handlers.go"), map[string]interface{}{
+		"Spec": g.spec,
+	}); err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		src, err := g.renderTemplate("handler_controller.go.tmpl", map[string]interface{}{
+			"Spec":  g.spec,
+			"Owner": owner,
+		})
+		if err != nil {
+			return err
+		}
+		// The template imports every package a handler may need; drop the unused ones
+		if src, err = mergeStubs(nil, src); err != nil {
+			return fmt.Errorf("%sController: %w", owner.Name, err)
+		}
+		outputPath := filepath.Join(g.outputDir, "internal/controller", strings.ToLower(owner.Name)+"_handlers.go")
+		if err := os.WriteFile(outputPath, src, 0644); err != nil {
+			return fmt.Errorf("写入输出文件失败: %w", err)
+		}
+		fmt.Printf("  ✓ %sController 自定义端点（%d 个）\n", owner.Name, len(owner.Handlers))
+	}
 	return nil
 }
 
+// listPaging returns the List pagination settings of a model, taken from its list
+// endpoint (GET without path params); offset paging with 20/100 page sizes by default
+func listPaging(endpoints []APIEndpoint) PaginationConfig {
+	cfg := PaginationConfig{Mode: PaginationOffset}
+	for _, ep := range endpoints {
+		if strings.EqualFold(ep.Method, "GET") && !strings.Contains(ep.Path, ":") {
+			if p, err := ep.Paging(); err == nil && p != nil {
+				cfg = *p
+			}
+			break
+		}
+	}
+	return pagingDefaults(cfg)
+}
+
+// pagingDefaults fills in the default page sizes: 20 per page, at most 100
+func pagingDefaults(cfg PaginationConfig) PaginationConfig {
+	if cfg.MaxPageSize <= 0 {
+		cfg.MaxPageSize = 100
+	}
+	if cfg.PageSize <= 0 || cfg.PageSize > cfg.MaxPageSize {
+		cfg.PageSize = min(20, cfg.MaxPageSize)
+	}
+	return cfg
+}
+
 // generateValidators generates validator files
 func (g *Generator) generateValidators() error {
 	fmt.Println("\n📦 生成请求验证器...")
@@ -258,80 +465,41 @@ func (g *Generator) generateValidators() error {
 	return nil
 }
 
+// generateDocs generates the OpenAPI document and the docs package serving Swagger UI
+func (g *Generator) generateDocs() error {
+	fmt.Println("\n📦 生成接口文档...")
+
+	doc, err := BuildOpenAPI(g.spec)
+	if err != nil {
+		return err
+	}
+	if err := openapi.WriteDocs(filepath.Join(g.outputDir, "docs"), doc); err != nil {
+		return err
+	}
+
+	fmt.Printf("  ✓ docs/openapi.yaml、docs/openapi.json 与 Swagger UI\n")
+	return nil
+}
+
 // generateRoutes generates route registration
 func (g *Generator) generateRoutes() error {
 	fmt.Println("\n📦 生成路由注册...")
 
 	outputPath := filepath.Join(g.outputDir, "internal/routes", "auto_routes.go")
 
-	// Build per-model auth/permission info for routes
-	type routeInfo struct {
-		ModelName  string
-		ModelVar   string
-		CreateAuth bool
-		UpdateAuth bool
-		GetAuth    bool
-		DeleteAuth bool
-		ListAuth   bool
-		CreatePerm string
-		UpdatePerm string
-		GetPerm    string
-		DeletePerm string
-		ListPerm   string
-	}
-
 	var modelsInfo []routeInfo
 	for _, model := range g.spec.Models {
-		endpoints := g.spec.GetEndpointsByModel(model.Name)
-		ri := routeInfo{ModelName: model.Name, ModelVar: toLowerCamelCase(model.Name)}
-		for _, ep := range endpoints {
-			m := strings.ToUpper(ep.Method)
-			switch m {
-			case "POST":
-				if ep.Auth {
-					ri.CreateAuth = true
-				}
-				if ep.Permission != "" && ri.CreatePerm == "" {
-					ri.CreatePerm = ep.Permission
-				}
-			case "PUT":
-				if ep.Auth {
-					ri.UpdateAuth = true
-				}
-				if ep.Permission != "" && ri.UpdatePerm == "" {
-					ri.UpdatePerm = ep.Permission
-				}
-			case "GET":
-				if strings.Contains(ep.Path, ":") {
-					if ep.Auth {
-						ri.GetAuth = true
-					}
-					if ep.Permission != "" && ri.GetPerm == "" {
-						ri.GetPerm = ep.Permission
-					}
-				} else {
-					if ep.Auth {
-						ri.ListAuth = true
-					}
-					if ep.Permission != "" && ri.ListPerm == "" {
-						ri.ListPerm = ep.Permission
-					}
-				}
-			case "DELETE":
-				if ep.Auth {
-					ri.DeleteAuth = true
-				}
-				if ep.Permission != "" && ri.DeletePerm == "" {
-					ri.DeletePerm = ep.Permission
-				}
-			}
-		}
-		modelsInfo = append(modelsInfo, ri)
+		modelsInfo = append(modelsInfo, newRouteInfo(g.spec, model))
+	}
+	handlers, err := customHandlers(g.spec)
+	if err != nil {
+		return err
 	}
 
 	if err := g.generateFile("routes.go.tmpl", outputPath, map[string]interface{}{
 		"Spec":       g.spec,
 		"ModelsInfo": modelsInfo,
+		"Handlers":   handlers,
 	}); err != nil {
 		return err
 	}
@@ -341,6 +509,89 @@ func (g *Generator) generateRoutes() error {
 	return nil
 }
 
+// routeInfo per-model auth/permission of the CRUD operations, shared by Gin routes and gRPC
+//
+// Nested relation routes (GET /:id/<relation>) take the auth/permission of the endpoint
+// declared for the same route, and reuse those of GetByID otherwise.
+type routeInfo struct {
+	ModelName  string
+	ModelVar   string
+	CreateAuth bool
+	UpdateAuth bool
+	GetAuth    bool
+	DeleteAuth bool
+	ListAuth   bool
+	CreatePerm string
+	UpdatePerm string
+	GetPerm    string
+	DeletePerm string
+	ListPerm   string
+	Nested     []relationInfo
+}
+
+// newRouteInfo derives the auth/permission of each CRUD operation from the model's endpoints
+func newRouteInfo(s *Spec, model ModelDefinition) routeInfo {
+	ri := routeInfo{ModelName: model.Name, ModelVar: toLowerCamelCase(model.Name)}
+	for _, ep := range s.GetEndpointsByModel(model.Name) {
+		m := strings.ToUpper(ep.Method)
+		switch m {
+		case "POST":
+			if ep.Auth {
+				ri.CreateAuth = true
+			}
+			if ep.Permission != "" && ri.CreatePerm == "" {
+				ri.CreatePerm = ep.Permission
+			}
+		case "PUT":
+			if ep.Auth {
+				ri.UpdateAuth = true
+			}
+			if ep.Permission != "" && ri.UpdatePerm == "" {
+				ri.UpdatePerm = ep.Permission
+			}
+		case "GET":
+			if strings.Contains(ep.Path, ":") {
+				if ep.Auth {
+					ri.GetAuth = true
+				}
+				if ep.Permission != "" && ri.GetPerm == "" {
+					ri.GetPerm = ep.Permission
+				}
+			} else {
+				if ep.Auth {
+					ri.ListAuth = true
+				}
+				if ep.Permission != "" && ri.ListPerm == "" {
+					ri.ListPerm = ep.Permission
+				}
+			}
+		case "DELETE":
+			if ep.Auth {
+				ri.DeleteAuth = true
+			}
+			if ep.Permission != "" && ri.DeletePerm == "" {
+				ri.DeletePerm = ep.Permission
+			}
+		}
+	}
+
+	for _, rel := range modelRelations(s, model) {
+		if rel.Path == "" {
+			continue
+		}
+		rel.Auth, rel.Permission = ri.GetAuth, ri.GetPerm
+		route := "/" + pluralize(ri.ModelVar) + rel.Path
+		for _, ep := range s.APIs {
+			if strings.EqualFold(ep.Method, "GET") && sameRoute(ep.Path, route) {
+				rel.Auth, rel.Permission = ep.Auth, ep.Permission
+				break
+			}
+		}
+		ri.Nested = append(ri.Nested, rel)
+	}
+	return ri
+}
+
 // generateFile generates a single file from template
 func (g *Generator) generateFile(templateName, outputPath string, data interface{}) error {
 	// Create output directory
@@ -348,10 +599,51 @@ func (g *Generator) generateFile(templateName, outputPath string, data interface
 		return fmt.Errorf("创建输出目录失败: %w", err)
 	}
 
+	src, err := g.renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, src, 0644); err != nil {
+		return fmt.Errorf("创建输出文件失败: %w", err)
+	}
+
+	return nil
+}
+
+// generateStubs renders a stub file and merges it into the existing one,
+// keeping every declaration already there (see mergeStubs)
+func (g *Generator) generateStubs(templateName, outputPath string, data interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	src, err := g.renderTemplate(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(outputPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("读取已有文件失败: %w", err)
+	}
+	merged, err := mergeStubs(existing, src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", outputPath, err)
+	}
+
+	if err := os.WriteFile(outputPath, merged, 0644); err != nil {
+		return fmt.Errorf("写入输出文件失败: %w", err)
+	}
+	return nil
+}
+
+// renderTemplate executes a builtin template
+func (g *Generator) renderTemplate(templateName string, data interface{}) ([]byte, error) {
 	// Get template content
 	templateContent := getBuiltinTemplate(templateName)
 	if templateContent == "" {
-		return fmt.Errorf("模板 %s 不存在", templateName)
+		return nil, fmt.Errorf("模板 %s 不存在", templateName)
 	}
 
 	// Parse template with custom functions
@@ -359,32 +651,34 @@ func (g *Generator) generateFile(templateName, outputPath string, data interface
 		"ToCamelCase":      toCamelCase,
 		"ToLowerCamelCase": toLowerCamelCase,
 		"pluralize":        pluralize,
-		"getGoType":        getGoType,
-		"getGoType2":       getGoType2,
+		"getGoType":        func(fieldType string) string { return getGoType(g.spec.Project.Types, fieldType) },
+		"getGoType2":       func(field FieldDef) string { return getGoType2(g.spec.Project.Types, field) },
+		"getFieldType": func(model ModelDefinition, field FieldDef) string {
+			return getFieldType(g.spec.Project.Types, model, field)
+		},
+		"getBindingTag": func(model ModelDefinition, field FieldDef) string {
+			return getBindingTag(g.spec.Project.Types, model, field)
+		},
 		"getGormTag":       getGormTag,
 		"getJSONTag":       getJSONTag,
 		"getIndexTags":     getIndexTags,
 		"getReqFieldType":  getReqFieldType,
+		"primaryKeyName":   primaryKeyName,
+		"primaryKeyColumn": primaryKeyColumn,
 	}
 
 	tmpl, err := template.New(templateName).Funcs(funcMap).Parse(templateContent)
 	if err != nil {
-		return fmt.Errorf("解析模板失败: %w", err)
-	}
-
-	// Create output file
-	outputFile, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("创建输出文件失败: %w", err)
+		return nil, fmt.Errorf("解析模板失败: %w", err)
 	}
-	defer outputFile.Close()
 
 	// Execute template
-	if err := tmpl.Execute(outputFile, data); err != nil {
-		return fmt.Errorf("执行模板失败: %w", err)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("执行模板失败: %w", err)
 	}
 
-	return nil
+	return buf.Bytes(), nil
 }
 
 // Helper functions for templates
@@ -411,50 +705,119 @@ func pluralize(s string) string {
 	return s + "s"
 }
 
-func getGoType(fieldType string) string {
-	typeMap := map[string]string{
-		"uint":      "uint",
-		"int":       "int",
-		"string":    "string",
-		"text":      "string",
-		"bool":      "bool",
-		"float":     "float64",
-		"double":    "float64",
-		"decimal":   "float64",
-		"timestamp": "time.Time",
-		"date":      "time.Time",
-		"datetime":  "time.Time",
-		"json":      "string",
+// specTypes spec 中的通用类型名，其余类型（bigint、decimal、json、uuid 等）按数据库类型映射
+var specTypes = map[string]string{
+	"uint":      "uint",
+	"int":       "int",
+	"string":    "string",
+	"text":      "string",
+	"bool":      "bool",
+	"float":     "float64",
+	"double":    "float64",
+	"timestamp": "time.Time",
+	"date":      "time.Time",
+	"datetime":  "time.Time",
+}
+
+// getGoType 字段类型对应的 Go 类型，不考虑可空
+func getGoType(types typemap.Options, fieldType string) string {
+	return baseGoType(types, FieldDef{Type: fieldType})
+}
+
+// getGoType2 字段对应的 Go 类型，可空字段按 project.types.nullable 策略使用指针或 sql.Null*
+func getGoType2(types typemap.Options, field FieldDef) string {
+	base := baseGoType(types, field)
+	if field.NotNull || field.PrimaryKey {
+		return base
 	}
+	return types.NullableType(base)
+}
 
-	if goType, ok := typeMap[fieldType]; ok {
+// baseGoType 按 spec 类型名或数据库类型（含 size，如 tinyint + size 1 映射为 bool）计算 Go 类型
+func baseGoType(types typemap.Options, field FieldDef) string {
+	if goType, ok := specTypes[strings.ToLower(field.Type)]; ok {
 		return goType
 	}
 
-	return "string" // default
+	columnType := field.Type
+	if field.Size > 0 {
+		columnType = fmt.Sprintf("%s(%d)", field.Type, field.Size)
+	}
+	return types.BaseType(typemap.Column{Type: field.Type, ColumnType: columnType})
 }
 
-func getGoType2(field FieldDef) string {
-	base := getGoType(field.Type)
-	if field.NotNull {
-		return base
+// getFieldType 模型字段的 Go 类型，带 enum 的字段使用生成的枚举类型
+func getFieldType(types typemap.Options, model ModelDefinition, field FieldDef) string {
+	enum := specEnum(types, model, field)
+	if enum == nil {
+		return getGoType2(types, field)
+	}
+	if field.NotNull || field.PrimaryKey {
+		return enum.TypeName
+	}
+	return types.NullableType(enum.TypeName)
+}
+
+// getBindingTag 模型字段的 binding 标签，枚举字段在创建/更新时按 oneof 校验
+func getBindingTag(types typemap.Options, model ModelDefinition, field FieldDef) string {
+	enum := specEnum(types, model, field)
+	if enum == nil {
+		return ""
+	}
+	if binding := enum.BindingTag(); binding != "" {
+		return ` binding:"` + binding + `"`
+	}
+	return ""
+}
+
+// specEnum 字段的枚举类型（类型名为模型名+字段名，如 ArticleStatus），没有 enum 时返回 nil
+func specEnum(types typemap.Options, model ModelDefinition, field FieldDef) *typemap.Enum {
+	if len(field.Enum) == 0 {
+		return nil
+	}
+
+	enum := typemap.Enum{
+		TypeName: model.Name + toCamelCase(field.Name),
+		BaseType: baseGoType(types, field),
+		Comment:  field.Comment,
+	}
+	if enum.Comment == "" {
+		enum.Comment = model.Name + "." + field.Name + " 的取值"
+	}
+	for _, v := range field.Enum {
+		enum.Values = append(enum.Values, typemap.EnumValue{Name: v.Name, Value: v.Value, Label: v.Label})
+	}
+	enum.Normalize()
+	return &enum
+}
+
+// isIntegerType 是否为整数类型
+func isIntegerType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// modelImports 模型字段类型需要导入的包
+func modelImports(types typemap.Options, model ModelDefinition) []string {
+	var goTypes []string
+	for _, field := range model.Fields {
+		goTypes = append(goTypes, getFieldType(types, model, field))
 	}
-	switch base {
-	case "uint":
-		return "*uint"
-	case "int":
-		return "*int"
-	case "string":
-		return "*string"
-	case "bool":
-		return "*bool"
-	case "float64":
-		return "*float64"
-	case "time.Time":
-		return "*time.Time"
-	default:
-		return "*" + base
+	return typemap.Imports(goTypes...)
+}
+
+// modelEnums 模型中所有枚举字段的类型定义
+func modelEnums(types typemap.Options, model ModelDefinition) []typemap.Enum {
+	var enums []typemap.Enum
+	for _, field := range model.Fields {
+		if enum := specEnum(types, model, field); enum != nil {
+			enums = append(enums, *enum)
+		}
 	}
+	return enums
 }
 
 func getGormTag(field FieldDef) string {
@@ -0,0 +1,326 @@
+package spec
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
+)
+
+// 生成目标
+const (
+	TargetHTTP = "http" // Gin 控制器、路由与 OpenAPI 文档
+	TargetGRPC = "grpc" // .proto 文件与 gRPC 服务实现
+)
+
+// SetTargets 设置生成目标（http、grpc），模型、仓储与服务层各目标共用，默认只生成 http
+func (g *Generator) SetTargets(targets ...string) error {
+	g.targets = make(map[string]bool)
+	for _, target := range targets {
+		for _, t := range strings.Split(target, ",") {
+			switch t = strings.ToLower(strings.TrimSpace(t)); t {
+			case TargetHTTP, TargetGRPC:
+				g.targets[t] = true
+			case "":
+			default:
+				return fmt.Errorf("不支持的生成目标 %q (支持: %s, %s)", t, TargetHTTP, TargetGRPC)
+			}
+		}
+	}
+	if g.targets[TargetGRPC] && g.spec.Project.Types.Nullable == typemap.NullableSQLNull {
+		return fmt.Errorf("gRPC 目标暂不支持 project.types.nullable: %s，请使用 %s", typemap.NullableSQLNull, typemap.NullablePointer)
+	}
+	return nil
+}
+
+// target 是否生成指定目标，未设置时只生成 http
+func (g *Generator) target(name string) bool {
+	if len(g.targets) == 0 {
+		return name == TargetHTTP
+	}
+	return g.targets[name]
+}
+
+// protoField 模型字段在 .proto 中的定义，以及与 model 结构体之间的转换表达式
+type protoField struct {
+	Name     string // proto 字段名，与 spec 字段名一致
+	Number   int    // 字段编号
+	Type     string // proto 类型，如 uint64、google.protobuf.Timestamp
+	Repeated bool
+	Optional bool
+	Comment  string
+
+	GoName   string // model 结构体字段名
+	PBName   string // protoc-gen-go 生成的字段名
+	ToPB     string // model → pb 的表达式，m 为 *model.X
+	FromPB   string // pb → model 的表达式，p 为 *pb.X
+	Fallible bool   // FromPB 返回 (值, error)，如 decimal 解析
+}
+
+// protoScalars Go 基础类型对应的 proto 类型与 protoc-gen-go 生成的 Go 类型
+var protoScalars = map[string][2]string{
+	"bool":           {"bool", "bool"},
+	"string":         {"string", "string"},
+	"[]byte":         {"bytes", "[]byte"},
+	"int":            {"int64", "int64"},
+	"int8":           {"int32", "int32"},
+	"int16":          {"int32", "int32"},
+	"int32":          {"int32", "int32"},
+	"int64":          {"int64", "int64"},
+	"uint":           {"uint64", "uint64"},
+	"uint8":          {"uint32", "uint32"},
+	"uint16":         {"uint32", "uint32"},
+	"uint32":         {"uint32", "uint32"},
+	"uint64":         {"uint64", "uint64"},
+	"float32":        {"float", "float32"},
+	"float64":        {"double", "float64"},
+	"datatypes.JSON": {"string", "string"}, // JSON 文本
+}
+
+// protoArrays PostgreSQL 数组类型对应的 repeated 元素类型
+var protoArrays = map[string][2]string{
+	"pq.StringArray":  {"string", "[]string"},
+	"pq.Int64Array":   {"int64", "[]int64"},
+	"pq.Float64Array": {"double", "[]float64"},
+	"pq.BoolArray":    {"bool", "[]bool"},
+}
+
+// protoFields 模型的 proto 字段，json:"-" 的字段不对外暴露；字段编号按 spec 中的顺序分配
+func protoFields(types typemap.Options, model ModelDefinition) ([]protoField, error) {
+	var fields []protoField
+	for _, field := range model.Fields {
+		if getJSONTag(field.Name, field.JSON) == "-" {
+			continue
+		}
+
+		pf, err := newProtoField(types, model, field)
+		if err != nil {
+			return nil, fmt.Errorf("模型 %s 字段 %s: %w", model.Name, field.Name, err)
+		}
+		pf.Number = len(fields) + 1
+		fields = append(fields, pf)
+	}
+	return fields, nil
+}
+
+// newProtoField 计算字段的 proto 类型与转换表达式
+func newProtoField(types typemap.Options, model ModelDefinition, field FieldDef) (protoField, error) {
+	pf := protoField{
+		Name:    field.Name,
+		Comment: field.Comment,
+		GoName:  toCamelCase(field.Name),
+		PBName:  protoGoName(field.Name),
+	}
+	m, p := "m."+pf.GoName, "p."+pf.PBName
+
+	goType := getFieldType(types, model, field)
+	pointer := strings.HasPrefix(goType, "*")
+	goType = strings.TrimPrefix(goType, "*")
+
+	base := baseGoType(types, field)
+	modelType := base
+	if enum := specEnum(types, model, field); enum != nil {
+		modelType = "model." + enum.TypeName
+	}
+
+	switch {
+	case base == "time.Time":
+		pf.Type = "google.protobuf.Timestamp"
+		if pointer {
+			pf.ToPB, pf.FromPB = "timestampPtr("+m+")", "timePtr("+p+")"
+		} else {
+			pf.ToPB, pf.FromPB = "timestamppb.New("+m+")", "fromTimestamp("+p+")"
+		}
+	case base == "decimal.Decimal":
+		pf.Type, pf.Optional, pf.Fallible = "string", pointer, true
+		if pointer {
+			pf.ToPB, pf.FromPB = "decimalString("+m+")", "decimalPtr("+p+")"
+		} else {
+			pf.ToPB, pf.FromPB = m+".String()", "decimalFromString("+p+")"
+		}
+	case protoArrays[goType][0] != "":
+		pf.Type, pf.Repeated = protoArrays[goType][0], true
+		pf.ToPB, pf.FromPB = protoArrays[goType][1]+"("+m+")", goType+"("+p+")"
+	case protoScalars[base][0] != "":
+		pbType := protoScalars[base][1]
+		pf.Type, pf.Optional = protoScalars[base][0], pointer && base != "[]byte" && base != "datatypes.JSON"
+		switch {
+		case modelType == pbType:
+			pf.ToPB, pf.FromPB = m, p
+		case !pf.Optional:
+			pf.ToPB, pf.FromPB = pbType+"("+m+")", modelType+"("+p+")"
+		case pbType == "string":
+			pf.ToPB, pf.FromPB = "stringPtr["+pbType+"]("+m+")", "stringPtr["+modelType+"]("+p+")"
+		default:
+			pf.ToPB, pf.FromPB = "numberPtr["+pbType+"]("+m+")", "numberPtr["+modelType+"]("+p+")"
+		}
+	default:
+		return pf, fmt.Errorf("不支持的 Go 类型 %s", goType)
+	}
+	return pf, nil
+}
+
+// protoGoName protoc-gen-go 为字段生成的 Go 名称（与 protogen.GoCamelCase 一致），如 category_id → CategoryId
+func protoGoName(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && isASCIILower(name[i+1]); i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+// protoPackage proto 包名与 Go 包路径，如 blogapi.v1 与 <module>/api/gen/blogapi/v1
+func (g *Generator) protoPackage() (string, string) {
+	name := strings.ToLower(identifier(g.spec.Name))
+	if name == "" {
+		name = "api"
+	}
+	return name + ".v1", g.spec.Project.Module + "/api/gen/" + name + "/v1"
+}
+
+// grpcMethodAuth gRPC 方法的认证要求：需要认证的方法映射到所需权限（只需登录时为空）
+//
+// 与 Gin 路由一致，按 spec 端点的 auth 与 permission 推导。
+func grpcMethodAuth(info routeInfo, service string) map[string]string {
+	methods := make(map[string]string)
+	add := func(method string, auth bool, perm string) {
+		if auth || perm != "" {
+			methods[service+"/"+method] = perm
+		}
+	}
+	add("Create"+info.ModelName, info.CreateAuth, info.CreatePerm)
+	add("Get"+info.ModelName, info.GetAuth, info.GetPerm)
+	add("Update"+info.ModelName, info.UpdateAuth, info.UpdatePerm)
+	add("Delete"+info.ModelName, info.DeleteAuth, info.DeletePerm)
+	add("List"+pluralize(info.ModelName), info.ListAuth, info.ListPerm)
+	return methods
+}
+
+// generateGRPC generates .proto files and gRPC servers delegating to the generated services
+func (g *Generator) generateGRPC() error {
+	fmt.Println("\n📦 生成 gRPC 服务...")
+
+	protoPkg, goPkg := g.protoPackage()
+	protoDir := filepath.Join(strings.Split(protoPkg, ".")...)
+
+	goAlias := strings.ReplaceAll(protoPkg, ".", "")
+
+	var protoFiles []string
+	decimal := false
+	methodAuth := make(map[string]string)
+	for _, model := range g.spec.Models {
+		fields, err := protoFields(g.spec.Project.Types, model)
+		if err != nil {
+			return err
+		}
+
+		protoFile := filepath.ToSlash(filepath.Join(protoDir, strings.ToLower(model.Name)+".proto"))
+		protoFiles = append(protoFiles, protoFile)
+
+		timestamp, fallible := false, false
+		for _, f := range fields {
+			timestamp = timestamp || f.Type == "google.protobuf.Timestamp"
+			fallible = fallible || f.Fallible
+		}
+		decimal = decimal || fallible
+
+		data := map[string]interface{}{
+			"Spec":         g.spec,
+			"Model":        model,
+			"Fields":       fields,
+			"PK":           primaryKeyName(model),
+			"Message":      snakeCase(model.Name),
+			"ProtoPackage": protoPkg,
+			"GoPackage":    goPkg,
+			"GoAlias":      goAlias,
+			"Paging":       listPaging(g.spec.GetEndpointsByModel(model.Name)),
+			"Imports":      grpcImports(fields),
+			"Timestamp":    timestamp,
+			"Fallible":     fallible,
+		}
+		if err := g.generateFile("proto.tmpl", filepath.Join(g.outputDir, "api/proto", protoFile), data); err != nil {
+			return err
+		}
+		if err := g.generateFile("grpc_server.go.tmpl", filepath.Join(g.outputDir, "internal/grpcserver", strings.ToLower(model.Name)+".go"), data); err != nil {
+			return err
+		}
+
+		for method, perm := range grpcMethodAuth(newRouteInfo(g.spec, model), "/"+protoPkg+"."+model.Name+"Service") {
+			methodAuth[method] = perm
+		}
+		fmt.Printf("  ✓ %sService\n", model.Name)
+	}
+
+	methods := make([]string, 0, len(methodAuth))
+	for method := range methodAuth {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	if err := g.generateFile("grpc_register.go.tmpl", filepath.Join(g.outputDir, "internal/grpcserver", "server.go"), map[string]interface{}{
+		"Spec":       g.spec,
+		"GoPackage":  goPkg,
+		"Methods":    methods,
+		"MethodAuth": methodAuth,
+		"Decimal":    decimal,
+	}); err != nil {
+		return err
+	}
+	if err := g.generateFile("proto_generate.go.tmpl", filepath.Join(g.outputDir, "api/proto", "generate.go"), map[string]interface{}{
+		"Spec":       g.spec,
+		"ProtoFiles": protoFiles,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("  ✓ api/proto（执行 go generate ./api/proto 生成 Go 代码）\n")
+	return nil
+}
+
+// primaryKeyName 主键在 model 结构体中的字段名，未声明主键时为 id
+func primaryKeyName(model ModelDefinition) string {
+	return toCamelCase(primaryKeyColumn(model))
+}
+
+// grpcImports gRPC 服务实现中转换字段需要导入的包
+func grpcImports(fields []protoField) []string {
+	imports := map[string]bool{}
+	for _, f := range fields {
+		switch {
+		case strings.HasPrefix(f.ToPB, "timestamppb."):
+			imports["google.golang.org/protobuf/types/known/timestamppb"] = true
+		case strings.HasPrefix(f.FromPB, "pq."):
+			imports["github.com/lib/pq"] = true
+		case strings.HasPrefix(f.FromPB, "datatypes."):
+			imports["gorm.io/datatypes"] = true
+		}
+	}
+
+	var list []string
+	for path := range imports {
+		list = append(list, path)
+	}
+	sort.Strings(list)
+	return list
+}
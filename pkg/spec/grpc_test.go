@@ -0,0 +1,60 @@
+package spec
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestProtoFields 验证模型字段到 proto 字段的映射：编号按 spec 顺序分配，可空字段为 optional，
+// 枚举与时间字段生成对应的转换表达式
+func TestProtoFields(t *testing.T) {
+	s, err := New("").ParseFile(filepath.Join("..", "..", "spec", "example.blog.spec.yaml"))
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+	article, ok := s.GetModelByName("Article")
+	if !ok {
+		t.Fatal("example spec has no Article model")
+	}
+
+	fields, err := protoFields(s.Project.Types, *article)
+	if err != nil {
+		t.Fatalf("protoFields() unexpected error: %v", err)
+	}
+	byName := make(map[string]protoField)
+	for i, f := range fields {
+		if f.Number != i+1 {
+			t.Errorf("field %s number = %d, want %d", f.Name, f.Number, i+1)
+		}
+		byName[f.Name] = f
+	}
+
+	tests := []struct {
+		name     string
+		typ      string
+		optional bool
+		toPB     string
+		fromPB   string
+	}{
+		{"id", "uint64", false, "uint64(m.Id)", "uint(p.Id)"},
+		{"title", "string", false, "m.Title", "p.Title"},
+		{"category_id", "uint64", true, "numberPtr[uint64](m.CategoryId)", "numberPtr[uint](p.CategoryId)"},
+		{"status", "int64", true, "numberPtr[int64](m.Status)", "numberPtr[model.ArticleStatus](p.Status)"},
+		{"published_at", "google.protobuf.Timestamp", false, "timestampPtr(m.PublishedAt)", "timePtr(p.PublishedAt)"},
+	}
+	for _, tt := range tests {
+		f, ok := byName[tt.name]
+		if !ok {
+			t.Errorf("field %s missing", tt.name)
+			continue
+		}
+		if f.Type != tt.typ || f.Optional != tt.optional || f.ToPB != tt.toPB || f.FromPB != tt.fromPB {
+			t.Errorf("field %s = {%s optional=%v %s %s}, want {%s optional=%v %s %s}",
+				tt.name, f.Type, f.Optional, f.ToPB, f.FromPB, tt.typ, tt.optional, tt.toPB, tt.fromPB)
+		}
+	}
+
+	if got := protoGoName("cover_image"); got != "CoverImage" {
+		t.Errorf("protoGoName(cover_image) = %s, want CoverImage", got)
+	}
+}
@@ -0,0 +1,460 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// handlerInfo 没有对应 CRUD 或关联子资源路由的端点（如 POST /articles/:id/publish、POST /auth/login），
+// 生成 Controller 方法、Service 桩方法与路由注册
+type handlerInfo struct {
+	APIEndpoint
+	Owner  string            // 所属的 Controller/Service 名，如 Article、Auth
+	Route  string            // 注册的路由，路径参数名已与同一位置的其它路由统一
+	Params []handlerParam    // 路径参数
+	Paging *PaginationConfig // 分页配置（已补全默认值），未配置分页时为 nil
+}
+
+// handlerParam 自定义端点的路径参数
+type handlerParam struct {
+	Name  string // 路由中的参数名（统一后），Controller 按此读取
+	Label string // spec 中声明的参数名，用于错误提示
+	Var   string // Go 变量名
+	Type  string // id 与 *_id 为 uint，其余为 string
+}
+
+// handlerOwner 自定义端点所属的 Controller/Service
+type handlerOwner struct {
+	Name     string
+	Model    bool // 是否为模型的 Controller/Service；不是时一并生成类型与构造函数
+	Handlers []handlerInfo
+}
+
+// ServiceParams Service 桩方法的参数：路径参数、请求体、分页参数
+func (h handlerInfo) ServiceParams() string {
+	params := []string{"ctx context.Context"}
+	for _, p := range h.Params {
+		params = append(params, p.Var+" "+p.Type)
+	}
+	if h.Validate != "" {
+		params = append(params, "req *validator."+h.Validate)
+	}
+	switch {
+	case h.Paging == nil:
+	case h.Paging.Mode == PaginationCursor:
+		params = append(params, "cursor string", "pageSize int")
+	default:
+		params = append(params, "page, pageSize int")
+	}
+	return strings.Join(params, ", ")
+}
+
+// ServiceResults Service 桩方法的返回值：分页时额外返回总数（offset）或下一页游标（cursor）
+func (h handlerInfo) ServiceResults() string {
+	switch {
+	case h.Paging == nil:
+		return "(interface{}, error)"
+	case h.Paging.Mode == PaginationCursor:
+		return "(interface{}, string, error)"
+	default:
+		return "(interface{}, int64, error)"
+	}
+}
+
+// StubReturn Service 桩方法尚未实现时的返回语句
+func (h handlerInfo) StubReturn() string {
+	switch {
+	case h.Paging == nil:
+		return "return nil, ErrNotImplemented"
+	case h.Paging.Mode == PaginationCursor:
+		return `return nil, "", ErrNotImplemented`
+	default:
+		return "return nil, 0, ErrNotImplemented"
+	}
+}
+
+// CallArgs Controller 调用 Service 桩方法的参数
+func (h handlerInfo) CallArgs() string {
+	args := []string{"ctx"}
+	for _, p := range h.Params {
+		if p.Type == "uint" {
+			args = append(args, "uint("+p.Var+")")
+		} else {
+			args = append(args, p.Var)
+		}
+	}
+	if h.Validate != "" {
+		args = append(args, "&req")
+	}
+	switch {
+	case h.Paging == nil:
+	case h.Paging.Mode == PaginationCursor:
+		args = append(args, `ctx.Query("cursor")`, "pageSize")
+	default:
+		args = append(args, "page", "pageSize")
+	}
+	return strings.Join(args, ", ")
+}
+
+// reservedHandlerVars Controller 方法中已使用的变量名，路径参数与之重名时加 Param 后缀
+var reservedHandlerVars = map[string]bool{
+	"ctx": true, "req": true, "err": true, "data": true, "total": true, "next": true,
+	"page": true, "pageSize": true, "cursor": true,
+}
+
+// crudHandlers 模型 Controller 已生成的方法，自定义端点不能与之重名
+var crudHandlers = []string{"Create", "GetByID", "Update", "Delete", "List", "ListAfter"}
+
+// customHandlers 按所属的 Controller/Service 分组的自定义端点
+//
+// 端点的路由与模型的 CRUD 路由（POST/GET /articles、GET/PUT/DELETE /articles/:id）或
+// 关联子资源路由（GET /articles/:id/comments）相同时由这些路由处理，其余端点为自定义端点。
+// 所属的 Controller 依次取：处理器名匹配的模型、路径第一段对应的模型、路径第一段（如 /auth/login → Auth）。
+func customHandlers(s *Spec) ([]handlerOwner, error) {
+	// 生成的路由先登记，自定义路由的参数名与之统一，避免 gin 在同一位置注册不同名的参数
+	params := routeParams{}
+	methods := map[string]map[string]bool{}
+	for _, model := range s.Models {
+		base := "/" + pluralize(toLowerCamelCase(model.Name))
+		params.normalize(base + "/:id")
+		methods[model.Name] = map[string]bool{}
+		for _, name := range crudHandlers {
+			methods[model.Name][name] = true
+		}
+		for _, rel := range modelRelations(s, model) {
+			if rel.Path != "" {
+				params.normalize(base + rel.Path)
+				methods[model.Name][rel.Handler] = true
+			}
+		}
+	}
+
+	var owners []handlerOwner
+	index := map[string]int{}
+	routes := map[string]string{}
+	for _, ep := range s.APIs {
+		if generatedRoute(s, ep) {
+			continue
+		}
+
+		h := handlerInfo{APIEndpoint: ep, Route: params.normalize(ep.Path)}
+		owner, isModel, err := endpointOwner(s, ep)
+		if err != nil {
+			return nil, fmt.Errorf("端点 %s %s: %w", ep.Method, ep.Path, err)
+		}
+		h.Owner = owner
+
+		key := ep.Method + " " + h.Route
+		if other, ok := routes[key]; ok {
+			return nil, fmt.Errorf("端点 %s %s 与 %s 的路由重复", ep.Method, ep.Path, other)
+		}
+		routes[key] = ep.Handler
+
+		if methods[owner] == nil {
+			methods[owner] = map[string]bool{}
+		}
+		if methods[owner][ep.Handler] {
+			return nil, fmt.Errorf("端点 %s %s 的处理器 %s 与 %sController 已有的方法重名", ep.Method, ep.Path, ep.Handler, owner)
+		}
+		methods[owner][ep.Handler] = true
+
+		declared := strings.Split(strings.Trim(ep.Path, "/"), "/")
+		for i, segment := range strings.Split(strings.Trim(h.Route, "/"), "/") {
+			if !strings.HasPrefix(segment, ":") {
+				continue
+			}
+			label := strings.TrimPrefix(declared[i], ":")
+			p := handlerParam{Name: segment[1:], Label: label, Var: toLowerCamelCase(identifier(label)), Type: "string"}
+			if label == "id" || strings.HasSuffix(label, "_id") {
+				p.Type = "uint"
+			}
+			if reservedHandlerVars[p.Var] {
+				p.Var += "Param"
+			}
+			h.Params = append(h.Params, p)
+		}
+
+		paging, err := ep.Paging()
+		if err != nil {
+			return nil, fmt.Errorf("端点 %s %s: %w", ep.Method, ep.Path, err)
+		}
+		if paging != nil {
+			cfg := pagingDefaults(*paging)
+			h.Paging = &cfg
+		}
+
+		i, ok := index[owner]
+		if !ok {
+			i = len(owners)
+			index[owner] = i
+			owners = append(owners, handlerOwner{Name: owner, Model: isModel})
+		}
+		owners[i].Handlers = append(owners[i].Handlers, h)
+	}
+	return owners, nil
+}
+
+// generatedRoute 端点是否由模型的 CRUD 或关联子资源路由处理
+func generatedRoute(s *Spec, ep APIEndpoint) bool {
+	for _, model := range s.Models {
+		base := "/" + pluralize(toLowerCamelCase(model.Name))
+		if crudRoute(ep, base) {
+			return true
+		}
+		for _, rel := range modelRelations(s, model) {
+			if rel.Path != "" && strings.EqualFold(ep.Method, "GET") && sameRoute(ep.Path, base+rel.Path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// crudRoute 端点是否为模型路由组 base（如 /articles）下的 CRUD 路由
+func crudRoute(ep APIEndpoint, base string) bool {
+	switch strings.ToUpper(ep.Method) {
+	case "POST":
+		return sameRoute(ep.Path, base)
+	case "GET":
+		return sameRoute(ep.Path, base) || sameRoute(ep.Path, base+"/:id")
+	case "PUT", "DELETE":
+		return sameRoute(ep.Path, base+"/:id")
+	}
+	return false
+}
+
+// endpointOwner 自定义端点所属的 Controller/Service 名，以及是否为模型
+func endpointOwner(s *Spec, ep APIEndpoint) (string, bool, error) {
+	if model := endpointModel(s, ep); model != nil {
+		return model.Name, true, nil
+	}
+
+	segment := strings.Split(strings.Trim(ep.Path, "/"), "/")[0]
+	if segment == "" || strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+		return "", false, fmt.Errorf("无法由路径确定所属的 Controller，请在处理器名中包含模型名")
+	}
+	name := identifier(segment)
+	for _, model := range s.Models {
+		if strings.EqualFold(name, model.Name) || strings.EqualFold(name, pluralize(model.Name)) {
+			return model.Name, true, nil
+		}
+	}
+	return name, false, nil
+}
+
+// routeParams 路由树中每个位置已登记的参数名，键为参数之前的路径
+type routeParams map[string]string
+
+// normalize 把路由中的参数名替换为同一位置已登记的参数名（gin 要求同一位置的参数同名），
+// 该位置第一次出现参数时登记，如已有 /articles/:id 时 /articles/:article_id/comments → /articles/:id/comments
+func (p routeParams) normalize(route string) string {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		prefix := strings.Join(segments[:i], "/")
+		if name, ok := p[prefix]; ok {
+			segments[i] = name
+		} else {
+			p[prefix] = segment
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// mergeStubs 把新生成的桩代码合并进已有的文件：
+// 已有的声明（函数、方法、类型、变量、常量）原样保留，只追加已有文件中缺少的声明；
+// import 取两者的并集并去掉未使用的，结果经 gofmt 格式化。existing 为空时只格式化生成的代码。
+func mergeStubs(existing, generated []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		existing = generated
+	}
+
+	fset := token.NewFileSet()
+	oldFile, err := parser.ParseFile(fset, "existing.go", existing, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析已有文件失败: %w", err)
+	}
+	newFile, err := parser.ParseFile(fset, "generated.go", generated, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析生成的代码失败: %w", err)
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+
+	declared := map[string]bool{}
+	for _, decl := range oldFile.Decls {
+		for _, key := range declKeys(decl) {
+			declared[key] = true
+		}
+	}
+
+	var added []ast.Decl
+	var addedSrc []string
+	for _, decl := range newFile.Decls {
+		keys := declKeys(decl)
+		if len(keys) == 0 || declared[keys[0]] {
+			continue
+		}
+		start := decl.Pos()
+		if doc := declDoc(decl); doc != nil {
+			start = doc.Pos()
+		}
+		added = append(added, decl)
+		addedSrc = append(addedSrc, string(generated[offset(start):offset(decl.End())]))
+	}
+
+	// import 的并集，只保留合并后的代码中用到的包
+	used := map[string]bool{}
+	for _, decls := range [][]ast.Decl{oldFile.Decls, added} {
+		for _, decl := range decls {
+			ast.Inspect(decl, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if ident, ok := sel.X.(*ast.Ident); ok {
+						used[ident.Name] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	imports := map[string]string{}
+	for _, file := range []*ast.File{oldFile, newFile} {
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := ""
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			local := name
+			if local == "" {
+				local = importName(importPath)
+			}
+			if local == "_" || local == "." || used[local] {
+				imports[importPath] = name
+			}
+		}
+	}
+
+	// 替换已有文件的 import 部分，没有 import 时插入到 package 子句之后
+	start, end := offset(oldFile.Name.End()), offset(oldFile.Name.End())
+	hasImports := false
+	for _, decl := range oldFile.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			if !hasImports {
+				start, hasImports = offset(gen.Pos()), true
+			}
+			end = offset(gen.End())
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(existing[:start])
+	if !hasImports && len(imports) > 0 {
+		buf.WriteString("\n\n")
+	}
+	writeImports(&buf, imports)
+	buf.Write(existing[end:])
+	for _, src := range addedSrc {
+		buf.WriteString("\n\n" + src + "\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化合并后的代码失败: %w", err)
+	}
+	return src, nil
+}
+
+// declKeys 顶层声明定义的名称，方法带接收者类型（如 ArticleService.PublishArticle），import 没有名称
+func declKeys(decl ast.Decl) []string {
+	var keys []string
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		name := d.Name.Name
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			recv := d.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				name = ident.Name + "." + name
+			}
+		}
+		keys = append(keys, name)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch sp := spec.(type) {
+			case *ast.TypeSpec:
+				keys = append(keys, sp.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range sp.Names {
+					keys = append(keys, name.Name)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// declDoc 声明的文档注释
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// importName 包的默认名称：路径最后一段，去掉 /v2 这样的版本后缀与 go- 前缀
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// writeImports 输出 import 块，标准库在前
+func writeImports(buf *bytes.Buffer, imports map[string]string) {
+	if len(imports) == 0 {
+		return
+	}
+	var std, others []string
+	for importPath, name := range imports {
+		line := strconv.Quote(importPath)
+		if name != "" {
+			line = name + " " + line
+		}
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			others = append(others, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+
+	buf.WriteString("import (\n")
+	for _, line := range std {
+		buf.WriteString("\t" + line + "\n")
+	}
+	if len(std) > 0 && len(others) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, line := range others {
+		buf.WriteString("\t" + line + "\n")
+	}
+	buf.WriteString(")")
+}
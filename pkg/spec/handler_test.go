@@ -0,0 +1,107 @@
+package spec
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCustomHandlers 验证示例 spec 中不属于 CRUD 与子资源路由的端点归属、路由参数统一与 Service 方法签名
+func TestCustomHandlers(t *testing.T) {
+	s, err := New("").ParseFile(filepath.Join("..", "..", "spec", "example.blog.spec.yaml"))
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+	owners, err := customHandlers(s)
+	if err != nil {
+		t.Fatalf("customHandlers() unexpected error: %v", err)
+	}
+
+	got := map[string]handlerInfo{}
+	for _, owner := range owners {
+		for _, h := range owner.Handlers {
+			got[owner.Name+"."+h.Handler] = h
+		}
+	}
+	want := map[string]struct{ route, params string }{
+		"Article.PublishArticle": {"/articles/:id/publish", "ctx context.Context, id uint"},
+		"Auth.Register":          {"/auth/register", "ctx context.Context, req *validator.RegisterRequest"},
+		"Auth.Login":             {"/auth/login", "ctx context.Context, req *validator.LoginRequest"},
+		"User.GetProfile":        {"/user/profile", "ctx context.Context"},
+		"User.UpdateProfile":     {"/user/profile", "ctx context.Context, req *validator.UpdateProfileRequest"},
+		"Comment.CreateComment":  {"/articles/:id/comments", "ctx context.Context, articleId uint, req *validator.CreateCommentRequest"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("customHandlers() = %d handlers, want %d (ListComments 由子资源路由处理)", len(got), len(want))
+	}
+	for name, w := range want {
+		h, ok := got[name]
+		if !ok {
+			t.Errorf("missing handler %s", name)
+			continue
+		}
+		if h.Route != w.route || h.ServiceParams() != w.params {
+			t.Errorf("%s = {%s, %s}, want {%s, %s}", name, h.Route, h.ServiceParams(), w.route, w.params)
+		}
+	}
+}
+
+// TestMergeStubs 验证重新生成时保留已有的声明与手写的 import，只追加缺少的桩方法并去掉未使用的 import
+func TestMergeStubs(t *testing.T) {
+	existing := `// 手写的说明
+
+package service
+
+import (
+	"context"
+	"strings"
+)
+
+type AuthService struct{ prefix string }
+
+// Login 用户登录
+func (s *AuthService) Login(ctx context.Context) (interface{}, error) {
+	return strings.ToUpper(s.prefix), nil
+}
+`
+	generated := `package service
+
+import (
+	"context"
+	"errors"
+	"example.com/app/internal/validator"
+)
+
+type AuthService struct{}
+
+// Login 用户登录
+func (s *AuthService) Login(ctx context.Context) (interface{}, error) {
+	return nil, errors.New("TODO")
+}
+
+// Logout 退出登录
+func (s *AuthService) Logout(ctx context.Context) (interface{}, error) {
+	return nil, errors.New("TODO")
+}
+`
+	merged, err := mergeStubs([]byte(existing), []byte(generated))
+	if err != nil {
+		t.Fatalf("mergeStubs() unexpected error: %v", err)
+	}
+	src := string(merged)
+
+	for _, want := range []string{
+		"// 手写的说明",
+		"type AuthService struct{ prefix string }",
+		"return strings.ToUpper(s.prefix), nil",
+		"// Logout 退出登录\nfunc (s *AuthService) Logout",
+		`"errors"`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("merged source missing %q:\n%s", want, src)
+		}
+	}
+	if strings.Count(src, "func (s *AuthService) Login") != 1 || strings.Contains(src, "validator") {
+		t.Errorf("merged source should keep a single Login and drop unused imports:\n%s", src)
+	}
+}
@@ -0,0 +1,789 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// ImportOpenAPI 把 OpenAPI 文档转换为 spec：
+// components.schemas 中的对象转换为模型，接口转换为端点（security 决定 auth，x-permission 决定权限），
+// 请求体转换为请求验证定义（schema 约束映射为 validator 规则）
+//
+// 只被请求体引用的结构（如 CreateArticleRequest）视为请求定义，其余对象视为模型；
+// 统一响应结构与非对象结构（如枚举）不生成模型，引用它们的属性直接内联。
+func ImportOpenAPI(doc *openapi.Document, module string) (*Spec, error) {
+	im := &importer{
+		doc:      doc,
+		models:   make(map[string]bool),
+		requests: make(map[string]bool),
+		handlers: make(map[string]bool),
+	}
+	im.spec = &Spec{
+		Spec:    "1.0",
+		Kind:    "API",
+		Name:    identifier(doc.Info.Title),
+		Version: doc.Info.Version,
+		Project: ProjectConfig{Module: module, Description: doc.Info.Description},
+	}
+	if im.spec.Name == "" {
+		im.spec.Name = "API"
+	}
+
+	for _, name := range im.modelNames() {
+		im.models[name] = true
+	}
+	var schemas []*openapi.Schema
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		if im.models[name] {
+			im.spec.Models = append(im.spec.Models, im.model(name, doc.Components.Schemas[name]))
+			schemas = append(schemas, doc.Components.Schemas[name])
+		}
+	}
+	// 关联需要所有模型的字段，在模型转换完成后推导
+	for i := range im.spec.Models {
+		im.spec.Models[i].Relations = im.relations(im.spec.Models[i], schemas[i])
+	}
+
+	// 关联生成的子资源列表路由（GET /articles/{id}/tags）不再导入为端点
+	nested := make(map[string]bool)
+	for _, model := range im.spec.Models {
+		ri := newRouteInfo(im.spec, model)
+		for _, rel := range ri.Nested {
+			nested[openapi.Path("/"+pluralize(ri.ModelVar)+rel.Path)] = true
+		}
+	}
+
+	paths := sortedKeys(doc.Paths)
+	prefix := pathPrefix(paths)
+	for _, path := range paths {
+		for _, method := range openapi.Methods {
+			if method == "GET" && nested[strings.TrimPrefix(path, prefix)] {
+				continue
+			}
+			if op := doc.Paths[path].Operation(method); op != nil {
+				im.spec.APIs = append(im.spec.APIs, im.endpoint(method, strings.TrimPrefix(path, prefix), op))
+			}
+		}
+	}
+
+	if err := New("").validateSpec(im.spec); err != nil {
+		return nil, fmt.Errorf("导入结果验证失败: %w", err)
+	}
+	return im.spec, nil
+}
+
+// importer OpenAPI 文档到 spec 的转换状态
+type importer struct {
+	doc      *openapi.Document
+	spec     *Spec
+	models   map[string]bool // 转换为模型的结构名
+	requests map[string]bool // 已生成的请求定义
+	handlers map[string]bool // 已使用的 handler 名
+}
+
+// schemaRefPrefix 组件结构引用的前缀
+const schemaRefPrefix = "#/components/schemas/"
+
+// refName 引用指向的组件结构名，不是组件结构引用时返回空
+func refName(ref string) string {
+	if !strings.HasPrefix(ref, schemaRefPrefix) {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(ref, schemaRefPrefix), "/")
+	return name
+}
+
+// deref 解析组件结构引用
+func (im *importer) deref(s *openapi.Schema) *openapi.Schema {
+	for depth := 0; s != nil && s.Ref != "" && depth < 10; depth++ {
+		target, ok := im.doc.Components.Schemas[refName(s.Ref)]
+		if !ok {
+			return s
+		}
+		s = target
+	}
+	return s
+}
+
+// modelNames 转换为模型的结构：对象结构中去掉统一响应结构与只被请求体引用的结构
+func (im *importer) modelNames() []string {
+	bodyRefs := make(map[string]bool)
+	otherRefs := make(map[string]bool)
+	for _, item := range im.doc.Paths {
+		for _, method := range openapi.Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			if schema := im.bodySchema(op.RequestBody); schema != nil {
+				bodyRefs[refName(schema.Ref)] = true
+			}
+			for _, resp := range op.Responses {
+				for _, media := range resp.Content {
+					collectRefs(media.Schema, otherRefs)
+				}
+			}
+		}
+	}
+	for _, schema := range im.doc.Components.Schemas {
+		for _, sub := range subSchemas(schema) {
+			// allOf 直接引用的结构是组合的基础，不算作使用
+			if sub != nil && sub.Ref != "" && slices.Contains(schema.AllOf, sub) {
+				continue
+			}
+			collectRefs(sub, otherRefs)
+		}
+	}
+
+	var names []string
+	for name, schema := range im.doc.Components.Schemas {
+		if im.object(schema) == nil || bodyRefs[name] && !otherRefs[name] {
+			continue
+		}
+		if _, ok := schema.Properties["code"]; ok && name == openapi.ResponseSchema {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// subSchemas 结构直接包含的子结构
+func subSchemas(s *openapi.Schema) []*openapi.Schema {
+	subs := append([]*openapi.Schema{s.Items}, s.AllOf...)
+	subs = append(subs, s.OneOf...)
+	subs = append(subs, s.AnyOf...)
+	for _, prop := range s.Properties {
+		subs = append(subs, prop)
+	}
+	return subs
+}
+
+// collectRefs 收集结构及其子结构引用的组件结构名
+func collectRefs(s *openapi.Schema, refs map[string]bool) {
+	if s == nil {
+		return
+	}
+	if name := refName(s.Ref); name != "" {
+		refs[name] = true
+	}
+	for _, sub := range subSchemas(s) {
+		collectRefs(sub, refs)
+	}
+}
+
+// object 对象结构，allOf 合并为一个对象；不是对象时返回 nil
+func (im *importer) object(s *openapi.Schema) *openapi.Schema {
+	s = im.deref(s)
+	if s == nil {
+		return nil
+	}
+	if len(s.AllOf) == 0 {
+		if s.Is("object") || len(s.Properties) > 0 {
+			return s
+		}
+		return nil
+	}
+
+	merged := openapi.Object(nil)
+	merged.Description = s.Description
+	for _, part := range append(s.AllOf, &openapi.Schema{Properties: s.Properties, Required: s.Required}) {
+		obj := im.object(part)
+		if obj == nil {
+			continue
+		}
+		for _, name := range obj.PropertyNames() {
+			merged.AddProperty(name, obj.Properties[name])
+		}
+		merged.Required = append(merged.Required, obj.Required...)
+		if merged.Description == "" {
+			merged.Description = obj.Description
+		}
+	}
+	if len(merged.Properties) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// property 属性的实际结构：解析引用，oneOf/anyOf 中的 [X, null] 视为可空的 X，
+// 引用模型的属性（关联）返回 nil
+func (im *importer) property(p *openapi.Schema) *openapi.Schema {
+	for _, alts := range [][]*openapi.Schema{p.OneOf, p.AnyOf} {
+		if len(alts) != 2 {
+			continue
+		}
+		for i, alt := range alts {
+			if alt.Is("null") && len(alt.Type) == 1 {
+				prop := im.property(alts[1-i])
+				if prop == nil {
+					return nil
+				}
+				nullable := *prop
+				nullable.Type = append(openapi.Types(nil), prop.Type...)
+				nullable.Nullable()
+				return describe(&nullable, p.Description)
+			}
+		}
+	}
+	if len(p.AllOf) == 1 && p.Properties == nil {
+		return im.property(p.AllOf[0])
+	}
+
+	if im.models[refName(p.Ref)] {
+		return nil
+	}
+	if p.Is("array") && p.Items != nil && im.models[refName(p.Items.Ref)] {
+		return nil
+	}
+	return describe(im.deref(p), p.Description)
+}
+
+// describe 引用处的描述优先于被引用结构的描述
+func describe(s *openapi.Schema, description string) *openapi.Schema {
+	if description == "" || description == s.Description {
+		return s
+	}
+	described := *s
+	described.Description = description
+	return &described
+}
+
+// model 把对象结构转换为模型；没有 id 属性时补充自增主键
+func (im *importer) model(name string, schema *openapi.Schema) ModelDefinition {
+	obj := im.object(schema)
+	model := ModelDefinition{
+		Name:    identifier(name),
+		Comment: obj.Description,
+	}
+	model.Table = pluralize(snakeCase(model.Name))
+
+	hasPrimaryKey := false
+	for _, prop := range obj.PropertyNames() {
+		p := im.property(obj.Properties[prop])
+		if p == nil {
+			continue
+		}
+
+		field := FieldDef{Name: snakeCase(prop)}
+		if getJSONTag(field.Name, "") != prop {
+			field.JSON = prop
+		}
+		field.Type, field.Size = fieldType(p)
+		field.Comment, field.Enum = enumDefs(p)
+		if p.Default != nil {
+			field.Default = fmt.Sprint(p.Default)
+		}
+		field.NotNull = !p.Is("null") && (slices.Contains(obj.Required, prop) || p.Default != nil)
+
+		switch {
+		case field.Name == "id":
+			// 生成的代码按 uint 主键处理，整数主键统一为 uint 自增
+			field.PrimaryKey = true
+			if p.Is("integer") {
+				field.Type, field.AutoIncrement = "uint", true
+			} else if field.Type == "text" {
+				field.Type = "string"
+			}
+			hasPrimaryKey = true
+		case field.Name == "created_at" && field.Type == "timestamp":
+			field.AutoCreateTime = true
+		case field.Name == "updated_at" && field.Type == "timestamp":
+			field.AutoUpdateTime = true
+		}
+
+		if field.PrimaryKey {
+			model.Fields = append([]FieldDef{field}, model.Fields...)
+		} else {
+			model.Fields = append(model.Fields, field)
+		}
+	}
+
+	if !hasPrimaryKey {
+		id := FieldDef{Name: "id", Type: "uint", PrimaryKey: true, AutoIncrement: true, Comment: "主键"}
+		model.Fields = append([]FieldDef{id}, model.Fields...)
+	}
+	return model
+}
+
+// relations 把引用其它模型的属性推导为关联，不能通过校验的属性忽略
+//
+//   - 单个引用：本模型有 <属性>_id 列时为 belongsTo，关联模型有 <本模型>_id 列时为 hasOne
+//   - 引用数组：关联模型有 <本模型>_id 列，或有指向本模型的 <属性>_id 列时为 hasMany，
+//     否则为 many2many，中间表按模型名排序命名（Article 与 Tag 均为 article_tags），两端一致
+func (im *importer) relations(model ModelDefinition, schema *openapi.Schema) []RelationDef {
+	obj := im.object(schema)
+	var result []RelationDef
+	for _, prop := range obj.PropertyNames() {
+		p := obj.Properties[prop]
+		rel := RelationDef{Name: toCamelCase(snakeCase(prop)), Comment: p.Description}
+		switch {
+		case im.models[refName(p.Ref)]:
+			rel.Model = identifier(refName(p.Ref))
+			rel.Type = RelationBelongsTo
+			if !hasColumn(model, snakeCase(prop)+"_id") {
+				rel.Type = RelationHasOne
+			}
+		case p.Is("array") && p.Items != nil && im.models[refName(p.Items.Ref)]:
+			rel.Model = identifier(refName(p.Items.Ref))
+			rel.Type = RelationHasMany
+			switch fk := im.backReference(model, refName(p.Items.Ref)); fk {
+			case "":
+				names := []string{snakeCase(model.Name), snakeCase(rel.Model)}
+				sort.Strings(names)
+				rel.Type, rel.JoinTable = RelationMany2Many, names[0]+"_"+pluralize(names[1])
+			case snakeCase(model.Name) + "_id":
+			default:
+				rel.ForeignKey = fk
+			}
+		default:
+			continue
+		}
+
+		if rel.Comment == "关联的 "+rel.Model+"，仅在 preload="+rel.Name+" 时返回" {
+			rel.Comment = "" // BuildOpenAPI 生成的描述
+		}
+		model.Relations = append(model.Relations, rel)
+		if validateRelation(im.spec, model, rel) == nil {
+			result = append(result, rel)
+		}
+	}
+	return result
+}
+
+// backReference 关联模型中指向本模型的外键列：<本模型>_id，或引用本模型的属性对应的 <属性>_id，没有时返回空
+func (im *importer) backReference(model ModelDefinition, targetSchema string) string {
+	target, ok := im.spec.GetModelByName(identifier(targetSchema))
+	if !ok {
+		return ""
+	}
+	if column := snakeCase(model.Name) + "_id"; hasColumn(*target, column) {
+		return column
+	}
+	obj := im.object(im.doc.Components.Schemas[targetSchema])
+	for _, prop := range obj.PropertyNames() {
+		if identifier(refName(obj.Properties[prop].Ref)) != model.Name {
+			continue
+		}
+		if column := snakeCase(prop) + "_id"; hasColumn(*target, column) {
+			return column
+		}
+	}
+	return ""
+}
+
+// fieldType 属性对应的 spec 字段类型与长度
+func fieldType(p *openapi.Schema) (string, int) {
+	switch {
+	case p.Is("integer"):
+		if p.Minimum != nil && *p.Minimum >= 0 {
+			return "uint", 0
+		}
+		if p.Format == "int64" {
+			return "bigint", 0
+		}
+		return "int", 0
+	case p.Is("number"):
+		if p.Format == "float" {
+			return "float", 0
+		}
+		return "double", 0
+	case p.Is("boolean"):
+		return "bool", 0
+	case p.Is("string") || len(p.Type) == 0 && len(p.Enum) > 0:
+		switch p.Format {
+		case "date-time":
+			return "timestamp", 0
+		case "date":
+			return "date", 0
+		case "uuid":
+			return "uuid", 0
+		case "decimal":
+			return "decimal", 0
+		case "byte", "binary":
+			return "blob", 0
+		}
+		if p.MaxLength != nil {
+			return "string", *p.MaxLength
+		}
+		if len(p.Enum) > 0 {
+			return "string", 0
+		}
+		return "text", 0
+	}
+	// 数组、对象与任意值存为 JSON
+	return "json", 0
+}
+
+// enumLabel 生成的文档在描述中列出的枚举取值含义，如 "状态；可选值：1=draft, 2=published"
+const enumLabel = "可选值："
+
+// identPattern 可以作为枚举取值名称的标识符
+var identPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// enumDefs 属性的注释与枚举取值；字符串与整数之外的枚举忽略
+func enumDefs(p *openapi.Schema) (string, []EnumDef) {
+	comment, labels, _ := strings.Cut(p.Description, enumLabel)
+	comment = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(comment), "；"))
+	if len(p.Enum) == 0 || !(p.Is("string") || p.Is("integer") || len(p.Type) == 0) {
+		return strings.TrimSpace(p.Description), nil
+	}
+
+	names := make(map[string]string)
+	for _, pair := range strings.Split(labels, ", ") {
+		if value, name, ok := strings.Cut(pair, "="); ok {
+			names[value] = name
+		}
+	}
+
+	var defs []EnumDef
+	for _, v := range p.Enum {
+		if v == nil {
+			continue
+		}
+		value := fmt.Sprint(v)
+		def := EnumDef{Name: value, Value: value}
+		if label := names[value]; label != "" && label != value {
+			if identPattern.MatchString(label) && p.Is("integer") {
+				def.Name = label
+			} else {
+				def.Label = label
+			}
+		}
+		defs = append(defs, def)
+	}
+	return comment, defs
+}
+
+// body 解析 components.requestBodies 引用
+func (im *importer) body(body *openapi.RequestBody) *openapi.RequestBody {
+	if body != nil && body.Ref != "" {
+		return im.doc.Components.RequestBodies[strings.TrimPrefix(body.Ref, "#/components/requestBodies/")]
+	}
+	return body
+}
+
+// bodySchema 请求体中 JSON 内容的结构
+func (im *importer) bodySchema(body *openapi.RequestBody) *openapi.Schema {
+	body = im.body(body)
+	if body == nil {
+		return nil
+	}
+	if media, ok := body.Content["application/json"]; ok {
+		return media.Schema
+	}
+	for contentType, media := range body.Content {
+		if strings.Contains(contentType, "json") {
+			return media.Schema
+		}
+	}
+	return nil
+}
+
+// parameters 接口的参数，解析 components.parameters 引用
+func (im *importer) parameters(op *openapi.Operation) []*openapi.Parameter {
+	var params []*openapi.Parameter
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			p = im.doc.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+		}
+		if p != nil {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// pathParam OpenAPI 路径参数 {id}
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// versionPrefix 所有路径共有的 /api、/api/v1 前缀，spec 的路由统一注册在 /api/v1 下
+var versionPrefix = regexp.MustCompile(`^/api(/v[0-9]+)?`)
+
+// pathPrefix 所有路径共有的版本前缀，没有时返回空
+func pathPrefix(paths []string) string {
+	prefix := ""
+	for i, path := range paths {
+		p := versionPrefix.FindString(path)
+		if p == "" || len(path) > len(p) && path[len(p)] != '/' || i > 0 && p != prefix {
+			return ""
+		}
+		prefix = p
+	}
+	return prefix
+}
+
+// endpoint 把接口转换为端点
+func (im *importer) endpoint(method, path string, op *openapi.Operation) APIEndpoint {
+	if path == "" {
+		path = "/"
+	}
+	ep := APIEndpoint{
+		Method:     method,
+		Path:       pathParam.ReplaceAllString(path, ":$1"),
+		Auth:       im.requiresAuth(op),
+		Permission: op.Permission,
+		Comment:    op.Summary,
+	}
+	if ep.Comment == "" {
+		ep.Comment, _, _ = strings.Cut(strings.TrimSpace(op.Description), "\n")
+	}
+
+	ep.Handler = identifier(op.OperationID)
+	if ep.Handler == "" {
+		ep.Handler = defaultHandler(method, path)
+	}
+	for n, handler := 2, ep.Handler; im.handlers[ep.Handler]; n++ {
+		ep.Handler = handler + strconv.Itoa(n)
+	}
+	im.handlers[ep.Handler] = true
+
+	ep.Validate = im.request(ep.Handler, op)
+	if method == "GET" {
+		if paging := im.paging(op); paging != nil {
+			ep.Pagination = paging
+		}
+	}
+	return ep
+}
+
+// requiresAuth 接口是否需要认证：接口未声明 security 时使用全局配置，包含空要求 {} 表示可匿名访问
+func (im *importer) requiresAuth(op *openapi.Operation) bool {
+	security := op.Security
+	if security == nil {
+		security = im.doc.Security
+	}
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+	return len(security) > 0
+}
+
+// handlerVerbs 没有 operationId 时 handler 名的动词
+var handlerVerbs = map[string]string{
+	"POST": "Create", "PUT": "Update", "PATCH": "Patch", "DELETE": "Delete",
+}
+
+// defaultHandler 没有 operationId 时由方法与路径生成 handler 名，如 GET /articles/{id} → GetArticles
+func defaultHandler(method, path string) string {
+	verb := handlerVerbs[method]
+	if verb == "" {
+		verb = "List"
+		if strings.HasSuffix(path, "}") {
+			verb = "Get"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(verb)
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, "{") {
+			b.WriteString(identifier(segment))
+		}
+	}
+	return b.String()
+}
+
+// paging 列表接口的分页配置：有 cursor 参数为游标分页，有 page/page_size 参数为偏移分页
+func (im *importer) paging(op *openapi.Operation) *PaginationConfig {
+	var cfg *PaginationConfig
+	var size *openapi.Schema
+	for _, p := range im.parameters(op) {
+		if p.In != "query" {
+			continue
+		}
+		switch p.Name {
+		case "cursor":
+			cfg = &PaginationConfig{Mode: PaginationCursor}
+		case "page":
+			if cfg == nil {
+				cfg = &PaginationConfig{}
+			}
+		case "page_size", "pageSize", "limit", "size":
+			size = im.deref(p.Schema)
+		}
+	}
+	if cfg == nil && size == nil {
+		return nil
+	}
+	if cfg == nil {
+		cfg = &PaginationConfig{}
+	}
+
+	if size != nil {
+		if n, ok := size.Default.(int); ok && n > 0 {
+			cfg.PageSize = n
+		}
+		if size.Maximum != nil && *size.Maximum > 0 {
+			cfg.MaxPageSize = int(*size.Maximum)
+		}
+		if cfg.MaxPageSize > 0 && cfg.PageSize > cfg.MaxPageSize {
+			cfg.PageSize = cfg.MaxPageSize
+		}
+	}
+	return cfg
+}
+
+// request 把接口的请求体转换为请求定义，返回定义名；请求体不是对象或没有属性时返回空
+//
+// 请求体引用的请求结构沿用结构名；内联结构或直接使用模型时以 handler 名命名（如 CreatePetRequest），
+// 模型中的只读属性不作为请求字段。
+func (im *importer) request(handler string, op *openapi.Operation) string {
+	schema := im.bodySchema(op.RequestBody)
+	obj := im.object(schema)
+	if obj == nil || len(obj.Properties) == 0 {
+		return ""
+	}
+
+	name := handler + "Request"
+	if ref := refName(schema.Ref); ref != "" && !im.models[ref] {
+		name = identifier(ref)
+	}
+	if im.requests[name] {
+		return name
+	}
+	im.requests[name] = true
+
+	req := RequestDef{Name: name, Comment: obj.Description}
+	if body := im.body(op.RequestBody); req.Comment == "" && body != nil {
+		req.Comment = body.Description
+	}
+	for _, prop := range obj.PropertyNames() {
+		p := im.property(obj.Properties[prop])
+		if p == nil || p.ReadOnly {
+			continue
+		}
+		comment, _ := enumDefs(p)
+		req.Fields = append(req.Fields, RequestField{
+			Name:    prop,
+			Rules:   validateRules(p, slices.Contains(obj.Required, prop)),
+			Comment: comment,
+		})
+	}
+	im.spec.Requests = append(im.spec.Requests, req)
+	return name
+}
+
+// validateRules 把 schema 约束映射为 validator 规则，与 ruleSchema 互逆
+//
+// 类型标记（numeric、boolean、array）决定生成的验证器字段类型，见 getReqFieldType。
+func validateRules(p *openapi.Schema, required bool) string {
+	var rules []string
+	bound := func(rule string, n *float64) {
+		if n != nil {
+			rules = append(rules, rule+"="+strconv.FormatFloat(*n, 'f', -1, 64))
+		}
+	}
+	count := func(rule string, n *int) {
+		if n != nil {
+			rules = append(rules, rule+"="+strconv.Itoa(*n))
+		}
+	}
+
+	switch {
+	case p.Is("array"):
+		rules = append(rules, "array")
+		count("min", p.MinItems)
+		count("max", p.MaxItems)
+	case p.Is("integer") || p.Is("number"):
+		rules = append(rules, "numeric")
+		bound("min", p.Minimum)
+		bound("max", p.Maximum)
+		bound("gt", p.ExclusiveMinimum)
+		bound("lt", p.ExclusiveMaximum)
+	case p.Is("boolean"):
+		rules = append(rules, "boolean")
+	default:
+		count("min", p.MinLength)
+		count("max", p.MaxLength)
+		for rule, format := range ruleFormats {
+			if p.Format == format && rule != "uri" {
+				rules = append(rules, rule)
+			}
+		}
+		for rule, pattern := range rulePatterns {
+			if p.Pattern == pattern {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	var values []string
+	for _, v := range p.Enum {
+		value := fmt.Sprint(v)
+		if v == nil || value == "" || strings.ContainsAny(value, " ,|") {
+			values = nil
+			break
+		}
+		values = append(values, value)
+	}
+	if len(values) > 0 {
+		rules = append(rules, "oneof="+strings.Join(values, " "))
+	}
+
+	switch {
+	case required:
+		rules = append([]string{"required"}, rules...)
+	case len(rules) > 0:
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return strings.Join(rules, ",")
+}
+
+// identifier 把 operationId、结构名或标题转换为导出的 Go 标识符，如 listPets → ListPets、Blog API → BlogAPI
+func identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) && r < unicode.MaxASCII || unicode.IsDigit(r) && b.Len() > 0:
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+// snakeCase 把属性名或模型名转换为蛇形命名，如 createdAt → created_at、UserID → user_id
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			r = '_'
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && next {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// sortedKeys map 的键按名称排序，保证重复导入的结果一致
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package spec
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// TestImportOpenAPIRoundTrip 验证 spec 导出的 OpenAPI 文档可以导入回等价的 spec：
+// 模型与关联、端点的认证与权限、请求验证规则保持一致，导入结果通过 spec validate
+func TestImportOpenAPIRoundTrip(t *testing.T) {
+	original, err := New("").ParseFile(filepath.Join("..", "..", "spec", "example.blog.spec.yaml"))
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+	built, err := BuildOpenAPI(original)
+	if err != nil {
+		t.Fatalf("BuildOpenAPI() unexpected error: %v", err)
+	}
+
+	docPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := openapi.WriteFile(docPath, built); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+	doc, err := openapi.ReadFile(docPath)
+	if err != nil {
+		t.Fatalf("ReadFile() unexpected error: %v", err)
+	}
+
+	imported, err := ImportOpenAPI(doc, original.Project.Module)
+	if err != nil {
+		t.Fatalf("ImportOpenAPI() unexpected error: %v", err)
+	}
+
+	specPath := filepath.Join(t.TempDir(), "blog.spec.yaml")
+	if err := WriteFile(specPath, imported); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+	if _, err := New("").ParseFile(specPath); err != nil {
+		t.Fatalf("imported spec does not validate: %v", err)
+	}
+
+	if len(imported.Models) != len(original.Models) || len(imported.APIs) != len(original.APIs) {
+		t.Errorf("imported %d models, %d endpoints, want %d, %d",
+			len(imported.Models), len(imported.APIs), len(original.Models), len(original.APIs))
+	}
+
+	for _, ep := range imported.APIs {
+		if ep.Method == "POST" && ep.Path == "/articles" {
+			if ep.Handler != "CreateArticle" || !ep.Auth || ep.Permission != "article.create" || ep.Validate != "CreateArticleRequest" {
+				t.Errorf("POST /articles = %+v", ep)
+			}
+		}
+	}
+
+	relations := make(map[string]RelationDef)
+	for _, model := range imported.Models {
+		for _, rel := range model.Relations {
+			relations[model.Name+"."+rel.Name] = rel
+		}
+	}
+	for key, want := range map[string]RelationDef{
+		"Article.Author": {Name: "Author", Type: RelationBelongsTo, Model: "User"},
+		"Article.Tags":   {Name: "Tags", Type: RelationMany2Many, Model: "Tag", JoinTable: "article_tags"},
+		"Tag.Articles":   {Name: "Articles", Type: RelationMany2Many, Model: "Article", JoinTable: "article_tags"},
+		"User.Articles":  {Name: "Articles", Type: RelationHasMany, Model: "Article", ForeignKey: "author_id"},
+	} {
+		got := relations[key]
+		got.Comment = ""
+		if got != want {
+			t.Errorf("relation %s = %+v, want %+v", key, got, want)
+		}
+	}
+
+	rules := make(map[string]string)
+	for _, req := range imported.Requests {
+		for _, f := range req.Fields {
+			rules[req.Name+"."+f.Name] = f.Rules
+		}
+	}
+	tests := map[string]string{
+		"CreateArticleRequest.title":       "required,min=5,max=200",
+		"CreateArticleRequest.category_id": "required,numeric",
+		"UpdateArticleRequest.status":      "omitempty,oneof=1 2 3",
+		"RegisterRequest.username":         "required,min=3,max=20,alphanum",
+		"RegisterRequest.email":            "required,email",
+	}
+	for field, want := range tests {
+		if got := rules[field]; got != want {
+			t.Errorf("rules of %s = %q, want %q", field, got, want)
+		}
+	}
+}
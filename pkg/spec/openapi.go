@@ -0,0 +1,488 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Martindeeepdark/go-start/pkg/openapi"
+)
+
+// BuildOpenAPI 根据 spec 构建 OpenAPI 文档：
+// 模型与请求验证器生成到 components.schemas，端点生成到 paths（含认证、权限与分页参数），
+// 模型关联的子资源列表路由（GET /articles/{id}/comments）没有声明为端点时也会加入 paths
+func BuildOpenAPI(s *Spec) (*openapi.Document, error) {
+	doc := openapi.New(s.Name, s.Version, s.Project.Description)
+	doc.Servers = []openapi.Server{{URL: "/api/v1"}}
+
+	for _, model := range s.Models {
+		doc.Components.Schemas[model.Name] = modelSchema(s, model)
+		doc.AddTag(model.Name, model.Comment)
+	}
+	for _, req := range s.Requests {
+		doc.Components.Schemas[req.Name] = requestSchema(req)
+	}
+
+	for _, ep := range s.APIs {
+		op, err := endpointOperation(s, doc, ep)
+		if err != nil {
+			return nil, fmt.Errorf("端点 %s %s: %w", ep.Method, ep.Path, err)
+		}
+		if err := doc.AddOperation(ep.Method, ep.Path, op); err != nil {
+			return nil, fmt.Errorf("端点 %s %s: %w", ep.Method, ep.Path, err)
+		}
+	}
+
+	for _, model := range s.Models {
+		ri := newRouteInfo(s, model)
+		for _, rel := range ri.Nested {
+			route := "/" + pluralize(ri.ModelVar) + rel.Path
+			if declaredRoute(s, "GET", route) {
+				continue
+			}
+			if err := doc.AddOperation("GET", route, nestedOperation(doc, ri, rel)); err != nil {
+				return nil, fmt.Errorf("关联 %s.%s: %w", model.Name, rel.FieldName, err)
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// modelSchema 模型的结构，JSON 字段名与生成的模型一致，json:"-" 的字段不输出
+//
+// 非空且没有默认值、不由数据库生成的字段标记为必填；自增主键与自动时间戳为只读。
+func modelSchema(s *Spec, model ModelDefinition) *openapi.Schema {
+	types := s.Project.Types
+	schema := openapi.Object(map[string]*openapi.Schema{})
+	schema.Description = model.Comment
+
+	for _, field := range model.Fields {
+		name := getJSONTag(field.Name, field.JSON)
+		if name == "-" {
+			continue
+		}
+
+		var prop *openapi.Schema
+		if enum := specEnum(types, model, field); enum != nil {
+			prop = openapi.EnumSchema(*enum)
+			if !field.NotNull && !field.PrimaryKey {
+				prop.Nullable()
+			}
+		} else {
+			prop = openapi.SchemaFor(getGoType2(types, field))
+		}
+		prop.Describe(field.Comment)
+
+		if field.Size > 0 && prop.Is("string") {
+			prop.MaxLength = openapi.Int(field.Size)
+		}
+		if field.Default != "" {
+			prop.Default = typedValue(prop, field.Default)
+		}
+
+		generated := field.PrimaryKey && field.AutoIncrement || field.AutoCreateTime || field.AutoUpdateTime
+		prop.ReadOnly = generated
+		if field.NotNull && field.Default == "" && !generated {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.AddProperty(name, prop)
+	}
+
+	for _, rel := range modelRelations(s, model) {
+		prop := openapi.Ref(rel.Model)
+		if rel.Type == RelationHasMany || rel.Type == RelationMany2Many {
+			prop = openapi.ArrayOf(prop)
+		}
+		prop.Description = "关联的 " + rel.Model + "，仅在 preload=" + rel.FieldName + " 时返回"
+		schema.AddProperty(rel.JSONName, prop)
+	}
+
+	return schema
+}
+
+// requestSchema 请求验证器的结构，字段约束由 validator 规则推导
+func requestSchema(req RequestDef) *openapi.Schema {
+	schema := openapi.Object(map[string]*openapi.Schema{})
+	schema.Description = req.Comment
+
+	for _, field := range req.Fields {
+		prop, required := ruleSchema(field.Rules)
+		prop.Describe(field.Comment)
+		if required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+		schema.AddProperty(field.Name, prop)
+	}
+
+	return schema
+}
+
+// knownRules 不带参数的 validator 规则，用于判断 in=1,2,3 被逗号拆开后的取值在哪里结束
+var knownRules = map[string]bool{
+	"required": true, "omitempty": true, "email": true, "url": true, "uri": true, "uuid": true,
+	"alpha": true, "alphanum": true, "numeric": true, "number": true, "array": true, "bool": true, "boolean": true,
+}
+
+// rulePatterns 规则对应的正则约束
+var rulePatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+}
+
+// ruleFormats 规则对应的 format
+var ruleFormats = map[string]string{
+	"email": "email",
+	"url":   "uri",
+	"uri":   "uri",
+	"uuid":  "uuid",
+}
+
+// ruleSchema 把 validator 规则（如 required,min=5,max=200）映射为 JSON Schema 约束
+//
+// 字段类型与生成的验证器一致（见 getReqFieldType）；min/max/len 对字符串约束长度，
+// 对数字约束取值，对数组约束元素个数；oneof 与 in 映射为 enum。
+func ruleSchema(rules string) (*openapi.Schema, bool) {
+	var schema *openapi.Schema
+	switch getReqFieldType(rules) {
+	case "[]string":
+		schema = openapi.ArrayOf(openapi.String())
+	case "int":
+		schema = openapi.Integer()
+	case "bool":
+		schema = openapi.Boolean()
+	default:
+		schema = openapi.String()
+	}
+
+	required := false
+	tokens := strings.Split(rules, ",")
+	for i := 0; i < len(tokens); i++ {
+		name, value, _ := strings.Cut(strings.TrimSpace(tokens[i]), "=")
+		switch name {
+		case "required":
+			required = true
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			n, err := strconv.ParseFloat(value, 64)
+			if err == nil {
+				applyBound(schema, name, n)
+			}
+		case "oneof":
+			for _, v := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, typedValue(schema, v))
+			}
+		case "in":
+			// in=1,2,3 的取值被逗号拆成了多个规则，向后收集到下一个规则为止
+			values := []string{value}
+			for i+1 < len(tokens) && !strings.Contains(tokens[i+1], "=") && !knownRules[strings.TrimSpace(tokens[i+1])] {
+				i++
+				values = append(values, strings.TrimSpace(tokens[i]))
+			}
+			for _, v := range values {
+				schema.Enum = append(schema.Enum, typedValue(schema, v))
+			}
+		default:
+			if format, ok := ruleFormats[name]; ok {
+				schema.Format = format
+			}
+			if pattern, ok := rulePatterns[name]; ok {
+				schema.Pattern = pattern
+			}
+		}
+	}
+
+	return schema, required
+}
+
+// applyBound 按字段类型应用 min/max/len/gt/gte/lt/lte 约束
+func applyBound(schema *openapi.Schema, rule string, n float64) {
+	if schema.Is("integer") || schema.Is("number") {
+		switch rule {
+		case "min", "gte":
+			schema.Minimum = openapi.Float(n)
+		case "max", "lte":
+			schema.Maximum = openapi.Float(n)
+		case "len":
+			schema.Minimum, schema.Maximum = openapi.Float(n), openapi.Float(n)
+		case "gt":
+			schema.ExclusiveMinimum = openapi.Float(n)
+		case "lt":
+			schema.ExclusiveMaximum = openapi.Float(n)
+		}
+		return
+	}
+
+	// 字符串与数组按长度约束，gt/lt 换算为闭区间
+	size := int(n)
+	var lower, upper *int
+	switch rule {
+	case "min", "gte":
+		lower = openapi.Int(size)
+	case "max", "lte":
+		upper = openapi.Int(size)
+	case "len":
+		lower, upper = openapi.Int(size), openapi.Int(size)
+	case "gt":
+		lower = openapi.Int(size + 1)
+	case "lt":
+		upper = openapi.Int(size - 1)
+	}
+
+	if schema.Is("array") {
+		if lower != nil {
+			schema.MinItems = lower
+		}
+		if upper != nil {
+			schema.MaxItems = upper
+		}
+		return
+	}
+	if lower != nil {
+		schema.MinLength = lower
+	}
+	if upper != nil {
+		schema.MaxLength = upper
+	}
+}
+
+// typedValue 按结构类型转换取值（默认值、枚举值），整数与布尔解析失败时保留字符串
+func typedValue(schema *openapi.Schema, value string) interface{} {
+	switch {
+	case schema.Is("integer"):
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case schema.Is("number"):
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case schema.Is("boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// endpointModel 端点所属的模型（按处理器名匹配，取名称最长的模型），没有时返回 nil
+func endpointModel(s *Spec, ep APIEndpoint) *ModelDefinition {
+	var found *ModelDefinition
+	for i := range s.Models {
+		model := &s.Models[i]
+		if containsModelName(ep.Handler, model.Name) && (found == nil || len(model.Name) > len(found.Name)) {
+			found = model
+		}
+	}
+	return found
+}
+
+// hasRequest spec 中是否定义了指定名称的请求验证器
+func hasRequest(s *Spec, name string) bool {
+	for _, req := range s.Requests {
+		if req.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointOperation 端点对应的接口描述
+func endpointOperation(s *Spec, doc *openapi.Document, ep APIEndpoint) (*openapi.Operation, error) {
+	paging, err := ep.Paging()
+	if err != nil {
+		return nil, err
+	}
+
+	op := &openapi.Operation{Summary: ep.Comment, OperationID: ep.Handler}
+	method := strings.ToUpper(ep.Method)
+	path := openapi.Path(ep.Path)
+	model := endpointModel(s, ep)
+	if model != nil {
+		op.Tags = []string{model.Name}
+	} else if segment := strings.Split(strings.Trim(path, "/"), "/")[0]; segment != "" {
+		op.Tags = []string{segment}
+	}
+
+	// 路径参数：id 与 *_id 按整数处理（生成的 Controller 用 ParseUint 解析）
+	for _, name := range strings.Split(path, "/") {
+		if !strings.HasPrefix(name, "{") {
+			continue
+		}
+		name = strings.Trim(name, "{}")
+		schema := openapi.String()
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			schema = &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64", Minimum: openapi.Float(1)}
+		}
+		op.Parameters = append(op.Parameters, &openapi.Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+
+	// 请求体：声明了 validate 时使用请求验证器，否则 Create/Update 端点使用模型
+	switch {
+	case ep.Validate != "":
+		body := openapi.Ref(ep.Validate)
+		if !hasRequest(s, ep.Validate) {
+			// requests 中没有定义的验证器无法推导字段，只声明为对象
+			body = openapi.Object(nil)
+		}
+		op.JSONBody(ep.Validate, body)
+		op.Error(400, "参数校验失败")
+	case model != nil && (method == "POST" || method == "PUT" || method == "PATCH") &&
+		(strings.HasPrefix(ep.Handler, "Create") || strings.HasPrefix(ep.Handler, "Update")):
+		op.JSONBody(model.Name, openapi.Ref(model.Name))
+		op.Error(400, "参数错误")
+	}
+
+	// 成功响应
+	var data *openapi.Schema
+	switch {
+	case paging != nil:
+		items := &openapi.Schema{}
+		if model != nil {
+			items = openapi.Ref(model.Name)
+		}
+		data = pagingParams(op, paging, items)
+		if model != nil {
+			preloadParam(op, modelRelations(s, *model))
+		}
+	case model == nil:
+	case method == "GET" && strings.HasPrefix(ep.Handler, "List"):
+		data = openapi.ArrayOf(openapi.Ref(model.Name))
+		preloadParam(op, modelRelations(s, *model))
+	case method == "GET" && strings.Contains(path, "{"):
+		data = openapi.Ref(model.Name)
+		preloadParam(op, modelRelations(s, *model))
+		op.Error(404, "记录不存在")
+	case method == "POST" && strings.HasPrefix(ep.Handler, "Create"):
+		data = openapi.Object(map[string]*openapi.Schema{"id": openapi.Integer()}, "id")
+	}
+	op.Success("成功", data)
+
+	// 认证与权限
+	var notes []string
+	if ep.Auth {
+		doc.RequireAuth(op)
+	}
+	if ep.Permission != "" {
+		op.Permission = ep.Permission
+		op.Error(403, "权限不足")
+		notes = append(notes, "需要权限 `"+ep.Permission+"`")
+	}
+	if ep.Cache != nil && ep.Cache.Enabled && ep.Cache.TTL > 0 {
+		notes = append(notes, fmt.Sprintf("响应缓存 %d 秒", ep.Cache.TTL))
+	}
+	op.Description = strings.Join(notes, "；")
+	op.Error(500, "服务器内部错误")
+
+	return op, nil
+}
+
+// pagingParams 添加分页查询参数，返回分页响应的 data 结构
+func pagingParams(op *openapi.Operation, paging *PaginationConfig, items *openapi.Schema) *openapi.Schema {
+	// 与 listPaging 相同的默认值：每页 20 条，最多 100 条
+	maxPageSize := paging.MaxPageSize
+	if maxPageSize <= 0 {
+		maxPageSize = 100
+	}
+	size := paging.PageSize
+	if size <= 0 || size > maxPageSize {
+		size = min(20, maxPageSize)
+	}
+	pageSize := &openapi.Schema{
+		Type:    openapi.Types{"integer"},
+		Minimum: openapi.Float(1),
+		Maximum: openapi.Float(float64(maxPageSize)),
+		Default: size,
+	}
+
+	if paging.Mode == PaginationCursor {
+		op.Query("cursor", "上一页返回的 next_cursor，为空时从第一页开始", openapi.String())
+		op.Query("page_size", "每页数量", pageSize)
+		op.Error(400, "无效的游标")
+		return openapi.Object(map[string]*openapi.Schema{
+			"list":        openapi.ArrayOf(items),
+			"next_cursor": openapi.String(),
+			"has_more":    openapi.Boolean(),
+			"page_size":   openapi.Integer(),
+		}, "list", "next_cursor", "has_more", "page_size")
+	}
+
+	page := &openapi.Schema{Type: openapi.Types{"integer"}, Minimum: openapi.Float(1), Default: 1}
+	if paging.Page > 0 {
+		page.Default = paging.Page
+	}
+	op.Query("page", "页码", page)
+	op.Query("page_size", "每页数量", pageSize)
+	return openapi.Object(map[string]*openapi.Schema{
+		"list":      openapi.ArrayOf(items),
+		"total":     &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64"},
+		"page":      openapi.Integer(),
+		"page_size": openapi.Integer(),
+	}, "list", "total", "page", "page_size")
+}
+
+// preloadParam 添加 preload 查询参数（可重复传入或逗号分隔），没有关联时不添加
+func preloadParam(op *openapi.Operation, relations []relationInfo) {
+	var names []interface{}
+	for _, rel := range relations {
+		names = append(names, rel.FieldName)
+	}
+	if len(names) == 0 {
+		return
+	}
+	op.Query("preload", "预加载的关联，可重复传入", openapi.ArrayOf(&openapi.Schema{Type: openapi.Types{"string"}, Enum: names}))
+	op.Error(400, "未知的预加载关联")
+}
+
+// nestedOperation 关联的子资源列表接口，认证与权限同模型的详情接口
+func nestedOperation(doc *openapi.Document, ri routeInfo, rel relationInfo) *openapi.Operation {
+	op := &openapi.Operation{
+		Summary:     "获取 " + ri.ModelName + " 关联的 " + rel.Model + " 列表",
+		OperationID: "List" + ri.ModelName + rel.FieldName,
+		Tags:        []string{ri.ModelName},
+	}
+	op.Parameters = append(op.Parameters, &openapi.Parameter{
+		Name: "id", In: "path", Required: true,
+		Schema: &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64", Minimum: openapi.Float(1)},
+	})
+	paging := &PaginationConfig{Mode: PaginationOffset, PageSize: rel.PageSize, MaxPageSize: rel.MaxPageSize}
+	op.Success("成功", pagingParams(op, paging, openapi.Ref(rel.Model)))
+	op.Error(400, "无效的ID")
+
+	if rel.Auth {
+		doc.RequireAuth(op)
+	}
+	if rel.Permission != "" {
+		op.Permission = rel.Permission
+		op.Error(403, "权限不足")
+		op.Description = "需要权限 `" + rel.Permission + "`"
+	}
+	op.Error(500, "服务器内部错误")
+	return op
+}
+
+// declaredRoute spec 是否声明了相同的端点，路径参数名不同（:id 与 :article_id）视为同一路由
+func declaredRoute(s *Spec, method, route string) bool {
+	for _, ep := range s.APIs {
+		if strings.EqualFold(ep.Method, method) && sameRoute(ep.Path, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameRoute 两个路由是否相同，路径参数名不同视为相同
+func sameRoute(a, b string) bool {
+	x := strings.Split(strings.Trim(openapi.Path(a), "/"), "/")
+	y := strings.Split(strings.Trim(openapi.Path(b), "/"), "/")
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		param := strings.HasPrefix(x[i], "{") && strings.HasPrefix(y[i], "{")
+		if x[i] != y[i] && !param {
+			return false
+		}
+	}
+	return true
+}
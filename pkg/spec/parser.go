@@ -1,12 +1,15 @@
 package spec
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/Martindeeepdark/go-start/pkg/typemap"
 )
 
 // Spec represents the API specification
@@ -16,46 +19,83 @@ type Spec struct {
 	Name     string            `yaml:"name"`
 	Version  string            `yaml:"version"`
 	Project  ProjectConfig     `yaml:"project"`
-	Models   []ModelDefinition `yaml:"models"`
-	APIs     []APIEndpoint     `yaml:"endpoints"`
-	Requests []RequestDef      `yaml:"requests"`
-	Rules    []BusinessRule    `yaml:"rules"`
+	Models   []ModelDefinition `yaml:"models,omitempty"`
+	APIs     []APIEndpoint     `yaml:"endpoints,omitempty"`
+	Requests []RequestDef      `yaml:"requests,omitempty"`
+	Rules    []BusinessRule    `yaml:"rules,omitempty"`
 }
 
 // ProjectConfig represents project configuration
 type ProjectConfig struct {
-	Module      string `yaml:"module"`
-	Author      string `yaml:"author"`
-	Description string `yaml:"description"`
+	Module      string          `yaml:"module"`
+	Author      string          `yaml:"author,omitempty"`
+	Description string          `yaml:"description,omitempty"`
+	Types       typemap.Options `yaml:"types,omitempty"` // 可空列与 decimal 的类型映射策略
 }
 
 // ModelDefinition represents a data model definition
 type ModelDefinition struct {
-	Name    string     `yaml:"name"`
-	Table   string     `yaml:"table"`
-	Comment string     `yaml:"comment"`
-	Fields  []FieldDef `yaml:"fields"`
-	Indexes []IndexDef `yaml:"indexes"`
+	Name      string        `yaml:"name"`
+	Table     string        `yaml:"table"`
+	Comment   string        `yaml:"comment,omitempty"`
+	Fields    []FieldDef    `yaml:"fields"`
+	Indexes   []IndexDef    `yaml:"indexes,omitempty"`
+	Relations []RelationDef `yaml:"relations,omitempty"` // 与其它模型的关联
 }
 
 // FieldDef represents a field definition
 type FieldDef struct {
-	Name           string `yaml:"name"`
-	Type           string `yaml:"type"`
-	Size           int    `yaml:"size,omitempty"`
-	PrimaryKey     bool   `yaml:"primary,omitempty"`
-	AutoIncrement  bool   `yaml:"autoIncrement,omitempty"`
-	NotNull        bool   `yaml:"notNull,omitempty"`
-	Unique         bool   `yaml:"unique,omitempty"`
-	Index          bool   `yaml:"index,omitempty"`
-	Default        string `yaml:"default,omitempty"`
-	JSON           string `yaml:"json,omitempty"` // 自定义 JSON tag
-	ForeignKey     string `yaml:"foreignKey,omitempty"`
-	OnDelete       string `yaml:"onDelete,omitempty"`
-	OnUpdate       string `yaml:"onUpdate,omitempty"`
-	Comment        string `yaml:"comment,omitempty"`
-	AutoCreateTime bool   `yaml:"autoCreateTime,omitempty"`
-	AutoUpdateTime bool   `yaml:"autoUpdateTime,omitempty"`
+	Name           string    `yaml:"name"`
+	Type           string    `yaml:"type"`
+	Size           int       `yaml:"size,omitempty"`
+	PrimaryKey     bool      `yaml:"primary,omitempty"`
+	AutoIncrement  bool      `yaml:"autoIncrement,omitempty"`
+	NotNull        bool      `yaml:"notNull,omitempty"`
+	Unique         bool      `yaml:"unique,omitempty"`
+	Index          bool      `yaml:"index,omitempty"`
+	Default        string    `yaml:"default,omitempty"`
+	JSON           string    `yaml:"json,omitempty"` // 自定义 JSON tag
+	ForeignKey     string    `yaml:"foreignKey,omitempty"`
+	OnDelete       string    `yaml:"onDelete,omitempty"`
+	OnUpdate       string    `yaml:"onUpdate,omitempty"`
+	Comment        string    `yaml:"comment,omitempty"`
+	AutoCreateTime bool      `yaml:"autoCreateTime,omitempty"`
+	AutoUpdateTime bool      `yaml:"autoUpdateTime,omitempty"`
+	Enum           []EnumDef `yaml:"enum,omitempty"` // 枚举取值，生成具名类型与常量
+}
+
+// EnumDef 枚举取值
+//
+// 完整写法：{name: draft, value: 1, label: 草稿}；
+// 字符串枚举可以简写为取值本身：enum: [draft, published]
+type EnumDef struct {
+	Name  string `yaml:"name"`            // 取值名称，用于生成常量名
+	Value string `yaml:"value,omitempty"` // 取值，默认与 name 相同
+	Label string `yaml:"label,omitempty"` // 可读名称
+}
+
+// UnmarshalYAML 支持标量简写
+func (e *EnumDef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		e.Name, e.Value = node.Value, node.Value
+		return nil
+	}
+
+	type plain EnumDef
+	return node.Decode((*plain)(e))
+}
+
+// MarshalYAML 没有单独取值与可读名称时输出为简写
+func (e EnumDef) MarshalYAML() (interface{}, error) {
+	if e.Label == "" && (e.Value == "" || e.Value == e.Name) {
+		return e.Name, nil
+	}
+
+	if e.Value == e.Name {
+		e.Value = ""
+	}
+	type plain EnumDef
+	return plain(e), nil
 }
 
 // IndexDef represents an index definition
@@ -65,6 +105,27 @@ type IndexDef struct {
 	Unique bool     `yaml:"unique"`
 }
 
+// RelationDef 模型关联
+//
+//	relations:
+//	  - {name: Author, type: belongsTo, model: User, foreignKey: author_id}
+//	  - {name: Comments, type: hasMany, model: Comment, foreignKey: article_id}
+//	  - {name: Tags, type: many2many, model: Tag, joinTable: article_tags}
+//
+// foreignKey 省略时按 GORM 的约定推导：belongsTo 为本模型的 <name>_id 列，
+// hasOne/hasMany 为关联模型的 <本模型>_id 列；references 默认为被引用模型的主键。
+type RelationDef struct {
+	Name           string `yaml:"name"`                     // 结构体字段名，如 Author
+	Type           string `yaml:"type"`                     // belongsTo、hasOne、hasMany、many2many
+	Model          string `yaml:"model"`                    // 关联的模型
+	ForeignKey     string `yaml:"foreignKey,omitempty"`     // 外键列
+	References     string `yaml:"references,omitempty"`     // 外键引用的列
+	JoinTable      string `yaml:"joinTable,omitempty"`      // many2many 的中间表
+	JoinForeignKey string `yaml:"joinForeignKey,omitempty"` // 中间表中指向本模型的列，默认 <本模型>_id
+	JoinReferences string `yaml:"joinReferences,omitempty"` // 中间表中指向关联模型的列，默认 <关联模型>_id
+	Comment        string `yaml:"comment,omitempty"`
+}
+
 // APIEndpoint represents an API endpoint definition
 type APIEndpoint struct {
 	Method     string       `yaml:"method"`
@@ -86,26 +147,86 @@ type CacheConfig struct {
 
 // PaginationConfig represents pagination configuration
 type PaginationConfig struct {
-	Page        int `yaml:"page,omitempty"`
-	PageSize    int `yaml:"pageSize,omitempty"`
-	MaxPageSize int `yaml:"maxPageSize,omitempty"`
+	Mode        string `yaml:"mode,omitempty"` // offset（默认）或 cursor（按主键游标分页，不统计总数）
+	Page        int    `yaml:"page,omitempty"`
+	PageSize    int    `yaml:"pageSize,omitempty"`
+	MaxPageSize int    `yaml:"maxPageSize,omitempty"`
+}
+
+// 分页方式
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+// Paging 解析端点的 pagination 配置，未配置分页时返回 nil
+//
+// 支持两种写法：
+//
+//	pagination: true
+//	pagination: {mode: cursor, pageSize: 50, maxPageSize: 200}
+func (e APIEndpoint) Paging() (*PaginationConfig, error) {
+	var cfg PaginationConfig
+	switch v := e.Pagination.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if !v {
+			return nil, nil
+		}
+	case PaginationConfig:
+		cfg = v
+	case *PaginationConfig:
+		if v == nil {
+			return nil, nil
+		}
+		cfg = *v
+	default:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 pagination 配置: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("无效的 pagination 配置: %w", err)
+		}
+	}
+
+	if cfg.Mode == "" {
+		cfg.Mode = PaginationOffset
+	}
+	if cfg.Mode != PaginationOffset && cfg.Mode != PaginationCursor {
+		return nil, fmt.Errorf("不支持的分页方式 %q (支持: %s, %s)", cfg.Mode, PaginationOffset, PaginationCursor)
+	}
+	if cfg.PageSize < 0 || cfg.MaxPageSize < 0 || cfg.MaxPageSize > 0 && cfg.PageSize > cfg.MaxPageSize {
+		return nil, fmt.Errorf("无效的分页大小: pageSize=%d, maxPageSize=%d", cfg.PageSize, cfg.MaxPageSize)
+	}
+	return &cfg, nil
 }
 
 // RequestDef represents a request validation definition
 type RequestDef struct {
 	Name    string         `yaml:"name"`
-	Comment string         `yaml:"comment"`
+	Comment string         `yaml:"comment,omitempty"`
 	Fields  []RequestField `yaml:"fields"`
 }
 
 // RequestField represents a request validation field
 type RequestField struct {
 	Name    string `yaml:"name"`
-	Rules   string `yaml:"rules"`
-	Comment string `yaml:"comment"`
+	Rules   string `yaml:"rules,omitempty"`
+	Comment string `yaml:"comment,omitempty"`
 }
 
 // BusinessRule represents a business rule definition
+//
+// trigger 为 [before_|after_]<create|update|delete|get>_<模型>，省略前缀时为操作之后；
+// 规则生成为 <Model>Service 的 rule<Name> 桩方法并注册到对应的生命周期钩子，action 写入方法注释：
+//
+//	rules:
+//	  - name: SlugGeneration
+//	    trigger: before_create_article   # ArticleService.Create 写入数据库之前
+//	    action: |
+//	      if article.slug == "" { article.slug = generateSlug(article.title) }
 type BusinessRule struct {
 	Name    string `yaml:"name"`
 	Comment string `yaml:"comment"`
@@ -147,6 +268,24 @@ func (p *Parser) ParseFile(specPath string) (*Spec, error) {
 	return &spec, nil
 }
 
+// WriteFile writes the spec as YAML
+func WriteFile(specPath string, s *Spec) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("序列化规范失败: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("序列化规范失败: %w", err)
+	}
+
+	if err := os.WriteFile(specPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("写入规范文件失败: %w", err)
+	}
+	return nil
+}
+
 // ParseDir parses all spec files in a directory
 func (p *Parser) ParseDir(dir string) ([]*Spec, error) {
 	var specs []*Spec
@@ -193,6 +332,9 @@ func (p *Parser) validateSpec(spec *Spec) error {
 	if spec.Project.Module == "" {
 		return fmt.Errorf("缺少项目模块名")
 	}
+	if err := spec.Project.Types.Validate(); err != nil {
+		return err
+	}
 
 	// Validate models
 	for _, model := range spec.Models {
@@ -201,11 +343,34 @@ func (p *Parser) validateSpec(spec *Spec) error {
 		}
 	}
 
+	for _, model := range spec.Models {
+		for _, rel := range model.Relations {
+			if err := validateRelation(spec, model, rel); err != nil {
+				return fmt.Errorf("模型 %s 的关联 %s 验证失败: %w", model.Name, rel.Name, err)
+			}
+		}
+	}
+
 	// Validate endpoints
 	for _, endpoint := range spec.APIs {
 		if err := p.validateEndpoint(&endpoint); err != nil {
 			return fmt.Errorf("端点 %s %s 验证失败: %w", endpoint.Method, endpoint.Path, err)
 		}
+		if endpoint.Validate != "" && !hasRequest(spec, endpoint.Validate) {
+			return fmt.Errorf("端点 %s %s 验证失败: 请求验证器 %s 未在 requests 中定义", endpoint.Method, endpoint.Path, endpoint.Validate)
+		}
+	}
+
+	// Validate business rules
+	for _, rule := range spec.Rules {
+		if err := validateRule(spec, rule); err != nil {
+			return fmt.Errorf("业务规则 %s 验证失败: %w", rule.Name, err)
+		}
+	}
+
+	// Custom endpoints must map to unique routes and handler names
+	if _, err := customHandlers(spec); err != nil {
+		return err
 	}
 
 	return nil
@@ -234,6 +399,17 @@ func (p *Parser) validateModel(model *ModelDefinition) error {
 		return fmt.Errorf("模型 %s 缺少主键", model.Name)
 	}
 
+	for _, field := range model.Fields {
+		if enum := specEnum(typemap.Options{}, *model, field); enum != nil {
+			if enum.BaseType != "string" && !isIntegerType(enum.BaseType) {
+				return fmt.Errorf("字段 %s: 枚举字段的类型必须是字符串或整数", field.Name)
+			}
+			if err := enum.Validate(); err != nil {
+				return fmt.Errorf("字段 %s: %w", field.Name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -260,6 +436,10 @@ func (p *Parser) validateEndpoint(endpoint *APIEndpoint) error {
 		return fmt.Errorf("Handler 不能为空")
 	}
 
+	if _, err := endpoint.Paging(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -281,12 +461,13 @@ func (s *Spec) GetModelByName(name string) (*ModelDefinition, bool) {
 	return nil, false
 }
 
-// GetEndpointsByModel gets all endpoints for a specific model
+// GetEndpointsByModel gets the endpoints served by the CRUD routes of a model:
+// POST/GET /<plural> and GET/PUT/DELETE /<plural>/:id, e.g. /articles and /articles/:id
 func (s *Spec) GetEndpointsByModel(modelName string) []APIEndpoint {
 	var endpoints []APIEndpoint
+	base := "/" + pluralize(toLowerCamelCase(modelName))
 	for _, endpoint := range s.APIs {
-		// Check if handler contains model name
-		if containsModelName(endpoint.Handler, modelName) {
+		if crudRoute(endpoint, base) {
 			endpoints = append(endpoints, endpoint)
 		}
 	}
@@ -0,0 +1,189 @@
+package spec
+
+import "fmt"
+
+// 关联类型
+const (
+	RelationBelongsTo = "belongsTo" // 外键在本模型，如 Article.author_id -> User
+	RelationHasOne    = "hasOne"    // 外键在关联模型，一对一
+	RelationHasMany   = "hasMany"   // 外键在关联模型，一对多
+	RelationMany2Many = "many2many" // 通过中间表关联
+)
+
+// relationInfo 模板使用的关联，省略的外键、中间表列已按约定补全
+type relationInfo struct {
+	RelationDef
+	FieldName   string // 结构体字段名
+	JSONName    string // JSON 字段名
+	GORMTag     string // gorm 标签
+	Table       string // 关联模型的表名
+	Column      string // 子资源查询的列：hasMany 为关联模型的外键列，many2many 为关联模型被中间表引用的列
+	Path        string // 子资源列表路由（相对模型路由组），如 /:id/comments，只有 hasMany/many2many 生成
+	Handler     string // 子资源列表方法名，如 ListComments
+	PageSize    int    // 子资源列表默认每页数量，取关联模型列表接口的分页配置
+	MaxPageSize int
+	Auth        bool // 子资源列表路由的认证与权限，由 newRouteInfo 填充
+	Permission  string
+}
+
+// GoType 字段类型：belongsTo/hasOne 为指针，hasMany/many2many 为切片
+func (r relationInfo) GoType() string {
+	if r.Type == RelationBelongsTo || r.Type == RelationHasOne {
+		return "*" + r.Model
+	}
+	return "[]*" + r.Model
+}
+
+// modelRelations 模型的关联（spec 已通过 validateRelation 校验）
+func modelRelations(s *Spec, model ModelDefinition) []relationInfo {
+	var result []relationInfo
+	for _, rel := range model.Relations {
+		if info, err := resolveRelation(s, model, rel); err == nil {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
+// validateRelation 校验关联：类型合法、关联模型存在、外键与引用的列存在、字段名不冲突
+func validateRelation(s *Spec, model ModelDefinition, rel RelationDef) error {
+	_, err := resolveRelation(s, model, rel)
+	return err
+}
+
+// resolveRelation 按约定补全关联的外键与中间表列，并检查引用的模型与列是否存在
+func resolveRelation(s *Spec, model ModelDefinition, rel RelationDef) (relationInfo, error) {
+	info := relationInfo{RelationDef: rel, FieldName: toCamelCase(rel.Name), JSONName: toLowerCamelCase(rel.Name)}
+	if rel.Name == "" {
+		return info, fmt.Errorf("关联名称不能为空")
+	}
+
+	switch rel.Type {
+	case RelationBelongsTo, RelationHasOne, RelationHasMany, RelationMany2Many:
+	default:
+		return info, fmt.Errorf("不支持的关联类型 %q (支持: %s, %s, %s, %s)",
+			rel.Type, RelationBelongsTo, RelationHasOne, RelationHasMany, RelationMany2Many)
+	}
+
+	target, ok := s.GetModelByName(rel.Model)
+	if !ok {
+		return info, fmt.Errorf("关联的模型 %q 不存在", rel.Model)
+	}
+	info.Table = target.Table
+
+	for _, field := range model.Fields {
+		if toCamelCase(field.Name) == info.FieldName {
+			return info, fmt.Errorf("关联名称与字段 %s 冲突", field.Name)
+		}
+	}
+	count := 0
+	for _, other := range model.Relations {
+		if toCamelCase(other.Name) == info.FieldName {
+			count++
+		}
+	}
+	if count > 1 {
+		return info, fmt.Errorf("关联名称重复")
+	}
+
+	switch rel.Type {
+	case RelationBelongsTo, RelationHasOne, RelationHasMany:
+		// belongsTo 的外键在本模型，引用关联模型；hasOne/hasMany 反之
+		owner, referenced := *target, model
+		foreignKey := snakeCase(model.Name) + "_id"
+		if rel.Type == RelationBelongsTo {
+			owner, referenced = model, *target
+			foreignKey = snakeCase(rel.Name) + "_id"
+		}
+		if rel.ForeignKey != "" {
+			foreignKey = rel.ForeignKey
+		}
+		references := rel.References
+		if references == "" {
+			references = primaryKeyColumn(referenced)
+		}
+		if !hasColumn(owner, foreignKey) {
+			return info, fmt.Errorf("外键列 %s 在模型 %s 中不存在", foreignKey, owner.Name)
+		}
+		if !hasColumn(referenced, references) {
+			return info, fmt.Errorf("引用的列 %s 在模型 %s 中不存在", references, referenced.Name)
+		}
+		info.ForeignKey, info.References, info.Column = foreignKey, references, foreignKey
+		info.GORMTag = "foreignKey:" + toCamelCase(foreignKey) + ";references:" + toCamelCase(references)
+
+	case RelationMany2Many:
+		if rel.JoinTable == "" {
+			return info, fmt.Errorf("many2many 关联缺少 joinTable")
+		}
+		// many2many 的 foreignKey/references 分别为本模型与关联模型被中间表引用的列
+		foreignKey, references := rel.ForeignKey, rel.References
+		if foreignKey == "" {
+			foreignKey = primaryKeyColumn(model)
+		}
+		if references == "" {
+			references = primaryKeyColumn(*target)
+		}
+		if !hasColumn(model, foreignKey) {
+			return info, fmt.Errorf("引用的列 %s 在模型 %s 中不存在", foreignKey, model.Name)
+		}
+		if !hasColumn(*target, references) {
+			return info, fmt.Errorf("引用的列 %s 在模型 %s 中不存在", references, target.Name)
+		}
+		joinForeignKey, joinReferences := rel.JoinForeignKey, rel.JoinReferences
+		if joinForeignKey == "" {
+			joinForeignKey = snakeCase(model.Name) + "_id"
+		}
+		if joinReferences == "" {
+			joinReferences = snakeCase(target.Name) + "_id"
+		}
+		// 中间表同时定义为模型时，检查其中的列
+		for _, join := range s.Models {
+			if join.Table != rel.JoinTable {
+				continue
+			}
+			for _, column := range []string{joinForeignKey, joinReferences} {
+				if !hasColumn(join, column) {
+					return info, fmt.Errorf("中间表列 %s 在模型 %s 中不存在", column, join.Name)
+				}
+			}
+		}
+		info.ForeignKey, info.References, info.Column = foreignKey, references, references
+		info.JoinForeignKey, info.JoinReferences = joinForeignKey, joinReferences
+		info.GORMTag = "many2many:" + rel.JoinTable +
+			";foreignKey:" + toCamelCase(foreignKey) +
+			";joinForeignKey:" + toCamelCase(joinForeignKey) +
+			";references:" + toCamelCase(references) +
+			";joinReferences:" + toCamelCase(joinReferences)
+	}
+
+	// 子资源路由按本模型的主键（/:id）查询
+	owned := rel.Type == RelationHasMany && info.References == primaryKeyColumn(model) ||
+		rel.Type == RelationMany2Many && info.ForeignKey == primaryKeyColumn(model)
+	if owned {
+		paging := listPaging(s.GetEndpointsByModel(target.Name))
+		info.Path = "/:id/" + snakeCase(rel.Name)
+		info.Handler = "List" + info.FieldName
+		info.PageSize, info.MaxPageSize = paging.PageSize, paging.MaxPageSize
+	}
+	return info, nil
+}
+
+// hasColumn 模型是否定义了指定的列
+func hasColumn(model ModelDefinition, column string) bool {
+	for _, field := range model.Fields {
+		if field.Name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKeyColumn 模型主键的列名，没有声明主键时为 id
+func primaryKeyColumn(model ModelDefinition) string {
+	for _, field := range model.Fields {
+		if field.PrimaryKey {
+			return field.Name
+		}
+	}
+	return "id"
+}
@@ -0,0 +1,50 @@
+package spec
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestResolveRelation 验证示例 spec 中关联的外键推导、GORM 标签与子资源路由，以及引用不存在的模型或列时报错
+func TestResolveRelation(t *testing.T) {
+	s, err := New("").ParseFile(filepath.Join("..", "..", "spec", "example.blog.spec.yaml"))
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+	article, _ := s.GetModelByName("Article")
+
+	want := map[string]relationInfo{
+		"Category": {GORMTag: "foreignKey:CategoryId;references:Id"},
+		"Comments": {GORMTag: "foreignKey:ArticleId;references:Id", Path: "/:id/comments", Handler: "ListComments"},
+		"Tags": {
+			GORMTag: "many2many:article_tags;foreignKey:Id;joinForeignKey:ArticleId;references:Id;joinReferences:TagId",
+			Path:    "/:id/tags", Handler: "ListTags",
+		},
+	}
+	for _, rel := range modelRelations(s, *article) {
+		w, ok := want[rel.FieldName]
+		if !ok {
+			continue
+		}
+		if rel.GORMTag != w.GORMTag || rel.Path != w.Path || rel.Handler != w.Handler {
+			t.Errorf("relation %s = {%s %s %s}, want {%s %s %s}",
+				rel.FieldName, rel.GORMTag, rel.Path, rel.Handler, w.GORMTag, w.Path, w.Handler)
+		}
+	}
+
+	for _, tt := range []struct {
+		rel     RelationDef
+		wantErr string
+	}{
+		{RelationDef{Name: "Owner", Type: RelationBelongsTo, Model: "Account"}, "不存在"},
+		{RelationDef{Name: "Owner", Type: RelationBelongsTo, Model: "User"}, "owner_id"},
+		{RelationDef{Name: "Labels", Type: RelationMany2Many, Model: "Tag"}, "joinTable"},
+		{RelationDef{Name: "Title", Type: RelationHasOne, Model: "User"}, "冲突"},
+	} {
+		err := validateRelation(s, *article, tt.rel)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateRelation(%s) error = %v, want containing %q", tt.rel.Name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hookOps 支持生命周期钩子的 Service 操作
+var hookOps = []struct {
+	Op    string // 操作名，与触发时机中的操作对应（create、update、delete、get）
+	Label string // 中文名
+}{
+	{"Create", "创建"},
+	{"Update", "更新"},
+	{"Delete", "删除"},
+	{"Get", "查询详情"},
+}
+
+// hookInfo 模型 Service 的一个生命周期钩子，如 BeforeCreateArticle
+type hookInfo struct {
+	Phase string // Before 或 After
+	Op    string // Create、Update、Delete、Get
+	Name  string // 钩子方法名，如 BeforeCreateArticle，对应触发时机 before_create_article
+	Field string // 已注册钩子的字段名，如 beforeCreate
+	Label string // 调用时机，如 创建前
+	ByID  bool   // 参数为 id（删除前后、查询前），否则为模型
+	Rules []ruleInfo
+}
+
+// Doc 钩子接口的说明
+func (h hookInfo) Doc() string {
+	switch {
+	case h.Phase == "Before":
+		return "，返回错误时中止" + strings.TrimSuffix(h.Label, "前")
+	case h.Op == "Get":
+		return "，可以修改返回的记录"
+	}
+	return "，返回错误时作为操作的错误返回"
+}
+
+// ruleInfo spec 中的业务规则，生成为 Service 的方法并注册到触发的钩子
+type ruleInfo struct {
+	BusinessRule
+	Method string   // 实现规则的 Service 方法，如 ruleSlugGeneration
+	Action []string // action 的各行，生成到方法注释中
+}
+
+// modelHooks 模型 Service 的全部生命周期钩子，附带触发它们的业务规则（spec 已通过 validateRule 校验）
+func modelHooks(s *Spec, model ModelDefinition) []hookInfo {
+	var hooks []hookInfo
+	for _, op := range hookOps {
+		for _, phase := range []string{"Before", "After"} {
+			h := hookInfo{
+				Phase: phase,
+				Op:    op.Op,
+				Name:  phase + op.Op + model.Name,
+				Field: strings.ToLower(phase) + op.Op,
+				Label: op.Label + map[string]string{"Before": "前", "After": "后"}[phase],
+				ByID:  op.Op == "Delete" || op.Op == "Get" && phase == "Before",
+			}
+			for _, rule := range s.Rules {
+				if p, o, m, err := parseTrigger(s, rule.Trigger); err == nil && p == phase && o == op.Op && m == model.Name {
+					h.Rules = append(h.Rules, newRuleInfo(rule))
+				}
+			}
+			hooks = append(hooks, h)
+		}
+	}
+	return hooks
+}
+
+// hasRules 模型是否有业务规则
+func hasRules(hooks []hookInfo) bool {
+	for _, h := range hooks {
+		if len(h.Rules) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// newRuleInfo 业务规则对应的 Service 方法
+func newRuleInfo(rule BusinessRule) ruleInfo {
+	info := ruleInfo{BusinessRule: rule, Method: "rule" + identifier(rule.Name)}
+	if strings.TrimSpace(rule.Action) == "" {
+		return info
+	}
+	for _, line := range strings.Split(strings.TrimRight(rule.Action, "\n "), "\n") {
+		info.Action = append(info.Action, strings.TrimRight(line, " \t"))
+	}
+	return info
+}
+
+// parseTrigger 解析触发时机 [before_|after_]<create|update|delete|get>_<模型>，
+// 省略 before_/after_ 时为操作之后，如 get_article → After、Get、Article
+func parseTrigger(s *Spec, trigger string) (phase, op, model string, err error) {
+	rest := strings.ToLower(strings.TrimSpace(trigger))
+	phase = "After"
+	if strings.HasPrefix(rest, "before_") {
+		phase, rest = "Before", strings.TrimPrefix(rest, "before_")
+	} else {
+		rest = strings.TrimPrefix(rest, "after_")
+	}
+
+	name, target, _ := strings.Cut(rest, "_")
+	for _, o := range hookOps {
+		if strings.ToLower(o.Op) == name {
+			op = o.Op
+		}
+	}
+	if op == "" {
+		return "", "", "", fmt.Errorf("无效的触发时机 %q，格式为 [before_|after_]<create|update|delete|get>_<模型>，如 before_create_article", trigger)
+	}
+	for _, m := range s.Models {
+		if snakeCase(m.Name) == target {
+			return phase, op, m.Name, nil
+		}
+	}
+	return "", "", "", fmt.Errorf("触发时机 %q 中的模型 %q 不存在", trigger, target)
+}
+
+// validateRule 校验业务规则：名称可以作为 Go 标识符且不重复，触发时机合法
+func validateRule(s *Spec, rule BusinessRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("规则名称不能为空")
+	}
+	if identifier(rule.Name) == "" {
+		return fmt.Errorf("规则名称 %q 无法转换为 Go 标识符", rule.Name)
+	}
+	count := 0
+	for _, other := range s.Rules {
+		if identifier(other.Name) == identifier(rule.Name) {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("规则名称重复")
+	}
+	_, _, _, err := parseTrigger(s, rule.Trigger)
+	return err
+}
@@ -0,0 +1,63 @@
+package spec
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestModelHooks 验证示例 spec 中的业务规则按触发时机注册到对应的生命周期钩子
+func TestModelHooks(t *testing.T) {
+	s, err := New("").ParseFile(filepath.Join("..", "..", "spec", "example.blog.spec.yaml"))
+	if err != nil {
+		t.Fatalf("ParseFile() unexpected error: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, model := range s.Models {
+		for _, h := range modelHooks(s, model) {
+			for _, rule := range h.Rules {
+				got[rule.Method] = h.Name
+			}
+		}
+	}
+	want := map[string]string{
+		"ruleSlugGeneration":       "BeforeCreateArticle",
+		"ruleArticleViewIncrement": "AfterGetArticle",
+		"ruleCommentNotification":  "AfterCreateComment",
+	}
+	if len(got) != len(want) {
+		t.Errorf("modelHooks() registered %d rules, want %d: %v", len(got), len(want), got)
+	}
+	for method, hook := range want {
+		if got[method] != hook {
+			t.Errorf("%s registered to %q, want %q", method, got[method], hook)
+		}
+	}
+}
+
+// TestParseTrigger 验证触发时机的解析与错误
+func TestParseTrigger(t *testing.T) {
+	s := &Spec{Models: []ModelDefinition{{Name: "BlogPost"}}}
+
+	tests := []struct {
+		trigger string
+		want    string
+		wantErr bool
+	}{
+		{"before_update_blog_post", "Before Update BlogPost", false},
+		{"after_delete_blog_post", "After Delete BlogPost", false},
+		{"get_blog_post", "After Get BlogPost", false},
+		{"save_blog_post", "", true},
+		{"before_create_comment", "", true},
+	}
+	for _, tt := range tests {
+		phase, op, model, err := parseTrigger(s, tt.trigger)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTrigger(%q) error = %v, wantErr %v", tt.trigger, err, tt.wantErr)
+			continue
+		}
+		if got := phase + " " + op + " " + model; !tt.wantErr && got != tt.want {
+			t.Errorf("parseTrigger(%q) = %q, want %q", tt.trigger, got, tt.want)
+		}
+	}
+}
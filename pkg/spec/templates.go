@@ -3,16 +3,22 @@ package spec
 // 内置模板内容
 
 const modelTemplate = `package model
+{{- if .Imports}}
 
 import (
-	"time"
-	"gorm.io/gorm"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
 )
+{{- end}}
 
 // {{.Model.Name}} {{.Model.Comment}}
 type {{.Model.Name}} struct {
 	{{- range $field := .Model.Fields}}
-    {{$field.Name | ToCamelCase}} {{getGoType2 $field}} ` + "`" + `gorm:"{{getGormTag $field}}{{getIndexTags $field.Name $.Model.Indexes}}" json:"{{getJSONTag $field.Name $field.JSON}}"` + "`" + ` // {{$field.Comment}}
+    {{$field.Name | ToCamelCase}} {{getFieldType $.Model $field}} ` + "`" + `gorm:"{{getGormTag $field}}{{getIndexTags $field.Name $.Model.Indexes}}" json:"{{getJSONTag $field.Name $field.JSON}}"{{getBindingTag $.Model $field}}` + "`" + ` // {{$field.Comment}}
+	{{- end}}
+	{{- range .Relations}}
+    {{.FieldName}} {{.GoType}} ` + "`" + `gorm:"{{.GORMTag}}" json:"{{.JSONName}},omitempty"` + "`" + ` // {{if .Comment}}{{.Comment}}{{else}}{{.Type}} {{.Model}}{{end}}，仅在预加载时返回
 	{{- end}}
 }
 
@@ -30,6 +36,43 @@ import (
     "{{.Spec.Project.Module}}/internal/model"
 )
 
+{{- $preload := ""}}
+{{- if .Relations}}{{$preload = ", preloads ...string"}}{{end}}
+{{- if .Relations}}
+
+// {{.Model.Name}} 可预加载的关联，作为 GetByID/List 的 preloads 参数
+const (
+{{- range .Relations}}
+	{{$.Model.Name}}Preload{{.FieldName}} = "{{.FieldName}}" // {{.Type}} {{.Model}}
+{{- end}}
+)
+
+// {{.Model.Name | ToLowerCamelCase}}Preloads {{.Model.Name}} 允许预加载的关联
+var {{.Model.Name | ToLowerCamelCase}}Preloads = map[string]bool{
+{{- range .Relations}}
+	{{$.Model.Name}}Preload{{.FieldName}}: true,
+{{- end}}
+}
+{{- end}}
+
+// {{.Model.Name}}Repo {{.Model.Name}} 仓储接口，Service 依赖此接口，测试时可替换为 mock.{{.Model.Name}}Repo
+type {{.Model.Name}}Repo interface {
+	Create(ctx context.Context, {{.Model.Name | ToLowerCamelCase}} *model.{{.Model.Name}}) error
+	GetByID(ctx context.Context, id uint{{$preload}}) (*model.{{.Model.Name}}, error)
+	Update(ctx context.Context, {{.Model.Name | ToLowerCamelCase}} *model.{{.Model.Name}}) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, page, pageSize int{{$preload}}) ([]*model.{{.Model.Name}}, int64, error)
+	{{- if eq .Paging.Mode "cursor"}}
+	ListAfter(ctx context.Context, after uint, pageSize int) ([]*model.{{.Model.Name}}, bool, error)
+	{{- end}}
+	{{- range .Relations}}{{if .Path}}
+	{{.Handler}}(ctx context.Context, id uint, page, pageSize int) ([]*model.{{.Model}}, int64, error)
+	{{- end}}{{end}}
+}
+
+// 确保 {{.Model.Name}}Repository 实现了 {{.Model.Name}}Repo 接口
+var _ {{.Model.Name}}Repo = (*{{.Model.Name}}Repository)(nil)
+
 // {{.Model.Name}}Repository {{.Model.Name}}数据访问层
 //
 // 职责说明：
@@ -50,10 +93,18 @@ func (r *{{.Model.Name}}Repository) Create(ctx context.Context, {{.Model.Name |
 	return r.db.WithContext(ctx).Create({{.Model.Name | ToLowerCamelCase}}).Error
 }
 
-// GetByID 根据 ID 获取 {{.Model.Name}}
-func (r *{{.Model.Name}}Repository) GetByID(ctx context.Context, id uint) (*model.{{.Model.Name}}, error) {
+// GetByID 根据 ID 获取 {{.Model.Name}}{{if .Relations}}，preloads 为要预加载的关联（{{.Model.Name}}Preload* 常量），未知关联返回 ErrUnknownPreload{{end}}
+func (r *{{.Model.Name}}Repository) GetByID(ctx context.Context, id uint{{$preload}}) (*model.{{.Model.Name}}, error) {
 	var {{.Model.Name | ToLowerCamelCase}} model.{{.Model.Name}}
+	{{- if .Relations}}
+	db, err := withPreloads(r.db.WithContext(ctx), {{.Model.Name | ToLowerCamelCase}}Preloads, preloads)
+	if err != nil {
+		return nil, err
+	}
+	err = db.First(&{{.Model.Name | ToLowerCamelCase}}, id).Error
+	{{- else}}
 	err := r.db.WithContext(ctx).First(&{{.Model.Name | ToLowerCamelCase}}, id).Error
+	{{- end}}
 	if err != nil {
 		return nil, err
 	}
@@ -70,41 +121,106 @@ func (r *{{.Model.Name}}Repository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&model.{{.Model.Name}}{}, id).Error
 }
 
-// List 获取 {{.Model.Name}} 列表（分页）
-func (r *{{.Model.Name}}Repository) List(ctx context.Context, page, pageSize int) ([]*model.{{.Model.Name}}, int64, error) {
+// List 获取 {{.Model.Name}} 列表（分页）{{if .Relations}}，preloads 同 GetByID{{end}}
+func (r *{{.Model.Name}}Repository) List(ctx context.Context, page, pageSize int{{$preload}}) ([]*model.{{.Model.Name}}, int64, error) {
 	var {{.Model.Name | ToLowerCamelCase}}s []*model.{{.Model.Name}}
 	var total int64
 
 	offset := (page - 1) * pageSize
 
 	query := r.db.WithContext(ctx).Model(&model.{{.Model.Name}}{})
+	{{- if .Relations}}
+	preloaded, err := withPreloads(query.Session(&gorm.Session{}), {{.Model.Name | ToLowerCamelCase}}Preloads, preloads)
+	if err != nil {
+		return nil, 0, err
+	}
+	{{- end}}
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	{{- if .Relations}}
+	if err := preloaded.Offset(offset).Limit(pageSize).Find(&{{.Model.Name | ToLowerCamelCase}}s).Error; err != nil {
+	{{- else}}
 	if err := query.Offset(offset).Limit(pageSize).Find(&{{.Model.Name | ToLowerCamelCase}}s).Error; err != nil {
+	{{- end}}
 		return nil, 0, err
 	}
 
 	return {{.Model.Name | ToLowerCamelCase}}s, total, nil
 }
+{{- range .Relations}}{{if .Path}}
+
+// {{.Handler}} 分页获取 {{$.Model.Name}} 关联的 {{.Model}} 列表（{{.Type}}）
+func (r *{{$.Model.Name}}Repository) {{.Handler}}(ctx context.Context, id uint, page, pageSize int) ([]*model.{{.Model}}, int64, error) {
+	var items []*model.{{.Model}}
+	var total int64
+
+	{{if eq .Type "many2many" -}}
+	query := r.db.WithContext(ctx).Model(&model.{{.Model}}{}).
+		Joins("JOIN {{.JoinTable}} ON {{.JoinTable}}.{{.JoinReferences}} = {{.Table}}.{{.Column}}").
+		Where("{{.JoinTable}}.{{.JoinForeignKey}} = ?", id)
+	{{- else -}}
+	query := r.db.WithContext(ctx).Model(&model.{{.Model}}{}).Where("{{.Column}} = ?", id)
+	{{- end}}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+{{- end}}{{end}}
+{{- if eq .Paging.Mode "cursor"}}
+
+// ListAfter 按主键 {{primaryKeyColumn .Model}} 游标（keyset）分页获取 {{.Model.Name}} 列表，不执行 COUNT
+//
+// after 为上一页最后一条记录的主键（第一页传 0），返回的 bool 表示是否还有下一页
+func (r *{{.Model.Name}}Repository) ListAfter(ctx context.Context, after uint, pageSize int) ([]*model.{{.Model.Name}}, bool, error) {
+	var {{.Model.Name | ToLowerCamelCase}}s []*model.{{.Model.Name}}
+
+	query := r.db.WithContext(ctx).Order("{{primaryKeyColumn .Model}}")
+	if after > 0 {
+		query = query.Where("{{primaryKeyColumn .Model}} > ?", after)
+	}
+
+	// 多查一条判断是否还有下一页，代替 COUNT
+	if err := query.Limit(pageSize + 1).Find(&{{.Model.Name | ToLowerCamelCase}}s).Error; err != nil {
+		return nil, false, err
+	}
+
+	if len({{.Model.Name | ToLowerCamelCase}}s) > pageSize {
+		return {{.Model.Name | ToLowerCamelCase}}s[:pageSize], true, nil
+	}
+	return {{.Model.Name | ToLowerCamelCase}}s, false, nil
+}
+{{- end}}
 `
 
 const serviceTemplate = `package service
 
 import (
     "context"
+    {{- if eq .Paging.Mode "cursor"}}
+    "encoding/base64"
+    {{- end}}
     "errors"
     "fmt"
-    "time"
+    {{- if eq .Paging.Mode "cursor"}}
+    "strconv"
+    {{- end}}
 
     "{{.Spec.Project.Module}}/internal/model"
     "{{.Spec.Project.Module}}/internal/repository"
-    {{- if or .GetCacheEnabled .ListCacheEnabled}}
     "github.com/Martindeeepdark/go-start/pkg/commonadapter"
-    {{- end}}
 )
 
+{{- $preload := ""}}
+{{- if .Relations}}{{$preload = ", preloads ...string"}}{{end}}
+
 // 定义业务错误
 var (
 	Err{{.Model.Name}}NotFound = errors.New("{{.Model.Name}}不存在")
@@ -118,34 +234,68 @@ var (
 //   - 处理数据的缓存策略
 //   - 实现业务的校验和规则
 type {{.Model.Name}}Service struct {
-    repo  *repository.{{.Model.Name}}Repository
+    repo  repository.{{.Model.Name}}Repo
+    hooks {{.Model.Name | ToLowerCamelCase}}Hooks
 }
-// listCacheEntry 用于列表缓存封装
-type listCacheEntry struct {
+// {{.Model.Name | ToLowerCamelCase}}ListCacheEntry 用于列表缓存封装
+type {{.Model.Name | ToLowerCamelCase}}ListCacheEntry struct {
     List []*model.{{.Model.Name}}
     Total int64
 }
 
-// New{{.Model.Name}}Service 创建 {{.Model.Name}} 服务实例
-func New{{.Model.Name}}Service(repo *repository.{{.Model.Name}}Repository) *{{.Model.Name}}Service {
-    return &{{.Model.Name}}Service{
+// New{{.Model.Name}}Service 创建 {{.Model.Name}} 服务实例，并注册 spec 中定义的业务规则
+func New{{.Model.Name}}Service(repo repository.{{.Model.Name}}Repo) *{{.Model.Name}}Service {
+    s := &{{.Model.Name}}Service{
         repo:  repo,
     }
+    s.registerRules()
+    return s
 }
 
 // Create 创建 {{.Model.Name}}
 func (s *{{.Model.Name}}Service) Create(ctx context.Context, {{.Model.Name | ToLowerCamelCase}} *model.{{.Model.Name}}) error {
+    if err := s.hooks.beforeCreateHooks(ctx, {{.Model.Name | ToLowerCamelCase}}); err != nil {
+        return err
+    }
     if err := s.repo.Create(ctx, {{.Model.Name | ToLowerCamelCase}}); err != nil {
         return fmt.Errorf("创建{{.Model.Name}}失败: %w", err)
     }
     _, cache, _, _, _, _ := commonadapter.Abilities()
-    _ = cache.Delete(fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:%d", {{.Model.Name | ToLowerCamelCase}}.ID))
+    _ = cache.Delete(fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:%d", {{.Model.Name | ToLowerCamelCase}}.{{primaryKeyName .Model}}))
     _ = cache.DeleteByPattern("{{.Model.Name | ToLowerCamelCase}}:list:*")
-    return nil
+    return s.hooks.afterCreateHooks(ctx, {{.Model.Name | ToLowerCamelCase}})
 }
 
-// GetByID 根据 ID 获取 {{.Model.Name}}
-func (s *{{.Model.Name}}Service) GetByID(ctx context.Context, id uint) (*model.{{.Model.Name}}, error) {
+// GetByID 根据 ID 获取 {{.Model.Name}}{{if .Relations}}，preloads 为要预加载的关联{{end}}
+func (s *{{.Model.Name}}Service) GetByID(ctx context.Context, id uint{{$preload}}) (*model.{{.Model.Name}}, error) {
+    if err := s.hooks.beforeGetHooks(ctx, id); err != nil {
+        return nil, err
+    }
+    {{.Model.Name | ToLowerCamelCase}}, err := s.getByID(ctx, id{{if .Relations}}, preloads...{{end}})
+    if err != nil {
+        return nil, err
+    }
+    if err := s.hooks.afterGetHooks(ctx, {{.Model.Name | ToLowerCamelCase}}); err != nil {
+        return nil, err
+    }
+    return {{.Model.Name | ToLowerCamelCase}}, nil
+}
+
+// getByID 查询 {{.Model.Name}}（先查缓存），不调用钩子
+func (s *{{.Model.Name}}Service) getByID(ctx context.Context, id uint{{$preload}}) (*model.{{.Model.Name}}, error) {
+    {{- if .Relations}}
+    if len(preloads) > 0 {
+        // 预加载关联的结果不缓存
+        {{.Model.Name | ToLowerCamelCase}}, err := s.repo.GetByID(ctx, id, preloads...)
+        if errors.Is(err, repository.ErrUnknownPreload) {
+            return nil, err
+        }
+        if err != nil {
+            return nil, Err{{.Model.Name}}NotFound
+        }
+        return {{.Model.Name | ToLowerCamelCase}}, nil
+    }
+    {{- end}}
     {{- if .GetCacheEnabled}}
     cacheKey := fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:%d", id)
     _, cache, _, _, _, _ := commonadapter.Abilities()
@@ -160,7 +310,6 @@ func (s *{{.Model.Name}}Service) GetByID(ctx context.Context, id uint) (*model.{
         return nil, Err{{.Model.Name}}NotFound
     }
     {{- if .GetCacheEnabled}}
-    _, cache, _, _, _, _ := commonadapter.Abilities()
     _ = cache.Set(cacheKey, {{.Model.Name | ToLowerCamelCase}}, {{if .GetCacheTTL}}{{.GetCacheTTL}}{{else}}600{{end}})
     {{- end}}
     return {{.Model.Name | ToLowerCamelCase}}, nil
@@ -168,40 +317,52 @@ func (s *{{.Model.Name}}Service) GetByID(ctx context.Context, id uint) (*model.{
 
 // Update 更新 {{.Model.Name}}
 func (s *{{.Model.Name}}Service) Update(ctx context.Context, {{.Model.Name | ToLowerCamelCase}} *model.{{.Model.Name}}) error {
+    if err := s.hooks.beforeUpdateHooks(ctx, {{.Model.Name | ToLowerCamelCase}}); err != nil {
+        return err
+    }
     if err := s.repo.Update(ctx, {{.Model.Name | ToLowerCamelCase}}); err != nil {
         return fmt.Errorf("更新{{.Model.Name}}失败: %w", err)
     }
     _, cache, _, _, _, _ := commonadapter.Abilities()
-    _ = cache.Delete(fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:%d", {{.Model.Name | ToLowerCamelCase}}.ID))
+    _ = cache.Delete(fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:%d", {{.Model.Name | ToLowerCamelCase}}.{{primaryKeyName .Model}}))
     _ = cache.DeleteByPattern("{{.Model.Name | ToLowerCamelCase}}:list:*")
-    return nil
+    return s.hooks.afterUpdateHooks(ctx, {{.Model.Name | ToLowerCamelCase}})
 }
 
 // Delete 删除 {{.Model.Name}}
 func (s *{{.Model.Name}}Service) Delete(ctx context.Context, id uint) error {
+    if err := s.hooks.beforeDeleteHooks(ctx, id); err != nil {
+        return err
+    }
     if err := s.repo.Delete(ctx, id); err != nil {
         return fmt.Errorf("删除{{.Model.Name}}失败: %w", err)
     }
     _, cache, _, _, _, _ := commonadapter.Abilities()
     _ = cache.Delete(fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:%d", id))
     _ = cache.DeleteByPattern("{{.Model.Name | ToLowerCamelCase}}:list:*")
-    return nil
+    return s.hooks.afterDeleteHooks(ctx, id)
 }
 
-// List 获取 {{.Model.Name}} 列表（分页）
-func (s *{{.Model.Name}}Service) List(ctx context.Context, page, pageSize int) ([]*model.{{.Model.Name}}, int64, error) {
+// List 获取 {{.Model.Name}} 列表（分页）{{if .Relations}}，preloads 为要预加载的关联{{end}}
+func (s *{{.Model.Name}}Service) List(ctx context.Context, page, pageSize int{{$preload}}) ([]*model.{{.Model.Name}}, int64, error) {
 	if page <= 0 {
 		page = 1
 	}
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 20
+	if pageSize <= 0 || pageSize > {{.Paging.MaxPageSize}} {
+		pageSize = {{.Paging.PageSize}}
 	}
+    {{- if .Relations}}
+    if len(preloads) > 0 {
+        // 预加载关联的结果不缓存
+        return s.repo.List(ctx, page, pageSize, preloads...)
+    }
+    {{- end}}
 
     {{- if .ListCacheEnabled}}
     cacheKey := fmt.Sprintf("{{.Model.Name | ToLowerCamelCase}}:list:%d:%d", page, pageSize)
     _, cache, _, _, _, _ := commonadapter.Abilities()
     if v, err := cache.Get(cacheKey); err == nil {
-        if entry, ok := v.(*listCacheEntry); ok {
+        if entry, ok := v.(*{{.Model.Name | ToLowerCamelCase}}ListCacheEntry); ok {
             return entry.List, entry.Total, nil
         }
     }
@@ -211,21 +372,73 @@ func (s *{{.Model.Name}}Service) List(ctx context.Context, page, pageSize int) (
         return nil, 0, err
     }
     {{- if .ListCacheEnabled}}
-    _, cache, _, _, _, _ := commonadapter.Abilities()
-    _ = cache.Set(cacheKey, &listCacheEntry{List: res, Total: total}, {{if .ListCacheTTL}}{{.ListCacheTTL}}{{else}}300{{end}})
+    _ = cache.Set(cacheKey, &{{.Model.Name | ToLowerCamelCase}}ListCacheEntry{List: res, Total: total}, {{if .ListCacheTTL}}{{.ListCacheTTL}}{{else}}300{{end}})
     {{- end}}
     return res, total, nil
 }
+{{- if eq .Paging.Mode "cursor"}}
+
+// ListAfter 按游标分页获取 {{.Model.Name}} 列表，不统计总数
+//
+// cursor 为上一页返回的游标（第一页传空），返回下一页的游标（没有更多数据时为空）
+// 和实际使用的每页数量（pageSize 不合法时为默认值）
+func (s *{{.Model.Name}}Service) ListAfter(ctx context.Context, cursor string, pageSize int) ([]*model.{{.Model.Name}}, string, int, error) {
+	if pageSize <= 0 || pageSize > {{.Paging.MaxPageSize}} {
+		pageSize = {{.Paging.PageSize}}
+	}
+
+	var after uint64
+	if cursor != "" {
+		data, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err == nil {
+			after, err = strconv.ParseUint(string(data), 10, 64)
+		}
+		if err != nil {
+			return nil, "", 0, ErrInvalidCursor
+		}
+	}
+
+	res, more, err := s.repo.ListAfter(ctx, uint(after), pageSize)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	var next string
+	if more {
+		next = base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(res[len(res)-1].{{primaryKeyName .Model}}), 10)))
+	}
+	return res, next, pageSize, nil
+}
+{{- end}}
+{{- range .Relations}}{{if .Path}}
+
+// {{.Handler}} 分页获取 {{$.Model.Name}} 关联的 {{.Model}} 列表
+func (s *{{$.Model.Name}}Service) {{.Handler}}(ctx context.Context, id uint, page, pageSize int) ([]*model.{{.Model}}, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > {{.MaxPageSize}} {
+		pageSize = {{.PageSize}}
+	}
+	return s.repo.{{.Handler}}(ctx, id, page, pageSize)
+}
+{{- end}}{{end}}
 `
 
 const controllerTemplate = `package controller
 
 import (
+    {{- if or (eq .Paging.Mode "cursor") .Relations}}
+    "errors"
+    {{- end}}
     "net/http"
     "strconv"
 
     "github.com/gin-gonic/gin"
     "{{.Spec.Project.Module}}/internal/model"
+    {{- if .Relations}}
+    "{{.Spec.Project.Module}}/internal/repository"
+    {{- end}}
     "{{.Spec.Project.Module}}/internal/service"
     "{{.Spec.Project.Module}}/pkg/httpx/response"
     "github.com/Martindeeepdark/go-start/pkg/commonadapter"
@@ -296,10 +509,10 @@ func (c *{{.Model.Name}}Controller) Create(ctx *gin.Context) {
         return
     }
     _ = audit.Record(userID, "{{.Model.Name}}", "create", "success", "")
-    response.Success(ctx, gin.H{"id": {{.Model.Name | ToLowerCamelCase}}.ID})
+    response.Success(ctx, gin.H{"id": {{.Model.Name | ToLowerCamelCase}}.{{primaryKeyName .Model}}})
 }
 
-// GetByID 获取 {{.Model.Name}} 详情
+// GetByID 获取 {{.Model.Name}} 详情{{if .Relations}}，?preload={{range $i, $r := .Relations}}{{if $i}},{{end}}{{$r.FieldName}}{{end}} 预加载关联{{end}}
 func (c *{{.Model.Name}}Controller) GetByID(ctx *gin.Context) {
     {{- if .GetAuth}}
     var userID string
@@ -314,8 +527,14 @@ func (c *{{.Model.Name}}Controller) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	{{.Model.Name | ToLowerCamelCase}}, err := c.service.GetByID(ctx, uint(id))
+	{{.Model.Name | ToLowerCamelCase}}, err := c.service.GetByID(ctx, uint(id){{if .Relations}}, ctx.QueryArray("preload")...{{end}})
 	if err != nil {
+		{{- if .Relations}}
+		if errors.Is(err, repository.ErrUnknownPreload) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		{{- end}}
 		response.Error(ctx, http.StatusNotFound, err.Error())
 		return
 	}
@@ -355,7 +574,7 @@ func (c *{{.Model.Name}}Controller) Update(ctx *gin.Context) {
         return
     }
 
-	{{.Model.Name | ToLowerCamelCase}}.ID = uint(id)
+	{{.Model.Name | ToLowerCamelCase}}.{{primaryKeyName .Model}} = uint(id)
     if err := c.service.Update(ctx, &{{.Model.Name | ToLowerCamelCase}}); err != nil {
         response.Error(ctx, http.StatusInternalServerError, err.Error())
         return
@@ -398,11 +617,32 @@ func (c *{{.Model.Name}}Controller) List(ctx *gin.Context) {
     if err := auth.RequirePermission(userID, "{{.ListPerm}}"); err != nil { response.Error(ctx, http.StatusForbidden, "权限不足"); return }
     {{- end}}
     {{- end}}
+    {{- if eq .Paging.Mode "cursor"}}
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "{{.Paging.PageSize}}"))
+
+	{{.Model.Name | ToLowerCamelCase}}s, next, pageSize, err := c.service.ListAfter(ctx, ctx.Query("cursor"), pageSize)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.CursorPaginated(ctx, {{.Model.Name | ToLowerCamelCase}}s, next, pageSize)
+    {{- else}}
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "{{.Paging.PageSize}}"))
 
-	{{.Model.Name | ToLowerCamelCase}}s, total, err := c.service.List(ctx, page, pageSize)
+	{{.Model.Name | ToLowerCamelCase}}s, total, err := c.service.List(ctx, page, pageSize{{if .Relations}}, ctx.QueryArray("preload")...{{end}})
 	if err != nil {
+		{{- if .Relations}}
+		if errors.Is(err, repository.ErrUnknownPreload) {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		{{- end}}
 		response.Error(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -413,21 +653,56 @@ func (c *{{.Model.Name}}Controller) List(ctx *gin.Context) {
 		"page":  page,
 		"page_size": pageSize,
 	})
+    {{- end}}
+}
+{{- range .Relations}}{{if .Path}}
+
+// {{.Handler}} 获取 {{$.Model.Name}} 关联的 {{.Model}} 列表
+func (c *{{$.Model.Name}}Controller) {{.Handler}}(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "无效的ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "{{.PageSize}}"))
+
+	items, total, err := c.service.{{.Handler}}(ctx, uint(id), page, pageSize)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(ctx, gin.H{
+		"list":  items,
+		"total": total,
+		"page":  page,
+		"page_size": pageSize,
+	})
 }
+{{- end}}{{end}}
 `
 
 const routesTemplate = `package routes
 
 import (
     "github.com/gin-gonic/gin"
+    "{{.Spec.Project.Module}}/docs"
     "{{.Spec.Project.Module}}/internal/controller"
     "github.com/Martindeeepdark/go-start/pkg/httpx/middleware"
 )
 
 // RegisterAutoRoutes 自动注册所有路由
+{{- range .Handlers}}{{if not .Model}}
+// controllers.{{.Name}} 为自定义端点的 {{.Name}}Controller，使用 controller.New{{.Name}}Controller 创建
+{{- end}}{{end}}
 //
 // 此文件由 spec 工具自动生成，请勿手动修改
 func RegisterAutoRoutes(r *gin.Engine, controllers *controller.Controllers) {
+    // 接口文档：/swagger/index.html
+    docs.Register(r)
+
     v1 := r.Group("/api/v1")
     {
         {{- range $info := .ModelsInfo}}
@@ -473,8 +748,34 @@ func RegisterAutoRoutes(r *gin.Engine, controllers *controller.Controllers) {
                 {{- end}}
                 controllers.{{$info.ModelName}}.Delete,
             )
+            {{- range $rel := $info.Nested}}
+            // {{$rel.Handler}}
+            {{$info.ModelVar}}.GET("{{$rel.Path}}",
+                {{- if or $rel.Auth $rel.Permission}}
+                {{- if $rel.Auth}}middleware.RequireAuth(),{{end}}
+                {{- if $rel.Permission}}middleware.RequirePermission("{{$rel.Permission}}"),{{end}}
+                {{- end}}
+                controllers.{{$info.ModelName}}.{{$rel.Handler}},
+            )
+            {{- end}}
         }
         {{- end}}
+        {{- if .Handlers}}
+
+        // 自定义端点
+        {{- range $owner := .Handlers}}
+        {{- range $h := $owner.Handlers}}
+        // {{$h.Handler}}{{if $h.Comment}} {{$h.Comment}}{{end}}
+        v1.{{$h.Method}}("{{$h.Route}}",
+            {{- if or $h.Auth $h.Permission}}
+            {{- if $h.Auth}}middleware.RequireAuth(),{{end}}
+            {{- if $h.Permission}}middleware.RequirePermission("{{$h.Permission}}"),{{end}}
+            {{- end}}
+            controllers.{{$owner.Name}}.{{$h.Handler}},
+        )
+        {{- end}}
+        {{- end}}
+        {{- end}}
     }
 }
 `
@@ -490,7 +791,7 @@ import (
 // 使用 validator.v10 进行字段规则校验
 type {{.Request.Name}} struct {
     {{- range $f := .Request.Fields}}
-    {{ToCamelCase $f.Name}} {{getReqFieldType $f.Rules}} ` + "`" + `validate:"{{$f.Rules}}"` + "`" + ` // {{$f.Comment}}
+    {{ToCamelCase $f.Name}} {{getReqFieldType $f.Rules}} ` + "`" + `json:"{{$f.Name}}" validate:"{{$f.Rules}}"` + "`" + ` // {{$f.Comment}}
     {{- end}}
 }
 
@@ -505,15 +806,1051 @@ func (r *{{.Request.Name}}) Validate() error {
 }
 `
 
+const serviceCursorTemplate = `package service
+
+import "errors"
+
+// ErrInvalidCursor 游标分页的 cursor 参数无效
+var ErrInvalidCursor = errors.New("无效的游标")
+`
+
+const preloadTemplate = `package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownPreload 请求预加载的关联不存在
+var ErrUnknownPreload = errors.New("未知的预加载关联")
+
+// withPreloads 校验并预加载关联，每一项可以是逗号分隔的多个关联名（如 ?preload=Author,Tags）
+func withPreloads(db *gorm.DB, allowed map[string]bool, preloads []string) (*gorm.DB, error) {
+	for _, item := range preloads {
+		for _, name := range strings.Split(item, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !allowed[name] {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownPreload, name)
+			}
+			db = db.Preload(name)
+		}
+	}
+	return db, nil
+}
+`
+
+const handlerControllerTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"{{.Spec.Project.Module}}/internal/service"
+	"{{.Spec.Project.Module}}/internal/validator"
+	"{{.Spec.Project.Module}}/pkg/httpx/response"
+)
+{{- $owner := .Owner}}
+{{- if not $owner.Model}}
+
+// {{$owner.Name}}Controller {{$owner.Name}} 相关的自定义端点
+type {{$owner.Name}}Controller struct {
+	service *service.{{$owner.Name}}Service
+}
+
+// New{{$owner.Name}}Controller 创建 {{$owner.Name}} 控制器实例
+func New{{$owner.Name}}Controller(service *service.{{$owner.Name}}Service) *{{$owner.Name}}Controller {
+	return &{{$owner.Name}}Controller{
+		service: service,
+	}
+}
+{{- end}}
+{{- range $h := $owner.Handlers}}
+
+// {{$h.Handler}} {{if $h.Comment}}{{$h.Comment}}{{else}}{{$h.Method}} {{$h.Path}}{{end}}
+//
+// {{$h.Method}} /api/v1{{$h.Route}}，业务逻辑由 service.{{$owner.Name}}Service.{{$h.Handler}} 实现
+func (c *{{$owner.Name}}Controller) {{$h.Handler}}(ctx *gin.Context) {
+	{{- range $h.Params}}
+	{{- if eq .Type "uint"}}
+	{{.Var}}, err := strconv.ParseUint(ctx.Param("{{.Name}}"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "无效的{{.Label}}")
+		return
+	}
+	{{- else}}
+	{{.Var}} := ctx.Param("{{.Name}}")
+	{{- end}}
+	{{- end}}
+	{{- if $h.Validate}}
+	{{- if $h.Params}}
+{{end}}
+	var req validator.{{$h.Validate}}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.Error(ctx, http.StatusBadRequest, "参数错误: "+err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	{{- end}}
+	{{- if or $h.Params $h.Validate}}
+{{end}}
+	{{- if not $h.Paging}}
+	data, err := c.service.{{$h.Handler}}({{$h.CallArgs}})
+	if err != nil {
+		handlerError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, data)
+	{{- else if eq $h.Paging.Mode "cursor"}}
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "{{$h.Paging.PageSize}}"))
+	if pageSize < 1 || pageSize > {{$h.Paging.MaxPageSize}} {
+		pageSize = {{$h.Paging.PageSize}}
+	}
+
+	data, next, err := c.service.{{$h.Handler}}({{$h.CallArgs}})
+	if err != nil {
+		handlerError(ctx, err)
+		return
+	}
+
+	response.CursorPaginated(ctx, data, next, pageSize)
+	{{- else}}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "{{if $h.Paging.Page}}{{$h.Paging.Page}}{{else}}1{{end}}"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "{{$h.Paging.PageSize}}"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > {{$h.Paging.MaxPageSize}} {
+		pageSize = {{$h.Paging.PageSize}}
+	}
+
+	data, total, err := c.service.{{$h.Handler}}({{$h.CallArgs}})
+	if err != nil {
+		handlerError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, gin.H{
+		"list":      data,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+	{{- end}}
+}
+{{- end}}
+`
+
+const handlerControllerCommonTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"{{.Spec.Project.Module}}/internal/service"
+	"{{.Spec.Project.Module}}/pkg/httpx/response"
+)
+
+// handlerError 自定义端点的错误响应：service.StatusError 按其状态码响应，
+// 桩方法尚未实现时返回 501，其余返回 500
+func handlerError(ctx *gin.Context, err error) {
+	var statusErr *service.StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		response.Error(ctx, statusErr.Status, statusErr.Message)
+	case errors.Is(err, service.ErrNotImplemented):
+		response.Error(ctx, http.StatusNotImplemented, err.Error())
+	default:
+		response.Error(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+`
+
+const handlerServiceTemplate = `// 自定义端点的业务逻辑，桩方法由 spec 工具生成，可以直接修改。
+// 重新生成时已有的声明原样保留，只追加 spec 中新增端点的方法。
+
+package service
+
+import (
+	"context"
+
+	"{{.Spec.Project.Module}}/internal/validator"
+)
+{{- $owner := .Owner}}
+{{- if not $owner.Model}}
+
+// {{$owner.Name}}Service {{$owner.Name}} 相关端点的业务逻辑
+type {{$owner.Name}}Service struct{}
+
+// New{{$owner.Name}}Service 创建 {{$owner.Name}} 服务实例
+func New{{$owner.Name}}Service() *{{$owner.Name}}Service {
+	return &{{$owner.Name}}Service{}
+}
+{{- end}}
+{{- range $h := $owner.Handlers}}
+
+// {{$h.Handler}} {{if $h.Comment}}{{$h.Comment}}{{else}}{{$h.Method}} {{$h.Path}}{{end}}
+func (s *{{$owner.Name}}Service) {{$h.Handler}}({{$h.ServiceParams}}) {{$h.ServiceResults}} {
+	// TODO: 实现{{if $h.Comment}}{{$h.Comment}}{{else}} {{$h.Handler}}{{end}}
+	{{- if $h.Auth}}
+	// 当前用户 ID：ctx.Value("UserID").(string)
+	{{- end}}
+	{{$h.StubReturn}}
+}
+{{- end}}
+`
+
+const handlerServiceCommonTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+package service
+
+import "errors"
+
+// ErrNotImplemented 自定义端点的桩方法尚未实现，Controller 返回 501
+var ErrNotImplemented = errors.New("接口未实现")
+
+// StatusError 指定 HTTP 状态码的业务错误，自定义端点返回时 Controller 以该状态码响应，
+// 如 &StatusError{Status: http.StatusUnauthorized, Message: "用户名或密码错误"}
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+// Error 实现 error 接口
+func (e *StatusError) Error() string {
+	return e.Message
+}
+`
+
+const hooksTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+
+	"{{.Spec.Project.Module}}/internal/model"
+)
+{{- $m := .Model.Name}}
+{{- $v := .Model.Name | ToLowerCamelCase}}
+{{- range .Hooks}}
+
+// {{.Name}}Hook {{$m}} {{.Label}}调用{{.Doc}}
+type {{.Name}}Hook interface {
+	{{.Name}}(ctx context.Context, {{if .ByID}}id uint{{else}}{{$v}} *model.{{$m}}{{end}}) error
+}
+
+// {{.Name}}Func 把函数转换为 {{.Name}}Hook
+type {{.Name}}Func func(ctx context.Context, {{if .ByID}}id uint{{else}}{{$v}} *model.{{$m}}{{end}}) error
+
+// {{.Name}} 实现 {{.Name}}Hook
+func (f {{.Name}}Func) {{.Name}}(ctx context.Context, {{if .ByID}}id uint{{else}}{{$v}} *model.{{$m}}{{end}}) error {
+	return f(ctx, {{if .ByID}}id{{else}}{{$v}}{{end}})
+}
+{{- end}}
+
+// {{$v}}Hooks {{$m}}Service 已注册的生命周期钩子
+type {{$v}}Hooks struct {
+	{{- range .Hooks}}
+	{{.Field}} []{{.Name}}Hook
+	{{- end}}
+}
+{{- range .Hooks}}
+
+// On{{.Phase}}{{.Op}} 注册{{.Label}}的钩子，按注册顺序调用
+func (s *{{$m}}Service) On{{.Phase}}{{.Op}}(hooks ...{{.Name}}Hook) {
+	s.hooks.{{.Field}} = append(s.hooks.{{.Field}}, hooks...)
+}
+{{- end}}
+{{- range .Hooks}}
+
+// {{.Field}}Hooks 依次调用{{.Label}}的钩子，遇到错误时停止
+func (h *{{$v}}Hooks) {{.Field}}Hooks(ctx context.Context, {{if .ByID}}id uint{{else}}{{$v}} *model.{{$m}}{{end}}) error {
+	for _, hook := range h.{{.Field}} {
+		if err := hook.{{.Name}}(ctx, {{if .ByID}}id{{else}}{{$v}}{{end}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{- end}}
+
+// registerRules 注册 spec 中定义的业务规则，由 New{{$m}}Service 调用
+func (s *{{$m}}Service) registerRules() {
+	{{- range $h := .Hooks}}
+	{{- range .Rules}}
+	s.On{{$h.Phase}}{{$h.Op}}({{$h.Name}}Func(s.{{.Method}})) // {{.Name}}{{if .Comment}}：{{.Comment}}{{end}}
+	{{- end}}
+	{{- end}}
+}
+`
+
+const rulesTemplate = `// {{.Model.Name}} 的业务规则，桩方法由 spec 的 rules 生成，可以直接修改。
+// 重新生成时已有的声明原样保留，只追加 spec 中新增规则的方法。
+
+package service
+
+import (
+	"context"
+
+	"{{.Spec.Project.Module}}/internal/model"
+)
+{{- $m := .Model.Name}}
+{{- $v := .Model.Name | ToLowerCamelCase}}
+{{- range $h := .Hooks}}
+{{- range .Rules}}
+
+// {{.Method}} {{if .Comment}}{{.Comment}}{{else}}业务规则 {{.Name}}{{end}}，触发时机 {{.Trigger}}（{{$m}} {{$h.Label}}）
+{{- if .Action}}
+//
+// spec 中的 action：
+//
+{{- range .Action}}
+//{{if .}}	{{.}}{{end}}
+{{- end}}
+{{- end}}
+func (s *{{$m}}Service) {{.Method}}(ctx context.Context, {{if $h.ByID}}id uint{{else}}{{$v}} *model.{{$m}}{{end}}) error {
+	// TODO: 实现业务规则 {{.Name}}
+	return nil
+}
+{{- end}}
+{{- end}}
+`
+
+const mockCommonTemplate = `package mock
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotMocked 调用了未设置 Func 字段的 Mock 方法
+var ErrNotMocked = errors.New("mock: 方法未设置")
+
+// calls 记录 Mock 方法的调用次数，并发安全
+type calls struct {
+	mu sync.Mutex
+	n  map[string]int
+}
+
+// add 记录一次调用
+func (c *calls) add(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.n == nil {
+		c.n = make(map[string]int)
+	}
+	c.n[method]++
+}
+
+// Calls 返回方法被调用的次数
+func (c *calls) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n[method]
+}
+`
+
+const mockTemplate = `package mock
+
+import (
+	"context"
+
+	"{{.Spec.Project.Module}}/internal/model"
+	"{{.Spec.Project.Module}}/internal/repository"
+)
+
+{{- $m := .Model.Name}}
+{{- $v := .Model.Name | ToLowerCamelCase}}
+{{- $preload := ""}}
+{{- if .Relations}}{{$preload = ", preloads ...string"}}{{end}}
+
+// {{$m}}Repo repository.{{$m}}Repo 的 Mock 实现，未设置 Func 字段的方法返回 ErrNotMocked
+type {{$m}}Repo struct {
+	CreateFunc  func(ctx context.Context, {{$v}} *model.{{$m}}) error
+	GetByIDFunc func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error)
+	UpdateFunc  func(ctx context.Context, {{$v}} *model.{{$m}}) error
+	DeleteFunc  func(ctx context.Context, id uint) error
+	ListFunc    func(ctx context.Context, page, pageSize int{{$preload}}) ([]*model.{{$m}}, int64, error)
+	{{- if eq .Paging.Mode "cursor"}}
+	ListAfterFunc func(ctx context.Context, after uint, pageSize int) ([]*model.{{$m}}, bool, error)
+	{{- end}}
+	{{- range .Relations}}{{if .Path}}
+	{{.Handler}}Func func(ctx context.Context, id uint, page, pageSize int) ([]*model.{{.Model}}, int64, error)
+	{{- end}}{{end}}
+
+	calls
+}
+
+// 确保 {{$m}}Repo 实现了 repository.{{$m}}Repo 接口
+var _ repository.{{$m}}Repo = (*{{$m}}Repo)(nil)
+
+// Create 调用 CreateFunc
+func (m *{{$m}}Repo) Create(ctx context.Context, {{$v}} *model.{{$m}}) error {
+	m.add("Create")
+	if m.CreateFunc == nil {
+		return ErrNotMocked
+	}
+	return m.CreateFunc(ctx, {{$v}})
+}
+
+// GetByID 调用 GetByIDFunc
+func (m *{{$m}}Repo) GetByID(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error) {
+	m.add("GetByID")
+	if m.GetByIDFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetByIDFunc(ctx, id{{if .Relations}}, preloads...{{end}})
+}
+
+// Update 调用 UpdateFunc
+func (m *{{$m}}Repo) Update(ctx context.Context, {{$v}} *model.{{$m}}) error {
+	m.add("Update")
+	if m.UpdateFunc == nil {
+		return ErrNotMocked
+	}
+	return m.UpdateFunc(ctx, {{$v}})
+}
+
+// Delete 调用 DeleteFunc
+func (m *{{$m}}Repo) Delete(ctx context.Context, id uint) error {
+	m.add("Delete")
+	if m.DeleteFunc == nil {
+		return ErrNotMocked
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+// List 调用 ListFunc
+func (m *{{$m}}Repo) List(ctx context.Context, page, pageSize int{{$preload}}) ([]*model.{{$m}}, int64, error) {
+	m.add("List")
+	if m.ListFunc == nil {
+		return nil, 0, ErrNotMocked
+	}
+	return m.ListFunc(ctx, page, pageSize{{if .Relations}}, preloads...{{end}})
+}
+{{- if eq .Paging.Mode "cursor"}}
+
+// ListAfter 调用 ListAfterFunc
+func (m *{{$m}}Repo) ListAfter(ctx context.Context, after uint, pageSize int) ([]*model.{{$m}}, bool, error) {
+	m.add("ListAfter")
+	if m.ListAfterFunc == nil {
+		return nil, false, ErrNotMocked
+	}
+	return m.ListAfterFunc(ctx, after, pageSize)
+}
+{{- end}}
+{{- range .Relations}}{{if .Path}}
+
+// {{.Handler}} 调用 {{.Handler}}Func
+func (m *{{$m}}Repo) {{.Handler}}(ctx context.Context, id uint, page, pageSize int) ([]*model.{{.Model}}, int64, error) {
+	m.add("{{.Handler}}")
+	if m.{{.Handler}}Func == nil {
+		return nil, 0, ErrNotMocked
+	}
+	return m.{{.Handler}}Func(ctx, id, page, pageSize)
+}
+{{- end}}{{end}}
+`
+
+const serviceTestTemplate = `package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	"{{.Spec.Project.Module}}/internal/model"
+	"{{.Spec.Project.Module}}/internal/repository/mock"
+)
+
+{{- $m := .Model.Name}}
+{{- $v := .Model.Name | ToLowerCamelCase}}
+{{- $preload := ""}}
+{{- if .Relations}}{{$preload = ", preloads ...string"}}{{end}}
+
+func Test{{$m}}Service_GetByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		getByID func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error)
+		wantErr error
+	}{
+		{
+			name: "found",
+			getByID: func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error) {
+				return &model.{{$m}}{}, nil
+			},
+		},
+		{
+			name: "not found",
+			getByID: func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+			wantErr: Err{{$m}}NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := New{{$m}}Service(&mock.{{$m}}Repo{GetByIDFunc: tt.getByID})
+
+			got, err := svc.GetByID(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetByID() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got == nil {
+				t.Fatal("GetByID() returned nil {{$m}}")
+			}
+		})
+	}
+}
+
+func Test{{$m}}Service_Create(t *testing.T) {
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		err     error // Mock Create 返回的错误
+		wantErr error
+	}{
+		{"ok", nil, nil},
+		{"repo error", errDB, errDB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mock.{{$m}}Repo{
+				CreateFunc: func(ctx context.Context, {{$v}} *model.{{$m}}) error {
+					return tt.err
+				},
+			}
+
+			err := New{{$m}}Service(repo).Create(context.Background(), &model.{{$m}}{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if n := repo.Calls("Create"); n != 1 {
+				t.Errorf("Create() called repository %d times, want 1", n)
+			}
+		})
+	}
+}
+`
+
+const controllerTestTemplate = `package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"{{.Spec.Project.Module}}/internal/model"
+	"{{.Spec.Project.Module}}/internal/repository/mock"
+	"{{.Spec.Project.Module}}/internal/service"
+)
+
+{{- $m := .Model.Name}}
+{{- $v := .Model.Name | ToLowerCamelCase}}
+{{- $preload := ""}}
+{{- if .Relations}}{{$preload = ", preloads ...string"}}{{end}}
+
+func Test{{$m}}Controller_GetByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	found := func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error) {
+		return &model.{{$m}}{}, nil
+	}
+	notFound := func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		getByID    func(ctx context.Context, id uint{{$preload}}) (*model.{{$m}}, error)
+		wantStatus int
+	}{
+		{"found", "/{{pluralize $v}}/1", found, http.StatusOK},
+		{"not found", "/{{pluralize $v}}/1", notFound, http.StatusNotFound},
+		{"invalid id", "/{{pluralize $v}}/abc", found, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New{{$m}}Controller(service.New{{$m}}Service(&mock.{{$m}}Repo{GetByIDFunc: tt.getByID}))
+			r := gin.New()
+			r.GET("/{{pluralize $v}}/:id", c.GetByID)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GET %s status = %d, want %d, body: %s", tt.path, w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+`
+
+const protoTemplate = `// Code generated by go-start. DO NOT EDIT.
+//
+// 字段编号按 spec 中的字段顺序分配，调整字段时请在 spec 末尾追加，避免已部署客户端的编号错位。
+
+syntax = "proto3";
+
+package {{.ProtoPackage}};
+
+option go_package = "{{.GoPackage}};{{.GoAlias}}";
+
+import "google/protobuf/empty.proto";
+{{- if .Timestamp}}
+import "google/protobuf/timestamp.proto";
+{{- end}}
+
+{{- $m := .Model.Name}}
+{{- $list := pluralize .Model.Name}}
+
+// {{$m}} {{.Model.Comment}}
+message {{$m}} {
+  {{- range .Fields}}
+  {{if .Repeated}}repeated {{else if .Optional}}optional {{end}}{{.Type}} {{.Name}} = {{.Number}};{{if .Comment}} // {{.Comment}}{{end}}
+  {{- end}}
+}
+
+message Create{{$m}}Request {
+  {{$m}} {{.Message}} = 1;
+}
+
+message Create{{$m}}Response {
+  uint64 id = 1;
+}
+
+message Get{{$m}}Request {
+  uint64 id = 1;
+}
+
+message Update{{$m}}Request {
+  {{$m}} {{.Message}} = 1; // 按主键更新
+}
+
+message Delete{{$m}}Request {
+  uint64 id = 1;
+}
+{{- if eq .Paging.Mode "cursor"}}
+
+message List{{$list}}Request {
+  string cursor = 1;    // 上一页返回的 next_cursor，第一页为空
+  int32 page_size = 2;  // 默认 {{.Paging.PageSize}}，最大 {{.Paging.MaxPageSize}}
+}
+
+message List{{$list}}Response {
+  repeated {{$m}} items = 1;
+  string next_cursor = 2; // 没有更多数据时为空
+}
+{{- else}}
+
+message List{{$list}}Request {
+  int32 page = 1;       // 从 1 开始
+  int32 page_size = 2;  // 默认 {{.Paging.PageSize}}，最大 {{.Paging.MaxPageSize}}
+}
+
+message List{{$list}}Response {
+  repeated {{$m}} items = 1;
+  int64 total = 2;
+  int32 page = 3;
+  int32 page_size = 4;
+}
+{{- end}}
+
+// {{$m}}Service {{$m}} 的 CRUD 服务，与 HTTP 接口共用同一个 service.{{$m}}Service
+service {{$m}}Service {
+  rpc Create{{$m}}(Create{{$m}}Request) returns (Create{{$m}}Response);
+  rpc Get{{$m}}(Get{{$m}}Request) returns ({{$m}});
+  rpc Update{{$m}}(Update{{$m}}Request) returns (google.protobuf.Empty);
+  rpc Delete{{$m}}(Delete{{$m}}Request) returns (google.protobuf.Empty);
+  rpc List{{$list}}(List{{$list}}Request) returns (List{{$list}}Response);
+}
+`
+
+const grpcServerTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	{{- if .Fallible}}
+	"fmt"
+	{{- end}}
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "{{.GoPackage}}"
+	"{{.Spec.Project.Module}}/internal/model"
+	"{{.Spec.Project.Module}}/internal/service"
+)
+
+{{- $m := .Model.Name}}
+{{- $v := .Model.Name | ToLowerCamelCase}}
+{{- $list := pluralize .Model.Name}}
+
+// {{$m}}Server 实现 pb.{{$m}}ServiceServer，业务逻辑委托给与 Gin 控制器共用的 service.{{$m}}Service
+type {{$m}}Server struct {
+	pb.Unimplemented{{$m}}ServiceServer
+	service *service.{{$m}}Service
+}
+
+// New{{$m}}Server 创建 {{$m}} gRPC 服务
+func New{{$m}}Server(service *service.{{$m}}Service) *{{$m}}Server {
+	return &{{$m}}Server{service: service}
+}
+
+// Create{{$m}} 创建 {{$m}}
+func (s *{{$m}}Server) Create{{$m}}(ctx context.Context, req *pb.Create{{$m}}Request) (*pb.Create{{$m}}Response, error) {
+	{{$v}}, err := {{$v}}FromPB(req.Get{{$m}}())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.service.Create(ctx, {{$v}}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Create{{$m}}Response{Id: uint64({{$v}}.{{.PK}})}, nil
+}
+
+// Get{{$m}} 根据 ID 获取 {{$m}}
+func (s *{{$m}}Server) Get{{$m}}(ctx context.Context, req *pb.Get{{$m}}Request) (*pb.{{$m}}, error) {
+	{{$v}}, err := s.service.GetByID(ctx, uint(req.GetId()))
+	if err != nil {
+		if errors.Is(err, service.Err{{$m}}NotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return {{$v}}ToPB({{$v}}), nil
+}
+
+// Update{{$m}} 按主键更新 {{$m}}
+func (s *{{$m}}Server) Update{{$m}}(ctx context.Context, req *pb.Update{{$m}}Request) (*emptypb.Empty, error) {
+	{{$v}}, err := {{$v}}FromPB(req.Get{{$m}}())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if {{$v}}.{{.PK}} == 0 {
+		return nil, status.Error(codes.InvalidArgument, "缺少 id")
+	}
+	if err := s.service.Update(ctx, {{$v}}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Delete{{$m}} 删除 {{$m}}
+func (s *{{$m}}Server) Delete{{$m}}(ctx context.Context, req *pb.Delete{{$m}}Request) (*emptypb.Empty, error) {
+	if err := s.service.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+{{- if eq .Paging.Mode "cursor"}}
+
+// List{{$list}} 按游标分页获取 {{$m}} 列表
+func (s *{{$m}}Server) List{{$list}}(ctx context.Context, req *pb.List{{$list}}Request) (*pb.List{{$list}}Response, error) {
+	items, next, _, err := s.service.ListAfter(ctx, req.GetCursor(), int(req.GetPageSize()))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.List{{$list}}Response{NextCursor: next}
+	for _, item := range items {
+		resp.Items = append(resp.Items, {{$v}}ToPB(item))
+	}
+	return resp, nil
+}
+{{- else}}
+
+// List{{$list}} 分页获取 {{$m}} 列表
+func (s *{{$m}}Server) List{{$list}}(ctx context.Context, req *pb.List{{$list}}Request) (*pb.List{{$list}}Response, error) {
+	page, pageSize := int(req.GetPage()), int(req.GetPageSize())
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > {{.Paging.MaxPageSize}} {
+		pageSize = {{.Paging.PageSize}}
+	}
+
+	items, total, err := s.service.List(ctx, page, pageSize)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.List{{$list}}Response{Total: total, Page: int32(page), PageSize: int32(pageSize)}
+	for _, item := range items {
+		resp.Items = append(resp.Items, {{$v}}ToPB(item))
+	}
+	return resp, nil
+}
+{{- end}}
+
+// {{$v}}ToPB 把模型转换为 pb 消息
+func {{$v}}ToPB(m *model.{{$m}}) *pb.{{$m}} {
+	return &pb.{{$m}}{
+		{{- range .Fields}}
+		{{.PBName}}: {{.ToPB}},
+		{{- end}}
+	}
+}
+
+// {{$v}}FromPB 把 pb 消息转换为模型，消息为空时返回空模型
+func {{$v}}FromPB(p *pb.{{$m}}) (*model.{{$m}}, error) {
+	if p == nil {
+		return &model.{{$m}}{}, nil
+	}
+	m := &model.{{$m}}{
+		{{- range .Fields}}
+		{{- if not .Fallible}}
+		{{.GoName}}: {{.FromPB}},
+		{{- end}}
+		{{- end}}
+	}
+	{{- if .Fallible}}
+
+	var err error
+	{{- end}}
+	{{- range .Fields}}
+	{{- if .Fallible}}
+	if m.{{.GoName}}, err = {{.FromPB}}; err != nil {
+		return nil, fmt.Errorf("{{.Name}}: %w", err)
+	}
+	{{- end}}
+	{{- end}}
+	return m, nil
+}
+`
+
+const grpcRegisterTemplate = `// Code generated by go-start. DO NOT EDIT.
+
+// Package grpcserver 实现 api/proto 中定义的 gRPC 服务，业务逻辑委托给与 Gin 控制器共用的 service 层
+package grpcserver
+
+import (
+	"context"
+	{{- if .Decimal}}
+	"errors"
+	{{- end}}
+	"strings"
+	"time"
+
+	{{if .Decimal}}"github.com/shopspring/decimal"
+	{{end}}"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "{{.GoPackage}}"
+	"{{.Spec.Project.Module}}/internal/service"
+	"github.com/Martindeeepdark/go-start/pkg/commonadapter"
+)
+
+// Services gRPC 服务依赖的业务服务，与 Gin 控制器使用同一组实例
+type Services struct {
+	{{- range .Spec.Models}}
+	{{.Name}} *service.{{.Name}}Service
+	{{- end}}
+}
+
+// Register 在 gRPC 服务器上注册所有服务
+//
+//	srv := grpc.NewServer(grpc.UnaryInterceptor(grpcserver.AuthInterceptor()))
+//	grpcserver.Register(srv, &grpcserver.Services{...})
+func Register(s grpc.ServiceRegistrar, services *Services) {
+	{{- range .Spec.Models}}
+	pb.Register{{.Name}}ServiceServer(s, New{{.Name}}Server(services.{{.Name}}))
+	{{- end}}
+}
+
+// methodAuth 需要认证的方法及所需权限（只需登录时为空），与 spec 端点的 auth、permission 一致
+var methodAuth = map[string]string{
+	{{- range .Methods}}
+	"{{.}}": "{{index $.MethodAuth .}}",
+	{{- end}}
+}
+
+// AuthInterceptor 按 spec 的认证要求校验 metadata 中的 authorization: Bearer <token>
+func AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		permission, ok := methodAuth[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = values[0]
+			}
+		}
+		if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+			token = token[7:]
+		}
+
+		auth, _, _, _, _, _ := commonadapter.Abilities()
+		userID, err := auth.VerifyToken(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "未授权")
+		}
+		if permission != "" {
+			if err := auth.RequirePermission(userID, permission); err != nil {
+				return nil, status.Error(codes.PermissionDenied, "权限不足")
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// numberPtr 转换可空数值（包括整数枚举）的类型
+func numberPtr[To, From number](v *From) *To {
+	if v == nil {
+		return nil
+	}
+	t := To(*v)
+	return &t
+}
+
+// stringPtr 转换可空字符串（包括字符串枚举）的类型
+func stringPtr[To, From ~string](v *From) *To {
+	if v == nil {
+		return nil
+	}
+	t := To(*v)
+	return &t
+}
+
+// fromTimestamp 把 Timestamp 转换为 time.Time，未设置时为零值
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// timePtr 把 Timestamp 转换为可空时间
+func timePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// timestampPtr 把可空时间转换为 Timestamp
+func timestampPtr(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+{{- if .Decimal}}
+
+// decimalFromString 解析 decimal，空字符串为 0
+func decimalFromString(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, errors.New("无效的数值: " + s)
+	}
+	return d, nil
+}
+
+// decimalPtr 解析可空 decimal
+func decimalPtr(s *string) (*decimal.Decimal, error) {
+	if s == nil {
+		return nil, nil
+	}
+	d, err := decimalFromString(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// decimalString 把可空 decimal 转换为字符串
+func decimalString(d *decimal.Decimal) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.String()
+	return &s
+}
+{{- end}}
+`
+
+const protoGenerateTemplate = `// Package proto 存放 gRPC 服务的 .proto 定义
+//
+// 修改 spec 后重新执行 go-start spec generate --target=grpc，再执行 go generate ./api/proto 生成 Go 代码，
+// 需要安装 protoc、protoc-gen-go 与 protoc-gen-go-grpc。
+package proto
+
+//go:generate protoc --proto_path=. --go_out=../.. --go_opt=module={{.Spec.Project.Module}} --go-grpc_out=../.. --go-grpc_opt=module={{.Spec.Project.Module}}{{range .ProtoFiles}} {{.}}{{end}}
+`
+
 // getBuiltinTemplate 获取内置模板内容
 func getBuiltinTemplate(name string) string {
 	templates := map[string]string{
 		"model.go.tmpl":      modelTemplate,
 		"repository.go.tmpl": repositoryTemplate,
 		"service.go.tmpl":    serviceTemplate,
+		"cursor.go.tmpl":     serviceCursorTemplate,
 		"controller.go.tmpl": controllerTemplate,
 		"routes.go.tmpl":     routesTemplate,
 		"validator.go.tmpl":  validatorTemplate,
+		"preload.go.tmpl":    preloadTemplate,
+
+		"handler_controller.go.tmpl":        handlerControllerTemplate,
+		"handler_controller_common.go.tmpl": handlerControllerCommonTemplate,
+		"handler_service.go.tmpl":           handlerServiceTemplate,
+		"handler_service_common.go.tmpl":    handlerServiceCommonTemplate,
+		"hooks.go.tmpl":                     hooksTemplate,
+		"rules.go.tmpl":                     rulesTemplate,
+
+		"mock_common.go.tmpl":     mockCommonTemplate,
+		"mock.go.tmpl":            mockTemplate,
+		"service_test.go.tmpl":    serviceTestTemplate,
+		"controller_test.go.tmpl": controllerTestTemplate,
+
+		"proto.tmpl":             protoTemplate,
+		"grpc_server.go.tmpl":    grpcServerTemplate,
+		"grpc_register.go.tmpl":  grpcRegisterTemplate,
+		"proto_generate.go.tmpl": protoGenerateTemplate,
 	}
 
 	return templates[name]
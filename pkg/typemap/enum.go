@@ -0,0 +1,272 @@
+package typemap
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Enum 枚举列对应的 Go 类型
+//
+// 数据库 ENUM 列、PostgreSQL 枚举类型以及 spec 中带 enum 的字段都会生成一个具名类型，
+// 附带常量、String()/IsValid()/Value() 方法与 JSON 编解码（拒绝不合法的取值），
+// 整数枚举的 JSON 同时接受数值与取值名称。
+type Enum struct {
+	TypeName string      // Go 类型名，如 ArticleStatus
+	BaseType string      // 底层类型：string 或整数类型
+	Comment  string      // 类型注释
+	Values   []EnumValue // 可选值
+}
+
+// EnumValue 枚举的一个取值
+type EnumValue struct {
+	Name  string // 取值名称，用于生成常量名及 JSON 中的名称，如 draft
+	Value string // 取值，字符串枚举与 Name 相同，整数枚举为数字
+	Label string // 可读名称（String() 的返回值），为空时使用 Name
+	Const string // Go 常量名，由 Normalize 生成，如 ArticleStatusDraft
+}
+
+// NewStringEnum 由字符串取值列表创建字符串枚举
+func NewStringEnum(typeName, comment string, values []string) Enum {
+	e := Enum{TypeName: typeName, BaseType: "string", Comment: comment}
+	for _, v := range values {
+		e.Values = append(e.Values, EnumValue{Name: v, Value: v})
+	}
+	e.Normalize()
+	return e
+}
+
+// Normalize 补全常量名与可读名称；常量名冲突时追加序号
+func (e *Enum) Normalize() {
+	used := make(map[string]bool)
+	for i := range e.Values {
+		v := &e.Values[i]
+		if v.Name == "" {
+			v.Name = v.Value
+		}
+		if v.Value == "" {
+			v.Value = v.Name
+		}
+
+		name := e.TypeName + identifier(v.Name)
+		if name == e.TypeName {
+			name = fmt.Sprintf("%sValue%d", e.TypeName, i+1)
+		}
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s%s%d", e.TypeName, identifier(v.Name), n)
+		}
+		used[name] = true
+		v.Const = name
+	}
+}
+
+// Numeric 是否为整数枚举
+func (e Enum) Numeric() bool {
+	return e.BaseType != "string"
+}
+
+// Validate 校验整数枚举的取值
+func (e Enum) Validate() error {
+	if len(e.Values) == 0 {
+		return fmt.Errorf("枚举 %s 没有可选值", e.TypeName)
+	}
+	if !e.Numeric() {
+		return nil
+	}
+	for _, v := range e.Values {
+		if _, err := strconv.ParseInt(v.Value, 10, 64); err != nil {
+			return fmt.Errorf("枚举 %s 的取值 %q 不是整数", e.TypeName, v.Value)
+		}
+	}
+	return nil
+}
+
+// Literal 取值的 Go 字面量
+func (v EnumValue) Literal(numeric bool) string {
+	if numeric {
+		return v.Value
+	}
+	return strconv.Quote(v.Value)
+}
+
+// DisplayName String() 返回的名称
+func (v EnumValue) DisplayName() string {
+	if v.Label != "" {
+		return v.Label
+	}
+	return v.Name
+}
+
+// OneOf 生成 validator 的 oneof 参数（如 oneof=draft published），
+// 取值含有无法写入结构体标签的字符时返回空
+func (e Enum) OneOf() string {
+	var parts []string
+	for _, v := range e.Values {
+		if strings.ContainsAny(v.Value, "\"`'\\|") {
+			return ""
+		}
+		value := strings.ReplaceAll(v.Value, ",", "0x2C")
+		if strings.ContainsAny(value, " \t") {
+			value = "'" + value + "'"
+		}
+		parts = append(parts, value)
+	}
+	return "oneof=" + strings.Join(parts, " ")
+}
+
+// BindingTag 生成 gin 的 binding 标签内容，可空或可省略的字段允许零值
+func (e Enum) BindingTag() string {
+	oneOf := e.OneOf()
+	if oneOf == "" {
+		return ""
+	}
+	return "omitempty," + oneOf
+}
+
+// RenderEnums 生成包含枚举类型定义的 Go 源文件
+func RenderEnums(pkg string, enums []Enum) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := enumTmpl.Execute(&buf, map[string]interface{}{
+		"Package": pkg,
+		"Enums":   enums,
+	}); err != nil {
+		return nil, fmt.Errorf("生成枚举代码失败: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化枚举代码失败: %w", err)
+	}
+	return src, nil
+}
+
+// identifier 把取值转换为常量名后缀：in_progress -> InProgress，1 -> V1
+func identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	result := b.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "V" + result
+	}
+	return result
+}
+
+var enumTmpl = template.Must(template.New("enum").Parse(`// Code generated by go-start. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+{{range $e := .Enums}}
+// {{$e.TypeName}} {{$e.Comment}}
+type {{$e.TypeName}} {{$e.BaseType}}
+
+// {{$e.TypeName}} 的可选值
+const (
+{{- range $e.Values}}
+	{{.Const}} {{$e.TypeName}} = {{.Literal $e.Numeric}}{{if .Label}} // {{.Label}}{{end}}
+{{- end}}
+)
+
+// {{$e.TypeName}}Values 返回 {{$e.TypeName}} 的全部可选值
+func {{$e.TypeName}}Values() []{{$e.TypeName}} {
+	return []{{$e.TypeName}}{ {{- range $i, $v := $e.Values}}{{if $i}}, {{end}}{{$v.Const}}{{end -}} }
+}
+
+// String 返回可读名称
+func (e {{$e.TypeName}}) String() string {
+	switch e {
+{{- range $e.Values}}
+	case {{.Const}}:
+		return {{printf "%q" .DisplayName}}
+{{- end}}
+	}
+	return fmt.Sprintf("{{$e.TypeName}}(%v)", {{$e.BaseType}}(e))
+}
+
+// IsValid 是否为合法取值
+func (e {{$e.TypeName}}) IsValid() bool {
+	switch e {
+	case {{range $i, $v := $e.Values}}{{if $i}}, {{end}}{{$v.Const}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// Value 实现 driver.Valuer，枚举可直接作为 GORM Gen 的查询条件
+func (e {{$e.TypeName}}) Value() (driver.Value, error) {
+	return {{if $e.Numeric}}int64{{else}}string{{end}}(e), nil
+}
+{{- if $e.Numeric}}
+
+// MarshalJSON 输出数值
+func (e {{$e.TypeName}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal({{$e.BaseType}}(e))
+}
+
+// UnmarshalJSON 接受数值或取值名称（如 {{printf "%q" (index $e.Values 0).Name}}），拒绝不合法的取值
+func (e *{{$e.TypeName}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		switch name {
+{{- range $e.Values}}
+		case {{printf "%q" .Name}}:
+			*e = {{.Const}}
+			return nil
+{{- end}}
+		}
+		return fmt.Errorf("无效的 {{$e.TypeName}}: %q", name)
+	}
+
+	var value {{$e.BaseType}}
+	if err := json.Unmarshal(data, &value); err != nil || !{{$e.TypeName}}(value).IsValid() {
+		return fmt.Errorf("无效的 {{$e.TypeName}}: %s", data)
+	}
+	*e = {{$e.TypeName}}(value)
+	return nil
+}
+{{- else}}
+
+// MarshalJSON 输出取值
+func (e {{$e.TypeName}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON 只接受可选值，拒绝不合法的取值
+func (e *{{$e.TypeName}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil || !{{$e.TypeName}}(value).IsValid() {
+		return fmt.Errorf("无效的 {{$e.TypeName}}: %s", data)
+	}
+	*e = {{$e.TypeName}}(value)
+	return nil
+}
+{{- end}}
+{{end}}`))
@@ -0,0 +1,75 @@
+package typemap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Martindeeepdark/go-start/pkg/internal/buildtest"
+)
+
+// enumJSONTest 在生成的枚举包中运行的测试：合法取值可以往返编解码，不合法的取值被拒绝
+const enumJSONTest = `package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type record struct {
+	Status PostStatus ` + "`json:\"status\"`" + `
+	Level  Level      ` + "`json:\"level\"`" + `
+}
+
+func TestEnumJSON(t *testing.T) {
+	for _, data := range []string{
+		` + "`" + `{"status":"draft","level":"high"}` + "`" + `,
+		` + "`" + `{"status":"published","level":1}` + "`" + `,
+		` + "`" + `{"status":null,"level":null}` + "`" + `,
+	} {
+		var r record
+		if err := json.Unmarshal([]byte(data), &r); err != nil {
+			t.Errorf("Unmarshal(%s) unexpected error: %v", data, err)
+		}
+	}
+	for _, data := range []string{
+		` + "`" + `{"status":"archived"}` + "`" + `,
+		` + "`" + `{"status":""}` + "`" + `,
+		` + "`" + `{"status":1}` + "`" + `,
+		` + "`" + `{"level":3}` + "`" + `,
+		` + "`" + `{"level":"medium"}` + "`" + `,
+	} {
+		var r record
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want error", data, r)
+		}
+	}
+
+	out, err := json.Marshal(record{Status: PostStatusPublished, Level: LevelHigh})
+	if want := ` + "`" + `{"status":"published","level":2}` + "`" + `; err != nil || string(out) != want {
+		t.Errorf("Marshal() = %s, %v, want %s", out, err, want)
+	}
+}
+`
+
+// TestRenderEnumsJSON 验证字符串与整数枚举都生成 JSON 编解码，且解码时拒绝不合法的取值
+func TestRenderEnumsJSON(t *testing.T) {
+	level := Enum{TypeName: "Level", BaseType: "int8", Comment: "等级", Values: []EnumValue{
+		{Name: "low", Value: "1"},
+		{Name: "high", Value: "2"},
+	}}
+	level.Normalize()
+
+	src, err := RenderEnums("model", []Enum{NewStringEnum("PostStatus", "文章状态", []string{"draft", "published"}), level})
+	if err != nil {
+		t.Fatalf("RenderEnums() unexpected error: %v", err)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"enums.go": string(src), "enums_test.go": enumJSONTest} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buildtest.Go(t, dir, "example.com/model", "test", ".")
+}
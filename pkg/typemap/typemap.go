@@ -0,0 +1,283 @@
+// Package typemap 把数据库列类型映射为 Go 类型
+//
+// gen db、gen sql、DDD 生成器与 spec 生成器共用同一套规则，
+// 保证同一列在不同生成路径下得到一致的 Go 类型。
+package typemap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 可空列的映射策略
+const (
+	NullablePointer = "pointer"  // 可空列使用指针，如 *string（默认）
+	NullableSQLNull = "sql_null" // 可空列使用 sql.Null*，如 sql.NullString
+)
+
+// decimal/numeric 列的映射策略
+const (
+	DecimalString     = "string"     // 使用 string 保存，不丢失精度且无额外依赖（默认）
+	DecimalShopspring = "shopspring" // 使用 github.com/shopspring/decimal
+)
+
+// Options 类型映射配置（gen.yaml 与 spec 中的 types 配置块）
+//
+// 示例：
+//
+//	types:
+//	  nullable: sql_null
+//	  decimal: shopspring
+type Options struct {
+	Nullable string `yaml:"nullable"` // pointer 或 sql_null
+	Decimal  string `yaml:"decimal"`  // string 或 shopspring
+}
+
+// Validate 校验配置
+func (o Options) Validate() error {
+	switch o.Nullable {
+	case "", NullablePointer, NullableSQLNull:
+	default:
+		return fmt.Errorf("不支持的可空类型策略: %s (支持: %s, %s)", o.Nullable, NullablePointer, NullableSQLNull)
+	}
+	switch o.Decimal {
+	case "", DecimalString, DecimalShopspring:
+	default:
+		return fmt.Errorf("不支持的 decimal 类型策略: %s (支持: %s, %s)", o.Decimal, DecimalString, DecimalShopspring)
+	}
+	return nil
+}
+
+// Column 列的类型信息
+type Column struct {
+	Type       string // 基础类型，如 varchar、bigint、numeric、int4（大小写不敏感）
+	ColumnType string // 完整类型，如 tinyint(1)、int unsigned、numeric(10,2)，可为空
+	Nullable   bool   // 是否可为空
+	PrimaryKey bool   // 是否主键（主键不按可空处理）
+}
+
+// GoType 返回列对应的 Go 类型，可空列按 Nullable 策略处理
+func (o Options) GoType(col Column) string {
+	base := o.BaseType(col)
+	if !col.Nullable || col.PrimaryKey {
+		return base
+	}
+	return o.NullableType(base)
+}
+
+// NullableType 返回可空列使用的 Go 类型：指针或 sql.Null*，
+// 切片、JSON、数组本身可以表示 NULL，保持不变
+func (o Options) NullableType(base string) string {
+	if nilable(base) || strings.HasPrefix(base, "*") {
+		return base
+	}
+
+	if o.Nullable == NullableSQLNull {
+		if wrapped, ok := sqlNullTypes[base]; ok {
+			return wrapped
+		}
+	}
+	return "*" + base
+}
+
+// BaseType 返回列对应的 Go 类型，不考虑可空
+func (o Options) BaseType(col Column) string {
+	dataType := normalize(col.Type)
+	full := strings.ToLower(strings.TrimSpace(col.ColumnType))
+	if full == "" {
+		full = dataType
+	}
+	unsigned := strings.Contains(full, "unsigned")
+
+	// PostgreSQL 数组：integer[]、_int4
+	if strings.HasSuffix(dataType, "[]") {
+		return arrayType(normalize(strings.TrimSuffix(dataType, "[]")))
+	}
+
+	switch dataType {
+	case "tinyint":
+		// MySQL 约定 tinyint(1) 表示布尔值
+		if strings.HasPrefix(full, "tinyint(1)") {
+			return "bool"
+		}
+		return signed("int8", unsigned)
+	case "bit":
+		if full == "bit" || strings.HasPrefix(full, "bit(1)") {
+			return "bool"
+		}
+		return "[]byte"
+	case "bool", "boolean":
+		return "bool"
+	case "smallint", "year":
+		return signed("int16", unsigned)
+	case "mediumint", "int", "integer":
+		return signed("int32", unsigned)
+	case "bigint":
+		return signed("int64", unsigned)
+	case "float", "real":
+		return "float32"
+	case "double", "double precision":
+		return "float64"
+	case "decimal", "numeric":
+		if o.Decimal == DecimalShopspring {
+			return "decimal.Decimal"
+		}
+		return "string"
+	case "json", "jsonb":
+		return "datatypes.JSON"
+	case "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob", "bytea":
+		return "[]byte"
+	case "date", "datetime", "timestamp", "timestamp with time zone", "timestamp without time zone":
+		return "time.Time"
+	}
+
+	// char/varchar/text/enum/set/uuid/time/interval/inet 等
+	return "string"
+}
+
+// ImportPath 返回 Go 类型需要导入的包，不需要导入时返回空
+func ImportPath(goType string) string {
+	goType = strings.TrimLeft(goType, "*[]")
+	idx := strings.Index(goType, ".")
+	if idx < 0 {
+		return ""
+	}
+	return importPaths[goType[:idx]]
+}
+
+// Imports 返回一组 Go 类型需要导入的包（去重并排序）
+func Imports(goTypes ...string) []string {
+	set := make(map[string]bool)
+	for _, goType := range goTypes {
+		if path := ImportPath(goType); path != "" {
+			set[path] = true
+		}
+	}
+
+	var result []string
+	for path := range set {
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Modules 返回 Go 类型依赖的第三方模块（用于生成 go.mod）
+func Modules(goTypes ...string) map[string]string {
+	result := make(map[string]string)
+	for _, path := range Imports(goTypes...) {
+		if version, ok := moduleVersions[path]; ok {
+			result[path] = version
+		}
+	}
+	return result
+}
+
+// KnownTypes 返回能识别的数据库类型名（含 PostgreSQL 的内部类型名），
+// 用于向 GORM Gen 注册自定义类型映射
+func KnownTypes() []string {
+	names := []string{
+		"tinyint", "bit", "bool", "boolean", "smallint", "year", "mediumint", "int", "integer", "bigint",
+		"float", "real", "double", "double precision", "decimal", "numeric", "json", "jsonb",
+		"binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob", "bytea",
+		"date", "datetime", "timestamp", "timestamp with time zone", "timestamp without time zone",
+		"char", "varchar", "character", "character varying", "text", "tinytext", "mediumtext", "longtext",
+		"enum", "set", "uuid", "time", "interval",
+	}
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	for _, elem := range []string{"smallint", "integer", "bigint", "text", "character varying", "boolean", "real", "double precision", "uuid"} {
+		names = append(names, elem+"[]")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// aliases PostgreSQL 内部类型名（udt_name）与常用别名
+var aliases = map[string]string{
+	"int2": "smallint", "int4": "integer", "int8": "bigint",
+	"serial": "integer", "serial4": "integer", "bigserial": "bigint", "serial8": "bigint",
+	"smallserial": "smallint", "serial2": "smallint",
+	"float4": "real", "float8": "double precision",
+	"bpchar": "character", "timestamptz": "timestamp with time zone", "timetz": "time",
+}
+
+// sqlNullTypes 可空列在 sql_null 策略下的类型，不在表中的类型回退为指针
+var sqlNullTypes = map[string]string{
+	"string":          "sql.NullString",
+	"bool":            "sql.NullBool",
+	"uint8":           "sql.NullByte",
+	"int16":           "sql.NullInt16",
+	"int32":           "sql.NullInt32",
+	"int64":           "sql.NullInt64",
+	"float64":         "sql.NullFloat64",
+	"time.Time":       "sql.NullTime",
+	"decimal.Decimal": "decimal.NullDecimal",
+}
+
+// importPaths 类型所在包的导入路径
+var importPaths = map[string]string{
+	"time":      "time",
+	"sql":       "database/sql",
+	"datatypes": "gorm.io/datatypes",
+	"decimal":   "github.com/shopspring/decimal",
+	"pq":        "github.com/lib/pq",
+	"gorm":      "gorm.io/gorm",
+}
+
+// moduleVersions 第三方类型所在模块的版本
+var moduleVersions = map[string]string{
+	"gorm.io/datatypes":             "v1.2.7",
+	"github.com/shopspring/decimal": "v1.4.0",
+	"github.com/lib/pq":             "v1.10.9",
+}
+
+// normalize 规范化类型名：小写、去掉长度/精度、展开别名
+func normalize(dataType string) string {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	if idx := strings.Index(dataType, "("); idx > 0 {
+		dataType = strings.TrimSpace(dataType[:idx])
+	}
+	if strings.HasPrefix(dataType, "_") {
+		// PostgreSQL 数组的内部类型名：_int4 -> integer[]
+		return normalize(dataType[1:]) + "[]"
+	}
+	if strings.HasPrefix(dataType, "timestamp with") {
+		return "timestamp with time zone"
+	}
+	if strings.HasPrefix(dataType, "timestamp without") {
+		return "timestamp without time zone"
+	}
+	if alias, ok := aliases[dataType]; ok {
+		return alias
+	}
+	return dataType
+}
+
+// arrayType PostgreSQL 数组对应的 lib/pq 类型
+func arrayType(elem string) string {
+	switch elem {
+	case "smallint", "integer", "bigint":
+		return "pq.Int64Array"
+	case "real", "double precision":
+		return "pq.Float64Array"
+	case "boolean", "bool":
+		return "pq.BoolArray"
+	}
+	return "pq.StringArray"
+}
+
+// signed 按 unsigned 返回对应的整数类型
+func signed(goType string, unsigned bool) string {
+	if unsigned {
+		return "u" + goType
+	}
+	return goType
+}
+
+// nilable 类型本身可以表示 NULL（切片、JSON、数组），不需要指针或 sql.Null*
+func nilable(goType string) bool {
+	return strings.HasPrefix(goType, "[]") || goType == "datatypes.JSON" || strings.HasPrefix(goType, "pq.")
+}
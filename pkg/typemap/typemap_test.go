@@ -0,0 +1,53 @@
+package typemap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		col  Column
+		want string
+	}{
+		{"tinyint(1) 视为布尔", Options{}, Column{Type: "tinyint", ColumnType: "tinyint(1)"}, "bool"},
+		{"tinyint", Options{}, Column{Type: "tinyint", ColumnType: "tinyint(4)"}, "int8"},
+		{"unsigned", Options{}, Column{Type: "int", ColumnType: "int unsigned"}, "uint32"},
+		{"bigint unsigned", Options{}, Column{Type: "bigint", ColumnType: "bigint(20) unsigned"}, "uint64"},
+		{"PostgreSQL udt_name", Options{}, Column{Type: "int8"}, "int64"},
+		{"decimal 默认 string", Options{}, Column{Type: "decimal", ColumnType: "decimal(10,2)"}, "string"},
+		{"decimal shopspring", Options{Decimal: DecimalShopspring}, Column{Type: "numeric"}, "decimal.Decimal"},
+		{"jsonb", Options{}, Column{Type: "jsonb", Nullable: true}, "datatypes.JSON"},
+		{"timestamptz", Options{}, Column{Type: "timestamptz"}, "time.Time"},
+		{"数组", Options{}, Column{Type: "integer[]"}, "pq.Int64Array"},
+		{"数组 udt_name", Options{}, Column{Type: "_text"}, "pq.StringArray"},
+		{"bytea 可空不加指针", Options{}, Column{Type: "bytea", Nullable: true}, "[]byte"},
+		{"可空默认指针", Options{}, Column{Type: "varchar", Nullable: true}, "*string"},
+		{"可空主键不加指针", Options{}, Column{Type: "bigint", Nullable: true, PrimaryKey: true}, "int64"},
+		{"sql_null", Options{Nullable: NullableSQLNull}, Column{Type: "datetime", Nullable: true}, "sql.NullTime"},
+		{"sql_null decimal", Options{Nullable: NullableSQLNull, Decimal: DecimalShopspring}, Column{Type: "decimal", Nullable: true}, "decimal.NullDecimal"},
+		{"sql_null 无对应类型时回退为指针", Options{Nullable: NullableSQLNull}, Column{Type: "int", ColumnType: "int unsigned", Nullable: true}, "*uint32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.GoType(tt.col); got != tt.want {
+				t.Errorf("GoType(%+v) = %q, want %q", tt.col, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImports(t *testing.T) {
+	got := Imports("*time.Time", "sql.NullString", "datatypes.JSON", "string", "[]byte", "time.Time")
+	want := []string{"database/sql", "gorm.io/datatypes", "time"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Imports() = %v, want %v", got, want)
+	}
+
+	if err := (Options{Nullable: "null"}).Validate(); err == nil {
+		t.Error("Validate() 应拒绝未知的可空类型策略")
+	}
+}
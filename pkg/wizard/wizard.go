@@ -481,10 +481,17 @@ func (w *Wizard) askAuth(config *ProjectConfig) error {
 	fmt.Println("  • 密码加密存储")
 	fmt.Println("  • 权限控制中间件")
 
+	// DDD 模板暂不包含认证系统，默认不启用
+	defaultAnswer := "y"
+	if config.Architecture == "ddd" {
+		fmt.Println("  ⚠️  DDD 架构暂不支持认证系统，选择 y 时创建项目会失败")
+		defaultAnswer = "n"
+	}
+
 	answer, err := w.ask(Question{
 		Text:     "是否需要认证系统？(y/n)",
 		Options:  []string{"y", "n", "yes", "no"},
-		Default:  "y",
+		Default:  defaultAnswer,
 		Required: true,
 	})
 	if err != nil {